@@ -9,9 +9,10 @@ import (
 	argocdv1alpha1 "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
 	"github.com/gruntwork-io/terratest/modules/k8s"
 
+	"github.com/davidcollom/terratest-utils/pkg/wait"
 	"github.com/stretchr/testify/require"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
 )
 
 // ListApplications retrieves a list of Argo CD Application resources from the specified namespace.
@@ -54,7 +55,7 @@ func ListApplicationsE(t testing.TestingT, options *k8s.KubectlOptions, namespac
 
 // WaitForApplicationHealthyAndSynced waits until the specified Argo CD Application resource
 // in the given namespace reaches both "Healthy" and "Synced" status within the provided timeout.
-// It polls the Application status every 2 seconds using the Argo CD client and fails the test
+// It watches the Application status, falling back to polling, using the Argo CD client and fails the test
 // if the desired state is not achieved within the timeout period.
 //
 // Parameters:
@@ -65,32 +66,81 @@ func ListApplicationsE(t testing.TestingT, options *k8s.KubectlOptions, namespac
 //	namespace- The namespace where the Application resides.
 //	timeout  - The maximum duration to wait for the Application to become Healthy and Synced.
 //
-// Fails the test if the Application does not reach the desired state within the timeout.
+// Fails the test if the Application does not reach the desired state within the timeout, or
+// immediately if its sync operation fails.
 // WaitForApplicationHealthyAndSynced waits for the resource condition to be satisfied.
-func WaitForApplicationHealthyAndSynced(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) {
-	err := WaitForApplicationHealthyAndSyncedE(t, options, name, namespace, timeout)
+func WaitForApplicationHealthyAndSynced(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) {
+	err := WaitForApplicationHealthyAndSyncedE(t, options, name, namespace, timeout, opts...)
 	require.NoError(t, err, "Application %s/%s did not become Healthy & Synced", namespace, name)
 }
 
 // WaitForApplicationHealthyAndSyncedE waits until the specified Argo CD Application resource
 // reaches both Healthy and Synced status within the provided timeout.
+// It returns a *wait.TerminalStateError as soon as the Application's sync operation fails or errors.
 // WaitForApplicationHealthyAndSyncedE waits for the resource condition to be satisfied.
-func WaitForApplicationHealthyAndSyncedE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) error {
+func WaitForApplicationHealthyAndSyncedE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) error {
 	client, err := NewArgoCDClient(t, options)
 	if err != nil {
 		return err
 	}
 
-	ctx := context.Background()
-	return wait.PollUntilContextTimeout(ctx, 2*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
-		app, err := client.ArgoprojV1alpha1().Applications(namespace).Get(ctx, name, metav1.GetOptions{})
-		if err != nil {
-			return false, nil
-		}
+	_, err = wait.Waiter[*argocdv1alpha1.Application]{
+		Kind:      "Application",
+		Name:      name,
+		Namespace: namespace,
+		Timeout:   timeout,
+		Get: func(ctx context.Context) (*argocdv1alpha1.Application, error) {
+			return client.ArgoprojV1alpha1().Applications(namespace).Get(ctx, name, metav1.GetOptions{})
+		},
+		Watch: func(ctx context.Context) (watch.Interface, error) {
+			return client.ArgoprojV1alpha1().Applications(namespace).Watch(ctx, metav1.SingleObject(metav1.ObjectMeta{Name: name}))
+		},
+		Ready:  IsApplicationHealthyAndSynced,
+		Failed: applicationSyncFailed,
+		Events: wait.KubeEvents(t, options),
+	}.Wait(t, opts...)
+	return err
+}
+
+// WaitForApplicationDeleted waits until the specified Argo CD Application resource in the given
+// namespace no longer exists within the provided timeout, failing the test otherwise.
+//
+// Parameters:
+//
+//	t        - The testing context.
+//	options  - Kubectl options containing the Kubernetes REST config.
+//	name     - The name of the Argo CD Application.
+//	namespace- The namespace where the Application resides.
+//	timeout  - The maximum duration to wait for the Application to be deleted.
+func WaitForApplicationDeleted(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) {
+	err := WaitForApplicationDeletedE(t, options, name, namespace, timeout, opts...)
+	require.NoError(t, err, "Application %s/%s was not deleted", namespace, name)
+}
+
+// WaitForApplicationDeletedE waits until the Application is gone. It does not delete the
+// Application. When the Application has the resources-finalizer.argocd.argoproj.io finalizer, Argo CD
+// deletes the resources it manages before removing the Application, so its disappearance shows the
+// cascade completed; on timeout the error lists the remaining finalizers and any DeletionError
+// condition.
+func WaitForApplicationDeletedE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) error {
+	client, err := NewArgoCDClient(t, options)
+	if err != nil {
+		return err
+	}
 
-		if IsApplicationHealthyAndSynced(app) {
-			return true, nil
-		}
-		return false, nil
-	})
+	_, err = wait.Waiter[*argocdv1alpha1.Application]{
+		Kind:         "Application",
+		Name:         name,
+		Namespace:    namespace,
+		Timeout:      timeout,
+		UntilDeleted: true,
+		Get: func(ctx context.Context) (*argocdv1alpha1.Application, error) {
+			return client.ArgoprojV1alpha1().Applications(namespace).Get(ctx, name, metav1.GetOptions{})
+		},
+		Watch: func(ctx context.Context) (watch.Interface, error) {
+			return client.ArgoprojV1alpha1().Applications(namespace).Watch(ctx, metav1.SingleObject(metav1.ObjectMeta{Name: name}))
+		},
+		Events: wait.KubeEvents(t, options),
+	}.Wait(t, opts...)
+	return err
 }
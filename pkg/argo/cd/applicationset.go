@@ -10,9 +10,10 @@ import (
 
 	"github.com/gruntwork-io/terratest/modules/k8s"
 
+	"github.com/davidcollom/terratest-utils/pkg/wait"
 	"github.com/stretchr/testify/require"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
 )
 
 // ListApplicationSets retrieves all Argo CD ApplicationSet resources in the specified namespace.
@@ -55,7 +56,7 @@ func ListApplicationSetsE(t testing.TestingT, options *k8s.KubectlOptions, names
 
 // WaitForApplicationSetHealthyAndSynced waits until the specified Argo CD ApplicationSet in the given namespace
 // is healthy and its resources are up to date, or until the provided timeout is reached.
-// It polls the ApplicationSet status every 2 seconds, checking for the "ResourcesUpToDate" condition with a "True" status.
+// It watches the ApplicationSet status, falling back to polling, checking for the "ResourcesUpToDate" condition with a "True" status.
 // If the ApplicationSet does not become healthy and synced within the timeout, the test fails.
 // Parameters:
 //   - t: The testing context.
@@ -65,32 +66,40 @@ func ListApplicationSetsE(t testing.TestingT, options *k8s.KubectlOptions, names
 //   - timeout: The maximum duration to wait for the ApplicationSet to become healthy and synced.
 //
 // WaitForApplicationSetHealthyAndSynced waits for the resource condition to be satisfied.
-func WaitForApplicationSetHealthyAndSynced(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) {
-	err := WaitForApplicationSetHealthyAndSyncedE(t, options, name, namespace, timeout)
+func WaitForApplicationSetHealthyAndSynced(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) {
+	err := WaitForApplicationSetHealthyAndSyncedE(t, options, name, namespace, timeout, opts...)
 	require.NoError(t, err, "ApplicationSet %s/%s did not become Healthy & Synced", namespace, name)
 }
 
 // WaitForApplicationSetHealthyAndSyncedE waits until the specified Argo CD ApplicationSet
 // reports the resources-up-to-date condition within the provided timeout.
 // WaitForApplicationSetHealthyAndSyncedE waits for the resource condition to be satisfied.
-func WaitForApplicationSetHealthyAndSyncedE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) error {
+func WaitForApplicationSetHealthyAndSyncedE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) error {
 	client, err := NewArgoCDClient(t, options)
 	if err != nil {
 		return err
 	}
 
-	ctx := context.Background()
-	return wait.PollUntilContextTimeout(ctx, 2*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
-		app, err := client.ArgoprojV1alpha1().ApplicationSets(namespace).Get(ctx, name, metav1.GetOptions{})
-		if err != nil {
-			return false, nil
-		}
-
-		for _, cond := range app.Status.Conditions {
-			if cond.Type == argocdv1alpha1.ApplicationSetConditionResourcesUpToDate && cond.Status == argocdv1alpha1.ApplicationSetConditionStatusTrue {
-				return true, nil
+	_, err = wait.Waiter[*argocdv1alpha1.ApplicationSet]{
+		Kind:      "ApplicationSet",
+		Name:      name,
+		Namespace: namespace,
+		Timeout:   timeout,
+		Get: func(ctx context.Context) (*argocdv1alpha1.ApplicationSet, error) {
+			return client.ArgoprojV1alpha1().ApplicationSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		},
+		Watch: func(ctx context.Context) (watch.Interface, error) {
+			return client.ArgoprojV1alpha1().ApplicationSets(namespace).Watch(ctx, metav1.SingleObject(metav1.ObjectMeta{Name: name}))
+		},
+		Ready: func(appSet *argocdv1alpha1.ApplicationSet) bool {
+			for _, cond := range appSet.Status.Conditions {
+				if cond.Type == argocdv1alpha1.ApplicationSetConditionResourcesUpToDate && cond.Status == argocdv1alpha1.ApplicationSetConditionStatusTrue {
+					return true
+				}
 			}
-		}
-		return false, nil
-	})
+			return false
+		},
+		Events: wait.KubeEvents(t, options),
+	}.Wait(t, opts...)
+	return err
 }
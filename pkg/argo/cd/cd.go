@@ -9,7 +9,8 @@ import (
 	apphealth "github.com/argoproj/argo-cd/gitops-engine/pkg/health"
 	argocdv1alpha1 "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
 	argocd "github.com/argoproj/argo-cd/v3/pkg/client/clientset/versioned"
-	"github.com/davidcollom/terratest-utils/pkg/utils"
+	"github.com/davidcollom/terratest-utils/pkg/clients"
+	"github.com/davidcollom/terratest-utils/pkg/wait"
 	"github.com/gruntwork-io/terratest/modules/k8s"
 	"k8s.io/client-go/rest"
 )
@@ -22,9 +23,24 @@ func IsApplicationHealthyAndSynced(app *argocdv1alpha1.Application) bool {
 		app.Status.Sync.Status == argocdv1alpha1.SyncStatusCodeSynced
 }
 
+// applicationSyncFailed reports an Application whose last sync operation completed unsuccessfully
+// (Failed or Error) for the revision it is currently comparing against as a terminal state. Argo
+// CD does not retry an automated sync of the same revision once it has failed, so the Application
+// will not become Synced on its own. A failed sync of an earlier revision is ignored, since Argo
+// CD has yet to sync the new one.
+func applicationSyncFailed(app *argocdv1alpha1.Application) error {
+	op := app.Status.OperationState
+	if op != nil && op.Phase.Completed() && !op.Phase.Successful() &&
+		op.SyncResult != nil && op.SyncResult.Revision == app.Status.Sync.Revision {
+		return &wait.TerminalStateError{Phase: string(op.Phase), Message: op.Message}
+	}
+	return nil
+}
+
 // NewArgoCDClient creates a new ArgoCD client interface for use in tests.
 // This function attempts to use the REST configuration from options if available,
-// otherwise it will create a new REST configuration using the kubectl options.
+// otherwise it will create a new REST configuration using the kubectl options. The client is cached
+// per cluster by pkg/clients.
 //
 // Parameters:
 //   - t: Testing context that implements the testing.TB interface
@@ -36,16 +52,7 @@ func IsApplicationHealthyAndSynced(app *argocdv1alpha1.Application) bool {
 var NewArgoCDClient = newArgoCDClient
 
 func newArgoCDClient(t testing.TestingT, options *k8s.KubectlOptions) (argocd.Interface, error) {
-	var cfg *rest.Config
-	var err error
-	if options.RestConfig == nil {
-		cfg, err = utils.GetRestConfigE(t, options)
-		if err != nil {
-			return nil, err
-		}
-	} else {
-		cfg = options.RestConfig
-	}
-
-	return argocd.NewForConfig(cfg)
+	return clients.Get(t, options, "argo-cd", func(cfg *rest.Config) (argocd.Interface, error) {
+		return argocd.NewForConfig(cfg)
+	})
 }
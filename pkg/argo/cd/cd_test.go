@@ -0,0 +1,98 @@
+package cd
+
+import (
+	"testing"
+	"time"
+
+	apphealth "github.com/argoproj/argo-cd/gitops-engine/pkg/health"
+	synccommon "github.com/argoproj/argo-cd/gitops-engine/pkg/sync/common"
+	argocdv1alpha1 "github.com/argoproj/argo-cd/v3/pkg/apis/application/v1alpha1"
+	"github.com/davidcollom/terratest-utils/pkg/wait"
+	"github.com/davidcollom/terratest-utils/pkg/waittest"
+	"github.com/gruntwork-io/terratest/modules/k8s"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// application returns an Application comparing against revision whose last sync of syncedRevision
+// ended in phase.
+func application(health apphealth.HealthStatusCode, sync argocdv1alpha1.SyncStatusCode, revision string, phase synccommon.OperationPhase, syncedRevision string) *argocdv1alpha1.Application {
+	return &argocdv1alpha1.Application{
+		ObjectMeta: metav1.ObjectMeta{Name: "guestbook", Namespace: "argocd"},
+		Status: argocdv1alpha1.ApplicationStatus{
+			Health: argocdv1alpha1.AppHealthStatus{Status: health},
+			Sync:   argocdv1alpha1.SyncStatus{Status: sync, Revision: revision},
+			OperationState: &argocdv1alpha1.OperationState{
+				Phase:      phase,
+				Message:    "one or more objects failed to apply",
+				SyncResult: &argocdv1alpha1.SyncOperationResult{Revision: syncedRevision},
+			},
+		},
+	}
+}
+
+func TestWaitForApplicationHealthyAndSyncedScripted(t *testing.T) {
+	t.Run("sync failed", func(t *testing.T) {
+		clock := waittest.NewClock()
+		script := waittest.NewScript(clock, "argocd", "guestbook").
+			At(0, application(apphealth.HealthStatusProgressing, argocdv1alpha1.SyncStatusCodeOutOfSync, "b2", synccommon.OperationRunning, "b2")).
+			At(time.Minute, application(apphealth.HealthStatusDegraded, argocdv1alpha1.SyncStatusCodeOutOfSync, "b2", synccommon.OperationFailed, "b2"))
+		waittest.Prepend(t, NewTestClient(t), "applications", script)
+
+		err := WaitForApplicationHealthyAndSyncedE(t, &k8s.KubectlOptions{}, "guestbook", "argocd", 10*time.Minute, wait.WithClock(clock))
+		require.True(t, wait.IsTerminalState(err), "expected a terminal state error, got %v", err)
+		assert.Equal(t, `Application argocd/guestbook reached terminal state "Failed": one or more objects failed to apply`, err.Error())
+		assert.Equal(t, time.Minute, clock.Elapsed())
+	})
+
+	t.Run("failed sync of a previous revision", func(t *testing.T) {
+		clock := waittest.NewClock()
+		script := waittest.NewScript(clock, "argocd", "guestbook").
+			At(0, application(apphealth.HealthStatusDegraded, argocdv1alpha1.SyncStatusCodeOutOfSync, "b2", synccommon.OperationFailed, "a1")).
+			At(2*time.Minute, application(apphealth.HealthStatusHealthy, argocdv1alpha1.SyncStatusCodeSynced, "b2", synccommon.OperationSucceeded, "b2"))
+		waittest.Prepend(t, NewTestClient(t), "applications", script)
+
+		err := WaitForApplicationHealthyAndSyncedE(t, &k8s.KubectlOptions{}, "guestbook", "argocd", 10*time.Minute, wait.WithClock(clock))
+		require.NoError(t, err)
+		assert.Equal(t, 2*time.Minute, clock.Elapsed())
+	})
+
+	t.Run("healthy and synced despite an earlier failed sync", func(t *testing.T) {
+		NewTestClient(t, application(apphealth.HealthStatusHealthy, argocdv1alpha1.SyncStatusCodeSynced, "b2", synccommon.OperationFailed, "b2"))
+
+		err := WaitForApplicationHealthyAndSyncedE(t, &k8s.KubectlOptions{}, "guestbook", "argocd", 10*time.Minute, wait.WithClock(waittest.NewClock()))
+		require.NoError(t, err)
+	})
+}
+
+func TestWaitForApplicationDeleted(t *testing.T) {
+	deleting := &argocdv1alpha1.Application{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "guestbook",
+			Namespace:         "argocd",
+			DeletionTimestamp: &metav1.Time{Time: time.Now()},
+			Finalizers:        []string{"resources-finalizer.argocd.argoproj.io"},
+		},
+	}
+	notFound := apierrors.NewNotFound(argocdv1alpha1.Resource("applications"), "guestbook")
+
+	t.Run("deleted", func(t *testing.T) {
+		clock := waittest.NewClock()
+		waittest.Prepend(t, NewTestClient(t), "applications", waittest.NewScript(clock, "argocd", "guestbook").
+			Return(deleting).
+			AtError(time.Minute, notFound))
+
+		WaitForApplicationDeleted(t, &k8s.KubectlOptions{}, "guestbook", "argocd", 5*time.Minute, wait.WithClock(clock))
+		assert.Equal(t, time.Minute, clock.Elapsed())
+	})
+
+	t.Run("timeout reports finalizers", func(t *testing.T) {
+		NewTestClient(t, deleting)
+
+		err := WaitForApplicationDeletedE(t, &k8s.KubectlOptions{}, "guestbook", "argocd", 5*time.Minute, wait.WithClock(waittest.NewClock()))
+		require.True(t, wait.IsTimeout(err), "expected a timeout, got %v", err)
+		assert.Contains(t, err.Error(), "deletion blocked by finalizers: resources-finalizer.argocd.argoproj.io")
+	})
+}
@@ -0,0 +1,32 @@
+package cd
+
+import (
+	argocd "github.com/argoproj/argo-cd/v3/pkg/client/clientset/versioned"
+	fakeargocd "github.com/argoproj/argo-cd/v3/pkg/client/clientset/versioned/fake"
+
+	"github.com/davidcollom/terratest-utils/pkg/utils"
+	"github.com/gruntwork-io/terratest/modules/k8s"
+	"github.com/gruntwork-io/terratest/modules/testing"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// NewTestClient creates a fake Argo CD clientset seeded with objs and makes NewArgoCDClient return it
+// for the rest of the test, so that every helper in this package runs against the fake. NewArgoCDClient
+// is restored when the test finishes.
+//
+// Example usage:
+//
+//	cd.NewTestClient(t, &argocdv1alpha1.Application{...})
+//	cd.WaitForApplicationHealthyAndSynced(t, &k8s.KubectlOptions{}, "my-app", "argocd", time.Second)
+func NewTestClient(t utils.CleanupT, objs ...runtime.Object) argocd.Interface {
+	client := fakeargocd.NewSimpleClientset(objs...)
+
+	NewArgoCDClient = func(t testing.TestingT, options *k8s.KubectlOptions) (argocd.Interface, error) {
+		return client, nil
+	}
+	t.Cleanup(func() {
+		NewArgoCDClient = newArgoCDClient
+	})
+
+	return client
+}
@@ -11,8 +11,9 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/davidcollom/terratest-utils/pkg/wait"
 	"github.com/gruntwork-io/terratest/modules/k8s"
-	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
 )
 
 // ListAppProjects retrieves a list of Argo CD AppProject resources in the specified namespace.
@@ -52,7 +53,7 @@ func ListAppProjectsE(t testing.TestingT, options *k8s.KubectlOptions, namespace
 }
 
 // WaitForAppProjectExists waits until an Argo CD AppProject with the specified name exists in the given namespace.
-// It polls the Kubernetes API at regular intervals until the AppProject is found or the timeout is reached.
+// It watches the Kubernetes API until the AppProject is found or the timeout is reached.
 // If the AppProject does not appear within the timeout, the test fails.
 // Parameters:
 //   - t: The testing context.
@@ -62,21 +63,31 @@ func ListAppProjectsE(t testing.TestingT, options *k8s.KubectlOptions, namespace
 //   - timeout: The maximum duration to wait for the AppProject to appear.
 //
 // WaitForAppProjectExists waits for the resource condition to be satisfied.
-func WaitForAppProjectExists(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) {
-	err := WaitForAppProjectExistsE(t, options, name, namespace, timeout)
+func WaitForAppProjectExists(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) {
+	err := WaitForAppProjectExistsE(t, options, name, namespace, timeout, opts...)
 	require.NoError(t, err, "AppProject %s/%s did not appear", namespace, name)
 }
 
 // WaitForAppProjectExistsE waits until an Argo CD AppProject with the specified name exists in the given namespace.
-func WaitForAppProjectExistsE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) error {
+func WaitForAppProjectExistsE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) error {
 	client, err := NewArgoCDClient(t, options)
 	if err != nil {
 		return err
 	}
 
-	ctx := context.Background()
-	return wait.PollUntilContextTimeout(ctx, 2*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
-		_, err := client.ArgoprojV1alpha1().AppProjects(namespace).Get(ctx, name, metav1.GetOptions{})
-		return err == nil, nil
-	})
+	_, err = wait.Waiter[*argocdv1alpha1.AppProject]{
+		Kind:      "AppProject",
+		Name:      name,
+		Namespace: namespace,
+		Timeout:   timeout,
+		Get: func(ctx context.Context) (*argocdv1alpha1.AppProject, error) {
+			return client.ArgoprojV1alpha1().AppProjects(namespace).Get(ctx, name, metav1.GetOptions{})
+		},
+		Watch: func(ctx context.Context) (watch.Interface, error) {
+			return client.ArgoprojV1alpha1().AppProjects(namespace).Watch(ctx, metav1.SingleObject(metav1.ObjectMeta{Name: name}))
+		},
+		Ready:  wait.Exists[*argocdv1alpha1.AppProject],
+		Events: wait.KubeEvents(t, options),
+	}.Wait(t, opts...)
+	return err
 }
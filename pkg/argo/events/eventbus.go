@@ -11,8 +11,9 @@ import (
 	"github.com/gruntwork-io/terratest/modules/k8s"
 	"github.com/stretchr/testify/require"
 
+	"github.com/davidcollom/terratest-utils/pkg/wait"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
 )
 
 // ListEventBuses retrieves a list of Argo EventBus resources in the specified namespace.
@@ -54,38 +55,46 @@ func ListEventBusesE(t testing.TestingT, options *k8s.KubectlOptions, namespace
 // WaitForEventBusReady waits until the specified Argo Events EventBus resource is Ready, or times out.
 // Useful for integration tests to ensure event infrastructure is available before proceeding.
 // WaitForEventBusReady waits for the resource condition to be satisfied.
-func WaitForEventBusReady(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) {
-	err := WaitForEventBusReadyE(t, options, name, namespace, timeout)
+func WaitForEventBusReady(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) {
+	err := WaitForEventBusReadyE(t, options, name, namespace, timeout, opts...)
 	require.NoError(t, err, "EventBus %s/%s did not become Ready", namespace, name)
 }
 
 // WaitForEventBusReadyE waits for the resource condition to be satisfied.
-func WaitForEventBusReadyE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) error {
+func WaitForEventBusReadyE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) error {
 	client, err := NewArgoEventsClient(t, options)
 	if err != nil {
 		return err
 	}
 
-	ctx := context.Background()
-	return wait.PollUntilContextTimeout(ctx, 2*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
-		eventBus, err := client.ArgoprojV1alpha1().EventBus(namespace).Get(ctx, name, metav1.GetOptions{})
-		if err != nil {
-			return false, nil
-		}
-
-		var (
-			configured = false
-			deployed   = false
-		)
+	_, err = wait.Waiter[*argoeventsv1alpha1.EventBus]{
+		Kind:      "EventBus",
+		Name:      name,
+		Namespace: namespace,
+		Timeout:   timeout,
+		Get: func(ctx context.Context) (*argoeventsv1alpha1.EventBus, error) {
+			return client.ArgoprojV1alpha1().EventBus(namespace).Get(ctx, name, metav1.GetOptions{})
+		},
+		Watch: func(ctx context.Context) (watch.Interface, error) {
+			return client.ArgoprojV1alpha1().EventBus(namespace).Watch(ctx, metav1.SingleObject(metav1.ObjectMeta{Name: name}))
+		},
+		Ready: func(eventBus *argoeventsv1alpha1.EventBus) bool {
+			var (
+				configured = false
+				deployed   = false
+			)
 
-		for _, cond := range eventBus.Status.Conditions {
-			if cond.Type == argoeventsv1alpha1.EventBusConditionDeployed && cond.IsTrue() {
-				deployed = true
-			}
-			if cond.Type == argoeventsv1alpha1.EventBusConditionConfigured && cond.IsTrue() {
-				configured = true
+			for _, cond := range eventBus.Status.Conditions {
+				if cond.Type == argoeventsv1alpha1.EventBusConditionDeployed && cond.IsTrue() {
+					deployed = true
+				}
+				if cond.Type == argoeventsv1alpha1.EventBusConditionConfigured && cond.IsTrue() {
+					configured = true
+				}
 			}
-		}
-		return configured && deployed, nil
-	})
+			return configured && deployed
+		},
+		Events: wait.KubeEvents(t, options),
+	}.Wait(t, opts...)
+	return err
 }
@@ -1,5 +1,5 @@
 // Package events provides Terratest-style helpers for testing Argo Events resources,
-// including EventSources and Sensors. These helpers use client-go polling to wait for
+// including EventSources and Sensors. These helpers use client-go watches to wait for
 // resources to report a Ready condition, ensuring event-driven workflows are correctly configured.
 package events
 
@@ -8,7 +8,7 @@ import (
 
 	argoclientset "github.com/argoproj/argo-events/pkg/client/clientset/versioned"
 
-	"github.com/davidcollom/terratest-utils/pkg/utils"
+	"github.com/davidcollom/terratest-utils/pkg/clients"
 	"github.com/gruntwork-io/terratest/modules/k8s"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/rest"
@@ -33,7 +33,8 @@ func HasReadyCondition(conds []metav1.Condition, expectedType string) bool {
 }
 
 // NewArgoEventsClient creates a new Argo Events client using the provided testing context and Kubernetes options.
-// It retrieves the Kubernetes REST configuration from the provided options or generates one if not present.
+// It retrieves the Kubernetes REST configuration from the provided options or generates one if not present,
+// and reuses the clientset cached for the cluster by pkg/clients.
 // Returns an Argo Events clientset interface and an error if the client could not be created.
 //
 // Parameters:
@@ -43,19 +44,10 @@ func HasReadyCondition(conds []metav1.Condition, expectedType string) bool {
 // Returns:
 //   - argoclientset.Interface: The Argo Events clientset interface for interacting with Argo Events resources.
 //   - error: An error if the client could not be created.
-//
-// NewArgoEventsClient creates a new client or helper instance.
-func NewArgoEventsClient(t testing.TestingT, options *k8s.KubectlOptions) (argoclientset.Interface, error) {
-	var cfg *rest.Config
-	var err error
-	if options.RestConfig == nil {
-		cfg, err = utils.GetRestConfigE(t, options)
-		if err != nil {
-			return nil, err
-		}
-	} else {
-		cfg = options.RestConfig
-	}
+var NewArgoEventsClient = newArgoEventsClient
 
-	return argoclientset.NewForConfig(cfg)
+func newArgoEventsClient(t testing.TestingT, options *k8s.KubectlOptions) (argoclientset.Interface, error) {
+	return clients.Get(t, options, "argo-events", func(cfg *rest.Config) (argoclientset.Interface, error) {
+		return argoclientset.NewForConfig(cfg)
+	})
 }
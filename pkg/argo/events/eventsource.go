@@ -8,11 +8,12 @@ import (
 
 	argoeventsv1alpha1 "github.com/argoproj/argo-events/pkg/apis/events/v1alpha1"
 
+	"github.com/davidcollom/terratest-utils/pkg/wait"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/util/wait"
 
 	"github.com/gruntwork-io/terratest/modules/k8s"
 	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/watch"
 )
 
 // ListEventSources retrieves a list of Argo EventSource resources from the specified namespace.
@@ -53,38 +54,46 @@ func ListEventSourcesE(t testing.TestingT, options *k8s.KubectlOptions, namespac
 // WaitForEventSourceReady waits until the specified Argo Events EventSource resource is Ready, or times out.
 // Useful for integration tests to ensure event sources are available before proceeding.
 // WaitForEventSourceReady waits for the resource condition to be satisfied.
-func WaitForEventSourceReady(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) {
-	err := WaitForEventSourceReadyE(t, options, name, namespace, timeout)
+func WaitForEventSourceReady(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) {
+	err := WaitForEventSourceReadyE(t, options, name, namespace, timeout, opts...)
 	require.NoError(t, err, "EventSource %s/%s did not become Ready", namespace, name)
 }
 
 // WaitForEventSourceReadyE waits for the resource condition to be satisfied.
-func WaitForEventSourceReadyE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) error {
+func WaitForEventSourceReadyE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) error {
 	client, err := NewArgoEventsClient(t, options)
 	if err != nil {
 		return err
 	}
 
-	ctx := context.Background()
-	return wait.PollUntilContextTimeout(ctx, 2*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
-		es, err := client.ArgoprojV1alpha1().EventSources(namespace).Get(ctx, name, metav1.GetOptions{})
-		if err != nil {
-			return false, nil // keep retrying
-		}
-
-		var (
-			deployed   = false
-			hasSources = false
-		)
+	_, err = wait.Waiter[*argoeventsv1alpha1.EventSource]{
+		Kind:      "EventSource",
+		Name:      name,
+		Namespace: namespace,
+		Timeout:   timeout,
+		Get: func(ctx context.Context) (*argoeventsv1alpha1.EventSource, error) {
+			return client.ArgoprojV1alpha1().EventSources(namespace).Get(ctx, name, metav1.GetOptions{})
+		},
+		Watch: func(ctx context.Context) (watch.Interface, error) {
+			return client.ArgoprojV1alpha1().EventSources(namespace).Watch(ctx, metav1.SingleObject(metav1.ObjectMeta{Name: name}))
+		},
+		Ready: func(es *argoeventsv1alpha1.EventSource) bool {
+			var (
+				deployed   = false
+				hasSources = false
+			)
 
-		for _, cond := range es.Status.Conditions {
-			if cond.Type == argoeventsv1alpha1.EventSourceConditionDeployed && cond.IsTrue() {
-				deployed = true
-			}
-			if cond.Type == argoeventsv1alpha1.EventSourceConditionSourcesProvided && cond.IsTrue() {
-				hasSources = true
+			for _, cond := range es.Status.Conditions {
+				if cond.Type == argoeventsv1alpha1.EventSourceConditionDeployed && cond.IsTrue() {
+					deployed = true
+				}
+				if cond.Type == argoeventsv1alpha1.EventSourceConditionSourcesProvided && cond.IsTrue() {
+					hasSources = true
+				}
 			}
-		}
-		return deployed && hasSources, nil
-	})
+			return deployed && hasSources
+		},
+		Events: wait.KubeEvents(t, options),
+	}.Wait(t, opts...)
+	return err
 }
@@ -0,0 +1,32 @@
+package events
+
+import (
+	argoclientset "github.com/argoproj/argo-events/pkg/client/clientset/versioned"
+	fakeargo "github.com/argoproj/argo-events/pkg/client/clientset/versioned/fake"
+
+	"github.com/davidcollom/terratest-utils/pkg/utils"
+	"github.com/gruntwork-io/terratest/modules/k8s"
+	"github.com/gruntwork-io/terratest/modules/testing"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// NewTestClient creates a fake Argo Events clientset seeded with objs and makes NewArgoEventsClient return it
+// for the rest of the test, so that every helper in this package runs against the fake. NewArgoEventsClient is
+// restored when the test finishes.
+//
+// Example usage:
+//
+//	events.NewTestClient(t, &eventsv1alpha1.Sensor{...})
+//	events.WaitForSensorReady(t, &k8s.KubectlOptions{}, "my-sensor", "default", time.Second)
+func NewTestClient(t utils.CleanupT, objs ...runtime.Object) argoclientset.Interface {
+	client := fakeargo.NewSimpleClientset(objs...)
+
+	NewArgoEventsClient = func(t testing.TestingT, options *k8s.KubectlOptions) (argoclientset.Interface, error) {
+		return client, nil
+	}
+	t.Cleanup(func() {
+		NewArgoEventsClient = newArgoEventsClient
+	})
+
+	return client
+}
@@ -11,8 +11,9 @@ import (
 	"github.com/gruntwork-io/terratest/modules/k8s"
 	"github.com/stretchr/testify/require"
 
+	"github.com/davidcollom/terratest-utils/pkg/wait"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
 )
 
 // ListSensors retrieves a list of Argo Events Sensor resources from the specified namespace.
@@ -52,7 +53,7 @@ func ListSensorsE(t testing.TestingT, options *k8s.KubectlOptions, namespace str
 }
 
 // WaitForSensorReady waits until the specified Argo Sensor resource in the given namespace becomes Ready.
-// It polls the sensor's status conditions at regular intervals until the ConditionReady is True or the timeout is reached.
+// It watches the sensor's status conditions until the ConditionReady is True or the timeout is reached.
 // If the sensor does not become Ready within the timeout, the test fails.
 // Parameters:
 //   - t: The testing context.
@@ -62,43 +63,51 @@ func ListSensorsE(t testing.TestingT, options *k8s.KubectlOptions, namespace str
 //   - timeout: The maximum duration to wait for the sensor to become Ready.
 //
 // WaitForSensorReady waits for the resource condition to be satisfied.
-func WaitForSensorReady(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) {
-	err := WaitForSensorReadyE(t, options, name, namespace, timeout)
+func WaitForSensorReady(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) {
+	err := WaitForSensorReadyE(t, options, name, namespace, timeout, opts...)
 	require.NoError(t, err, "Sensor %s/%s did not become Ready", namespace, name)
 }
 
 // WaitForSensorReadyE waits for the resource condition to be satisfied.
-func WaitForSensorReadyE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) error {
+func WaitForSensorReadyE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) error {
 	client, err := NewArgoEventsClient(t, options)
 	if err != nil {
 		return err
 	}
 
-	ctx := context.Background()
-	return wait.PollUntilContextTimeout(ctx, 2*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
-		sensor, err := client.ArgoprojV1alpha1().Sensors(namespace).Get(ctx, name, metav1.GetOptions{})
-		if err != nil {
-			return false, nil
-		}
-
-		var (
-			hasTriggers = false
-			hasDeployed = false
-			hasDeps     = false
-		)
+	_, err = wait.Waiter[*argoeventsv1alpha1.Sensor]{
+		Kind:      "Sensor",
+		Name:      name,
+		Namespace: namespace,
+		Timeout:   timeout,
+		Get: func(ctx context.Context) (*argoeventsv1alpha1.Sensor, error) {
+			return client.ArgoprojV1alpha1().Sensors(namespace).Get(ctx, name, metav1.GetOptions{})
+		},
+		Watch: func(ctx context.Context) (watch.Interface, error) {
+			return client.ArgoprojV1alpha1().Sensors(namespace).Watch(ctx, metav1.SingleObject(metav1.ObjectMeta{Name: name}))
+		},
+		Ready: func(sensor *argoeventsv1alpha1.Sensor) bool {
+			var (
+				hasTriggers = false
+				hasDeployed = false
+				hasDeps     = false
+			)
 
-		for _, cond := range sensor.Status.Conditions {
-			if cond.Type == argoeventsv1alpha1.SensorConditionTriggersProvided && cond.IsTrue() {
-				hasTriggers = true
-			}
-			if cond.Type == argoeventsv1alpha1.SensorConditionDeployed && cond.IsTrue() {
-				hasDeployed = true
-			}
-			if cond.Type == argoeventsv1alpha1.SensorConditionDepencencyProvided && cond.IsTrue() {
-				hasDeps = true
+			for _, cond := range sensor.Status.Conditions {
+				if cond.Type == argoeventsv1alpha1.SensorConditionTriggersProvided && cond.IsTrue() {
+					hasTriggers = true
+				}
+				if cond.Type == argoeventsv1alpha1.SensorConditionDeployed && cond.IsTrue() {
+					hasDeployed = true
+				}
+				if cond.Type == argoeventsv1alpha1.SensorConditionDepencencyProvided && cond.IsTrue() {
+					hasDeps = true
+				}
 			}
-		}
 
-		return hasTriggers && hasDeployed && hasDeps, nil
-	})
+			return hasTriggers && hasDeployed && hasDeps
+		},
+		Events: wait.KubeEvents(t, options),
+	}.Wait(t, opts...)
+	return err
 }
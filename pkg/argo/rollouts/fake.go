@@ -0,0 +1,32 @@
+package rollouts
+
+import (
+	rolloutClientSet "github.com/argoproj/argo-rollouts/pkg/client/clientset/versioned"
+	fakerollouts "github.com/argoproj/argo-rollouts/pkg/client/clientset/versioned/fake"
+
+	"github.com/davidcollom/terratest-utils/pkg/utils"
+	"github.com/gruntwork-io/terratest/modules/k8s"
+	"github.com/gruntwork-io/terratest/modules/testing"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// NewTestClient creates a fake Argo Rollouts clientset seeded with objs and makes NewArgoRolloutsClient return it
+// for the rest of the test, so that every helper in this package runs against the fake. NewArgoRolloutsClient is
+// restored when the test finishes.
+//
+// Example usage:
+//
+//	rollouts.NewTestClient(t, &rolloutsv1alpha1.Rollout{...})
+//	rollouts.WaitForRolloutHealthy(t, &k8s.KubectlOptions{}, "my-rollout", "default", time.Second)
+func NewTestClient(t utils.CleanupT, objs ...runtime.Object) rolloutClientSet.Interface {
+	client := fakerollouts.NewSimpleClientset(objs...)
+
+	NewArgoRolloutsClient = func(t testing.TestingT, options *k8s.KubectlOptions) (rolloutClientSet.Interface, error) {
+		return client, nil
+	}
+	t.Cleanup(func() {
+		NewArgoRolloutsClient = newArgoRolloutsClient
+	})
+
+	return client
+}
@@ -1,5 +1,5 @@
 // Package rollouts provides Terratest-style helpers for testing Argo Rollouts.
-// It includes polling-based utilities for checking rollout phases, pause states,
+// It includes watch-based utilities for checking rollout phases, pause states,
 // and progressive deployment status using the Argo Rollouts clientset.
 package rollouts
 
@@ -11,18 +11,20 @@ import (
 
 	rolloutsv1alpha1 "github.com/argoproj/argo-rollouts/pkg/apis/rollouts/v1alpha1"
 	rolloutClientSet "github.com/argoproj/argo-rollouts/pkg/client/clientset/versioned"
-	"github.com/davidcollom/terratest-utils/pkg/utils"
+	"github.com/davidcollom/terratest-utils/pkg/clients"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/rest"
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/davidcollom/terratest-utils/pkg/wait"
 	"github.com/gruntwork-io/terratest/modules/k8s"
-	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
 )
 
 // NewArgoRolloutsClient creates a new Argo Rollouts client using the provided testing context and kubectl options.
-// It retrieves the Kubernetes REST configuration from the given options or generates one if not present.
+// It retrieves the Kubernetes REST configuration from the given options or generates one if not present,
+// and reuses the clientset cached for the cluster by pkg/clients.
 // Returns an Argo Rollouts client interface and an error if the client could not be created.
 //
 // Parameters:
@@ -32,21 +34,12 @@ import (
 // Returns:
 //   - rolloutClientSet.Interface: The Argo Rollouts client interface for interacting with Rollouts resources.
 //   - error: An error if the client could not be created.
-//
-// NewArgoRolloutsClient creates a new client or helper instance.
-func NewArgoRolloutsClient(t testing.TestingT, options *k8s.KubectlOptions) (rolloutClientSet.Interface, error) {
-	var cfg *rest.Config
-	var err error
-	if options.RestConfig == nil {
-		cfg, err = utils.GetRestConfigE(t, options)
-		if err != nil {
-			return nil, err
-		}
-	} else {
-		cfg = options.RestConfig
-	}
+var NewArgoRolloutsClient = newArgoRolloutsClient
 
-	return rolloutClientSet.NewForConfig(cfg)
+func newArgoRolloutsClient(t testing.TestingT, options *k8s.KubectlOptions) (rolloutClientSet.Interface, error) {
+	return clients.Get(t, options, "argo-rollouts", func(cfg *rest.Config) (rolloutClientSet.Interface, error) {
+		return rolloutClientSet.NewForConfig(cfg)
+	})
 }
 
 // ListRollouts retrieves all Argo Rollouts in the specified namespace using the provided kubectl options.
@@ -84,8 +77,8 @@ func ListRolloutsE(t testing.TestingT, options *k8s.KubectlOptions, namespace st
 }
 
 // WaitForRolloutHealthy waits until the specified Argo Rollout resource reaches a Healthy phase within the given timeout.
-// It polls the rollout status every 2 seconds and checks for the "Progressing" condition with status "True" and phase "Healthy".
-// If the rollout does not become healthy within the timeout, the test fails.
+// It watches the rollout status, falling back to polling, and checks for the "Progressing" condition with status "True" and phase "Healthy".
+// If the rollout does not become healthy within the timeout, or is aborted or exceeds its progress deadline, the test fails.
 // Parameters:
 //   - t: The testing context.
 //   - options: Kubectl options containing the REST config for the Kubernetes client.
@@ -94,38 +87,48 @@ func ListRolloutsE(t testing.TestingT, options *k8s.KubectlOptions, namespace st
 //   - timeout: The maximum duration to wait for the rollout to become healthy.
 //
 // WaitForRolloutHealthy waits for the resource condition to be satisfied.
-func WaitForRolloutHealthy(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) {
-	err := WaitForRolloutHealthyE(t, options, name, namespace, timeout)
+func WaitForRolloutHealthy(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) {
+	err := WaitForRolloutHealthyE(t, options, name, namespace, timeout, opts...)
 	require.NoError(t, err, "Rollout %s/%s did not become Healthy in time", namespace, name)
 }
 
 // WaitForRolloutHealthyE waits for the resource condition to be satisfied.
-func WaitForRolloutHealthyE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) error {
+// It returns a *wait.TerminalStateError as soon as the rollout is aborted or exceeds its progress deadline.
+func WaitForRolloutHealthyE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) error {
 	client, err := NewArgoRolloutsClient(t, options)
 	if err != nil {
 		return err
 	}
 
-	ctx := context.Background()
-	return wait.PollUntilContextTimeout(ctx, 2*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
-		ro, err := client.ArgoprojV1alpha1().Rollouts(namespace).Get(ctx, name, metav1.GetOptions{})
-		if err != nil {
-			return false, nil
-		}
-
-		for _, cond := range ro.Status.Conditions {
-			if cond.Type == rolloutsv1alpha1.RolloutProgressing && cond.Status == "True" {
-				if ro.Status.Phase == rolloutsv1alpha1.RolloutPhaseHealthy {
-					return true, nil
+	_, err = wait.Waiter[*rolloutsv1alpha1.Rollout]{
+		Kind:      "Rollout",
+		Name:      name,
+		Namespace: namespace,
+		Timeout:   timeout,
+		Get: func(ctx context.Context) (*rolloutsv1alpha1.Rollout, error) {
+			return client.ArgoprojV1alpha1().Rollouts(namespace).Get(ctx, name, metav1.GetOptions{})
+		},
+		Watch: func(ctx context.Context) (watch.Interface, error) {
+			return client.ArgoprojV1alpha1().Rollouts(namespace).Watch(ctx, metav1.SingleObject(metav1.ObjectMeta{Name: name}))
+		},
+		Ready: func(ro *rolloutsv1alpha1.Rollout) bool {
+			for _, cond := range ro.Status.Conditions {
+				if cond.Type == rolloutsv1alpha1.RolloutProgressing && cond.Status == "True" {
+					if ro.Status.Phase == rolloutsv1alpha1.RolloutPhaseHealthy {
+						return true
+					}
 				}
 			}
-		}
-		return false, nil
-	})
+			return false
+		},
+		Failed: rolloutFailed,
+		Events: wait.KubeEvents(t, options),
+	}.Wait(t, opts...)
+	return err
 }
 
 // WaitForRolloutPaused waits until the specified Argo Rollout resource enters the "Paused" phase within the given timeout.
-// It polls the rollout status every 2 seconds using the provided Kubernetes options, rollout name, and namespace.
+// It watches the rollout status, falling back to polling, using the provided Kubernetes options, rollout name, and namespace.
 // If the rollout does not reach the paused phase within the timeout, the test fails with a fatal error.
 // Requires a valid Argo Rollouts clientset and test context.
 //
@@ -138,24 +141,55 @@ func WaitForRolloutHealthyE(t testing.TestingT, options *k8s.KubectlOptions, nam
 //	timeout  - The maximum duration to wait for the rollout to pause.
 //
 // WaitForRolloutPaused waits for the resource condition to be satisfied.
-func WaitForRolloutPaused(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) {
-	err := WaitForRolloutPausedE(t, options, name, namespace, timeout)
+func WaitForRolloutPaused(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) {
+	err := WaitForRolloutPausedE(t, options, name, namespace, timeout, opts...)
 	require.NoError(t, err, "Rollout %s/%s did not pause in time", namespace, name)
 }
 
 // WaitForRolloutPausedE waits for the resource condition to be satisfied.
-func WaitForRolloutPausedE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) error {
+// It returns a *wait.TerminalStateError as soon as the rollout is aborted or exceeds its progress deadline.
+func WaitForRolloutPausedE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) error {
 	client, err := NewArgoRolloutsClient(t, options)
 	if err != nil {
 		return err
 	}
 
-	ctx := context.Background()
-	return wait.PollUntilContextTimeout(ctx, 2*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
-		ro, err := client.ArgoprojV1alpha1().Rollouts(namespace).Get(ctx, name, metav1.GetOptions{})
-		if err != nil {
-			return false, nil
+	_, err = wait.Waiter[*rolloutsv1alpha1.Rollout]{
+		Kind:      "Rollout",
+		Name:      name,
+		Namespace: namespace,
+		Timeout:   timeout,
+		Get: func(ctx context.Context) (*rolloutsv1alpha1.Rollout, error) {
+			return client.ArgoprojV1alpha1().Rollouts(namespace).Get(ctx, name, metav1.GetOptions{})
+		},
+		Watch: func(ctx context.Context) (watch.Interface, error) {
+			return client.ArgoprojV1alpha1().Rollouts(namespace).Watch(ctx, metav1.SingleObject(metav1.ObjectMeta{Name: name}))
+		},
+		Ready: func(ro *rolloutsv1alpha1.Rollout) bool {
+			return ro.Status.Phase == rolloutsv1alpha1.RolloutPhasePaused
+		},
+		Failed: rolloutFailed,
+		Events: wait.KubeEvents(t, options),
+	}.Wait(t, opts...)
+	return err
+}
+
+// Condition reasons set by the Argo Rollouts controller when a rollout can no longer progress.
+const (
+	rolloutAbortedReason           = "RolloutAborted"
+	progressDeadlineExceededReason = "ProgressDeadlineExceeded"
+)
+
+// rolloutFailed reports an aborted rollout, or one whose Progressing condition has exceeded its
+// progress deadline, as a terminal state. Neither recovers without a new revision or a retry.
+func rolloutFailed(ro *rolloutsv1alpha1.Rollout) error {
+	if ro.Status.Abort {
+		return &wait.TerminalStateError{Phase: string(ro.Status.Phase), Reason: rolloutAbortedReason, Message: ro.Status.Message}
+	}
+	for _, cond := range ro.Status.Conditions {
+		if cond.Type == rolloutsv1alpha1.RolloutProgressing && cond.Status == "False" && cond.Reason == progressDeadlineExceededReason {
+			return &wait.TerminalStateError{Phase: string(cond.Type) + "=False", Reason: cond.Reason, Message: cond.Message}
 		}
-		return ro.Status.Phase == rolloutsv1alpha1.RolloutPhasePaused, nil
-	})
+	}
+	return nil
 }
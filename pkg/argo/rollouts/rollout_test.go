@@ -0,0 +1,74 @@
+package rollouts
+
+import (
+	"testing"
+	"time"
+
+	rolloutsv1alpha1 "github.com/argoproj/argo-rollouts/pkg/apis/rollouts/v1alpha1"
+	"github.com/davidcollom/terratest-utils/pkg/wait"
+	"github.com/davidcollom/terratest-utils/pkg/waittest"
+	"github.com/gruntwork-io/terratest/modules/k8s"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func rollout(phase rolloutsv1alpha1.RolloutPhase, abort bool, progressing corev1.ConditionStatus, reason string) *rolloutsv1alpha1.Rollout {
+	return &rolloutsv1alpha1.Rollout{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-rollout", Namespace: "default"},
+		Status: rolloutsv1alpha1.RolloutStatus{
+			Phase:   phase,
+			Abort:   abort,
+			Message: "canary step failed",
+			Conditions: []rolloutsv1alpha1.RolloutCondition{{
+				Type:    rolloutsv1alpha1.RolloutProgressing,
+				Status:  progressing,
+				Reason:  reason,
+				Message: "rollout did not progress in time",
+			}},
+		},
+	}
+}
+
+func TestWaitForRolloutHealthyScripted(t *testing.T) {
+	progressing := rollout(rolloutsv1alpha1.RolloutPhaseProgressing, false, corev1.ConditionTrue, "ReplicaSetUpdated")
+
+	t.Run("healthy", func(t *testing.T) {
+		clock := waittest.NewClock()
+		script := waittest.NewScript(clock, "default", "test-rollout").
+			At(0, progressing).
+			At(2*time.Minute, rollout(rolloutsv1alpha1.RolloutPhaseHealthy, false, corev1.ConditionTrue, "NewReplicaSetAvailable"))
+		waittest.Prepend(t, NewTestClient(t), "rollouts", script)
+
+		err := WaitForRolloutHealthyE(t, &k8s.KubectlOptions{}, "test-rollout", "default", 10*time.Minute, wait.WithClock(clock))
+		require.NoError(t, err)
+		assert.Equal(t, 2*time.Minute, clock.Elapsed())
+	})
+
+	t.Run("aborted", func(t *testing.T) {
+		clock := waittest.NewClock()
+		script := waittest.NewScript(clock, "default", "test-rollout").
+			At(0, progressing).
+			At(time.Minute, rollout(rolloutsv1alpha1.RolloutPhaseDegraded, true, corev1.ConditionFalse, rolloutAbortedReason))
+		waittest.Prepend(t, NewTestClient(t), "rollouts", script)
+
+		err := WaitForRolloutHealthyE(t, &k8s.KubectlOptions{}, "test-rollout", "default", 10*time.Minute, wait.WithClock(clock))
+		require.True(t, wait.IsTerminalState(err), "expected a terminal state error, got %v", err)
+		assert.Equal(t, `Rollout default/test-rollout reached terminal state "Degraded": RolloutAborted: canary step failed`, err.Error())
+		assert.Equal(t, time.Minute, clock.Elapsed())
+	})
+
+	t.Run("progress deadline exceeded", func(t *testing.T) {
+		clock := waittest.NewClock()
+		script := waittest.NewScript(clock, "default", "test-rollout").
+			At(0, progressing).
+			At(3*time.Minute, rollout(rolloutsv1alpha1.RolloutPhaseDegraded, false, corev1.ConditionFalse, progressDeadlineExceededReason))
+		waittest.Prepend(t, NewTestClient(t), "rollouts", script)
+
+		err := WaitForRolloutPausedE(t, &k8s.KubectlOptions{}, "test-rollout", "default", 10*time.Minute, wait.WithClock(clock))
+		require.True(t, wait.IsTerminalState(err), "expected a terminal state error, got %v", err)
+		assert.Contains(t, err.Error(), "ProgressDeadlineExceeded: rollout did not progress in time")
+		assert.Equal(t, 3*time.Minute, clock.Elapsed())
+	})
+}
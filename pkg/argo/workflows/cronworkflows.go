@@ -9,9 +9,10 @@ import (
 	workflowv1alpha1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/davidcollom/terratest-utils/pkg/wait"
 	"github.com/gruntwork-io/terratest/modules/k8s"
 	"github.com/stretchr/testify/require"
-	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
 )
 
 // ListCronWorkflows retrieves all Argo CronWorkflows in the specified namespace using the provided kubectl options.
@@ -55,32 +56,32 @@ func ListCronWorkflowsE(t testing.TestingT, options *k8s.KubectlOptions, namespa
 // It uses the provided KubectlOptions, workflow name, and namespace for the check.
 // Fails the test if the CronWorkflow does not become active within the timeout.
 // WaitForCronWorkflowActive waits for the resource condition to be satisfied.
-func WaitForCronWorkflowActive(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) {
-	err := WaitForCronWorkflowActiveE(t, options, name, namespace, timeout)
+func WaitForCronWorkflowActive(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) {
+	err := WaitForCronWorkflowActiveE(t, options, name, namespace, timeout, opts...)
 	require.NoError(t, err, "Workflow %s/%s did not reach phase %q in time", namespace, name, workflowv1alpha1.ActivePhase)
 }
 
 // WaitForCronWorkflowActiveE waits until the specified Argo CronWorkflow reaches the Active phase.
-func WaitForCronWorkflowActiveE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) error {
-	return WaitForCronWorkflowPhaseE(t, options, name, namespace, workflowv1alpha1.ActivePhase, timeout)
+func WaitForCronWorkflowActiveE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) error {
+	return WaitForCronWorkflowPhaseE(t, options, name, namespace, workflowv1alpha1.ActivePhase, timeout, opts...)
 }
 
 // WaitForCronWorkflowStopped waits until the specified Argo CronWorkflow reaches the "Stopped" phase within the given timeout.
 // It uses the provided testing context, kubectl options, workflow name, and namespace.
 // If the workflow does not reach the "Stopped" phase within the timeout, the test will fail.
 // WaitForCronWorkflowStopped waits for the resource condition to be satisfied.
-func WaitForCronWorkflowStopped(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) {
-	err := WaitForCronWorkflowStoppedE(t, options, name, namespace, timeout)
+func WaitForCronWorkflowStopped(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) {
+	err := WaitForCronWorkflowStoppedE(t, options, name, namespace, timeout, opts...)
 	require.NoError(t, err, "Workflow %s/%s did not reach phase %q in time", namespace, name, workflowv1alpha1.StoppedPhase)
 }
 
 // WaitForCronWorkflowStoppedE waits until the specified Argo CronWorkflow reaches the Stopped phase.
-func WaitForCronWorkflowStoppedE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) error {
-	return WaitForCronWorkflowPhaseE(t, options, name, namespace, workflowv1alpha1.StoppedPhase, timeout)
+func WaitForCronWorkflowStoppedE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) error {
+	return WaitForCronWorkflowPhaseE(t, options, name, namespace, workflowv1alpha1.StoppedPhase, timeout, opts...)
 }
 
 // WaitForCronWorkflowPhase waits until the specified Argo CronWorkflow reaches the desired phase within the given timeout.
-// It polls the CronWorkflow status at regular intervals and fails the test if the desired phase is not reached in time.
+// It watches the CronWorkflow status, falling back to polling, and fails the test if the desired phase is not reached in time.
 //
 // Parameters:
 //
@@ -93,25 +94,33 @@ func WaitForCronWorkflowStoppedE(t testing.TestingT, options *k8s.KubectlOptions
 //
 // Fails the test if the CronWorkflow does not reach the desired phase within the timeout.
 // WaitForCronWorkflowPhase waits for the resource condition to be satisfied.
-func WaitForCronWorkflowPhase(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, desiredPhase workflowv1alpha1.CronWorkflowPhase, timeout time.Duration) {
-	err := WaitForCronWorkflowPhaseE(t, options, name, namespace, desiredPhase, timeout)
+func WaitForCronWorkflowPhase(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, desiredPhase workflowv1alpha1.CronWorkflowPhase, timeout time.Duration, opts ...wait.WaitOption) {
+	err := WaitForCronWorkflowPhaseE(t, options, name, namespace, desiredPhase, timeout, opts...)
 	require.NoError(t, err, "Workflow %s/%s did not reach phase %q in time", namespace, name, desiredPhase)
 }
 
 // WaitForCronWorkflowPhaseE waits until the specified Argo CronWorkflow reaches the desired phase within the given timeout.
-func WaitForCronWorkflowPhaseE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, desiredPhase workflowv1alpha1.CronWorkflowPhase, timeout time.Duration) error {
+func WaitForCronWorkflowPhaseE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, desiredPhase workflowv1alpha1.CronWorkflowPhase, timeout time.Duration, opts ...wait.WaitOption) error {
 	client, err := NewArgoWorkflowsClient(t, options)
 	if err != nil {
 		return err
 	}
 
-	ctx := context.Background()
-	return wait.PollUntilContextTimeout(ctx, 2*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
-		wf, err := client.ArgoprojV1alpha1().CronWorkflows(namespace).Get(ctx, name, metav1.GetOptions{})
-		if err != nil {
-			return false, nil
-		}
-
-		return wf.Status.Phase == desiredPhase, nil
-	})
+	_, err = wait.Waiter[*workflowv1alpha1.CronWorkflow]{
+		Kind:      "CronWorkflow",
+		Name:      name,
+		Namespace: namespace,
+		Timeout:   timeout,
+		Get: func(ctx context.Context) (*workflowv1alpha1.CronWorkflow, error) {
+			return client.ArgoprojV1alpha1().CronWorkflows(namespace).Get(ctx, name, metav1.GetOptions{})
+		},
+		Watch: func(ctx context.Context) (watch.Interface, error) {
+			return client.ArgoprojV1alpha1().CronWorkflows(namespace).Watch(ctx, metav1.SingleObject(metav1.ObjectMeta{Name: name}))
+		},
+		Ready: func(wf *workflowv1alpha1.CronWorkflow) bool {
+			return wf.Status.Phase == desiredPhase
+		},
+		Events: wait.KubeEvents(t, options),
+	}.Wait(t, opts...)
+	return err
 }
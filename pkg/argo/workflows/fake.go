@@ -0,0 +1,32 @@
+package workflows
+
+import (
+	workflowsClientSet "github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned"
+	fakeworkflows "github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned/fake"
+
+	"github.com/davidcollom/terratest-utils/pkg/utils"
+	"github.com/gruntwork-io/terratest/modules/k8s"
+	"github.com/gruntwork-io/terratest/modules/testing"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// NewTestClient creates a fake Argo Workflows clientset seeded with objs and makes NewArgoWorkflowsClient return it
+// for the rest of the test, so that every helper in this package runs against the fake. NewArgoWorkflowsClient is
+// restored when the test finishes.
+//
+// Example usage:
+//
+//	workflows.NewTestClient(t, &workflowv1alpha1.Workflow{...})
+//	workflows.WaitForWorkflowRunning(t, &k8s.KubectlOptions{}, "my-workflow", "default", time.Second)
+func NewTestClient(t utils.CleanupT, objs ...runtime.Object) workflowsClientSet.Interface {
+	client := fakeworkflows.NewSimpleClientset(objs...)
+
+	NewArgoWorkflowsClient = func(t testing.TestingT, options *k8s.KubectlOptions) (workflowsClientSet.Interface, error) {
+		return client, nil
+	}
+	t.Cleanup(func() {
+		NewArgoWorkflowsClient = newArgoWorkflowsClient
+	})
+
+	return client
+}
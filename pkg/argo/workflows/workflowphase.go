@@ -9,9 +9,10 @@ import (
 	workflowv1alpha1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/davidcollom/terratest-utils/pkg/wait"
 	"github.com/gruntwork-io/terratest/modules/k8s"
 	"github.com/stretchr/testify/require"
-	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
 )
 
 // ListWorkflowPhases retrieves the phases of all Argo Workflows in the specified namespace.
@@ -58,7 +59,7 @@ func ListWorkflowPhasesE(t testing.TestingT, options *k8s.KubectlOptions, namesp
 }
 
 // WaitForWorkflowPhase waits until the specified Argo Workflow reaches the desired phase within the given timeout.
-// It polls the workflow status every 2 seconds using the provided Kubernetes options and namespace.
+// It watches the workflow status, falling back to polling, using the provided Kubernetes options and namespace.
 // If the workflow does not reach the desired phase in time, the test fails with a fatal error.
 //
 // Parameters:
@@ -70,27 +71,44 @@ func ListWorkflowPhasesE(t testing.TestingT, options *k8s.KubectlOptions, namesp
 //	desiredPhase - The target WorkflowPhase to wait for.
 //	timeout      - The maximum duration to wait for the workflow to reach the desired phase.
 //
-// Fails the test if the workflow does not reach the desired phase within the timeout.
+// Fails the test if the workflow does not reach the desired phase within the timeout, or immediately
+// if the workflow completes in a different phase (e.g. Failed while waiting for Succeeded).
 // WaitForWorkflowPhase waits for the resource condition to be satisfied.
-func WaitForWorkflowPhase(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, desiredPhase workflowv1alpha1.WorkflowPhase, timeout time.Duration) {
-	err := WaitForWorkflowPhaseE(t, options, name, namespace, desiredPhase, timeout)
+func WaitForWorkflowPhase(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, desiredPhase workflowv1alpha1.WorkflowPhase, timeout time.Duration, opts ...wait.WaitOption) {
+	err := WaitForWorkflowPhaseE(t, options, name, namespace, desiredPhase, timeout, opts...)
 	require.NoError(t, err, "Workflow %s/%s did not reach phase %q in time", namespace, name, desiredPhase)
 }
 
 // WaitForWorkflowPhaseE waits until the specified Argo Workflow reaches the desired phase within the given timeout.
-func WaitForWorkflowPhaseE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, desiredPhase workflowv1alpha1.WorkflowPhase, timeout time.Duration) error {
+// It returns a *wait.TerminalStateError as soon as the workflow completes in any other phase.
+func WaitForWorkflowPhaseE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, desiredPhase workflowv1alpha1.WorkflowPhase, timeout time.Duration, opts ...wait.WaitOption) error {
 	client, err := NewArgoWorkflowsClient(t, options)
 	if err != nil {
 		return err
 	}
 
-	ctx := context.Background()
-	return wait.PollUntilContextTimeout(ctx, 2*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
-		wf, err := client.ArgoprojV1alpha1().Workflows(namespace).Get(ctx, name, metav1.GetOptions{})
-		if err != nil {
-			return false, nil
-		}
-
-		return wf.Status.Phase == desiredPhase, nil
-	})
+	_, err = wait.Waiter[*workflowv1alpha1.Workflow]{
+		Kind:      "Workflow",
+		Name:      name,
+		Namespace: namespace,
+		Timeout:   timeout,
+		Get: func(ctx context.Context) (*workflowv1alpha1.Workflow, error) {
+			return client.ArgoprojV1alpha1().Workflows(namespace).Get(ctx, name, metav1.GetOptions{})
+		},
+		Watch: func(ctx context.Context) (watch.Interface, error) {
+			return client.ArgoprojV1alpha1().Workflows(namespace).Watch(ctx, metav1.SingleObject(metav1.ObjectMeta{Name: name}))
+		},
+		Ready: func(wf *workflowv1alpha1.Workflow) bool {
+			return wf.Status.Phase == desiredPhase
+		},
+		Failed: func(wf *workflowv1alpha1.Workflow) error {
+			// A completed Workflow never changes phase again, so waiting any longer is pointless.
+			if wf.Status.Phase.Completed() && wf.Status.Phase != desiredPhase {
+				return &wait.TerminalStateError{Phase: string(wf.Status.Phase), Message: wf.Status.Message}
+			}
+			return nil
+		},
+		Events: wait.KubeEvents(t, options),
+	}.Wait(t, opts...)
+	return err
 }
@@ -13,7 +13,8 @@ import (
 	workflowsClientSet "github.com/argoproj/argo-workflows/v3/pkg/client/clientset/versioned"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
-	"github.com/davidcollom/terratest-utils/pkg/utils"
+	"github.com/davidcollom/terratest-utils/pkg/clients"
+	"github.com/davidcollom/terratest-utils/pkg/wait"
 	"github.com/gruntwork-io/terratest/modules/k8s"
 	"github.com/stretchr/testify/require"
 	"k8s.io/client-go/rest"
@@ -59,21 +60,14 @@ func ListArgoWorkflowsE(t testing.TestingT, options *k8s.KubectlOptions, namespa
 // NewArgoWorkflowsClient creates a new Argo Workflows client using the provided testing context and Kubernetes options.
 // It returns an implementation of the workflowv1alpha1.Interface for interacting with Argo Workflows resources.
 // If the provided KubectlOptions does not include a RestConfig, it attempts to generate one.
+// The clientset is cached per cluster by pkg/clients.
 // Returns an error if the client cannot be created.
-// NewArgoWorkflowsClient creates a new client or helper instance.
-func NewArgoWorkflowsClient(t testing.TestingT, options *k8s.KubectlOptions) (workflowsClientSet.Interface, error) {
-	var cfg *rest.Config
-	var err error
-	if options.RestConfig == nil {
-		cfg, err = utils.GetRestConfigE(t, options)
-		if err != nil {
-			return nil, err
-		}
-	} else {
-		cfg = options.RestConfig
-	}
+var NewArgoWorkflowsClient = newArgoWorkflowsClient
 
-	return workflowsClientSet.NewForConfig(cfg)
+func newArgoWorkflowsClient(t testing.TestingT, options *k8s.KubectlOptions) (workflowsClientSet.Interface, error) {
+	return clients.Get(t, options, "argo-workflows", func(cfg *rest.Config) (workflowsClientSet.Interface, error) {
+		return workflowsClientSet.NewForConfig(cfg)
+	})
 }
 
 // ListWorkflows retrieves all Argo Workflows in the specified namespace using the provided KubectlOptions.
@@ -115,14 +109,14 @@ func ListWorkflowsE(t testing.TestingT, options *k8s.KubectlOptions, namespace s
 //   - timeout: The maximum duration to wait for the workflow to reach the "Running" phase.
 //
 // WaitForWorkflowRunning waits for the resource condition to be satisfied.
-func WaitForWorkflowRunning(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) {
-	err := WaitForWorkflowRunningE(t, options, name, namespace, timeout)
+func WaitForWorkflowRunning(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) {
+	err := WaitForWorkflowRunningE(t, options, name, namespace, timeout, opts...)
 	require.NoError(t, err, "Workflow %s/%s did not reach phase %q in time", namespace, name, workflowv1alpha1.WorkflowRunning)
 }
 
 // WaitForWorkflowRunningE waits until the specified Argo workflow reaches the Running phase.
-func WaitForWorkflowRunningE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) error {
-	return WaitForWorkflowPhaseE(t, options, name, namespace, workflowv1alpha1.WorkflowRunning, timeout)
+func WaitForWorkflowRunningE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) error {
+	return WaitForWorkflowPhaseE(t, options, name, namespace, workflowv1alpha1.WorkflowRunning, timeout, opts...)
 }
 
 // WaitForWorkflowError waits until the specified Argo workflow reaches the "Error" phase or the timeout is reached.
@@ -137,14 +131,14 @@ func WaitForWorkflowRunningE(t testing.TestingT, options *k8s.KubectlOptions, na
 //	timeout   - The maximum duration to wait for the workflow to reach the "Error" phase.
 //
 // WaitForWorkflowError waits for the resource condition to be satisfied.
-func WaitForWorkflowError(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) {
-	err := WaitForWorkflowErrorE(t, options, name, namespace, timeout)
+func WaitForWorkflowError(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) {
+	err := WaitForWorkflowErrorE(t, options, name, namespace, timeout, opts...)
 	require.NoError(t, err, "Workflow %s/%s did not reach phase %q in time", namespace, name, workflowv1alpha1.WorkflowError)
 }
 
 // WaitForWorkflowErrorE waits until the specified Argo workflow reaches the Error phase.
-func WaitForWorkflowErrorE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) error {
-	return WaitForWorkflowPhaseE(t, options, name, namespace, workflowv1alpha1.WorkflowError, timeout)
+func WaitForWorkflowErrorE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) error {
+	return WaitForWorkflowPhaseE(t, options, name, namespace, workflowv1alpha1.WorkflowError, timeout, opts...)
 }
 
 // WaitForWorkflowPending waits until the specified Argo workflow reaches the "Pending" phase within the given timeout.
@@ -160,12 +154,12 @@ func WaitForWorkflowErrorE(t testing.TestingT, options *k8s.KubectlOptions, name
 //
 // This function delegates to WaitForWorkflowPhase with the "Pending" phase.
 // WaitForWorkflowPending waits for the resource condition to be satisfied.
-func WaitForWorkflowPending(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) {
-	err := WaitForWorkflowPendingE(t, options, name, namespace, timeout)
+func WaitForWorkflowPending(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) {
+	err := WaitForWorkflowPendingE(t, options, name, namespace, timeout, opts...)
 	require.NoError(t, err, "Workflow %s/%s did not reach phase %q in time", namespace, name, workflowv1alpha1.WorkflowPending)
 }
 
 // WaitForWorkflowPendingE waits until the specified Argo workflow reaches the Pending phase.
-func WaitForWorkflowPendingE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) error {
-	return WaitForWorkflowPhaseE(t, options, name, namespace, workflowv1alpha1.WorkflowPending, timeout)
+func WaitForWorkflowPendingE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) error {
+	return WaitForWorkflowPhaseE(t, options, name, namespace, workflowv1alpha1.WorkflowPending, timeout, opts...)
 }
@@ -0,0 +1,43 @@
+package workflows
+
+import (
+	"testing"
+	"time"
+
+	workflowv1alpha1 "github.com/argoproj/argo-workflows/v3/pkg/apis/workflow/v1alpha1"
+	"github.com/davidcollom/terratest-utils/pkg/wait"
+	"github.com/gruntwork-io/terratest/modules/k8s"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestWaitForWorkflowPhase(t *testing.T) {
+	tests := []struct {
+		name           string
+		phase          workflowv1alpha1.WorkflowPhase
+		expectError    bool
+		expectTerminal bool
+	}{
+		{name: "succeeded", phase: workflowv1alpha1.WorkflowSucceeded},
+		{name: "running", phase: workflowv1alpha1.WorkflowRunning, expectError: true},
+		{name: "failed", phase: workflowv1alpha1.WorkflowFailed, expectError: true, expectTerminal: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			NewTestClient(t, &workflowv1alpha1.Workflow{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-workflow", Namespace: "argo"},
+				Status:     workflowv1alpha1.WorkflowStatus{Phase: tc.phase},
+			})
+
+			err := WaitForWorkflowPhaseE(t, &k8s.KubectlOptions{}, "test-workflow", "argo", workflowv1alpha1.WorkflowSucceeded, 200*time.Millisecond, wait.WithInterval(10*time.Millisecond))
+
+			if tc.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectTerminal, wait.IsTerminalState(err))
+		})
+	}
+}
@@ -2,6 +2,7 @@ package certmanager
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/gruntwork-io/terratest/modules/testing"
@@ -13,8 +14,9 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/davidcollom/terratest-utils/pkg/wait"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
 )
 
 // ListCertificateRequests retrieves all CertificateRequest resources in the specified namespace
@@ -53,8 +55,9 @@ func ListCertificateRequestsE(t testing.TestingT, options *k8s.KubectlOptions, n
 }
 
 // WaitForCertificateRequestReadyE waits until the specified CertificateRequest resource in the given namespace
-// reaches the Ready condition within the provided timeout duration. It polls the resource status every 2 seconds.
-// If the CertificateRequest does not become Ready within the timeout, the function returns an error.
+// reaches the Ready condition within the provided timeout duration. It watches the resource status.
+// If the CertificateRequest does not become Ready within the timeout, the function returns an error. A request
+// that is denied, invalid or failed returns a *wait.TerminalStateError immediately.
 //
 // Parameters:
 //
@@ -66,25 +69,49 @@ func ListCertificateRequestsE(t testing.TestingT, options *k8s.KubectlOptions, n
 //
 // This function requires cert-manager clientset and is intended for use in integration tests.
 // WaitForCertificateRequestReadyE waits for the resource condition to be satisfied.
-func WaitForCertificateRequestReadyE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) error {
+func WaitForCertificateRequestReadyE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) error {
 	client, err := NewClient(t, options)
 	if err != nil {
 		return err
 	}
 
-	ctx := context.Background()
-	return wait.PollUntilContextTimeout(ctx, 2*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
-		cr, err := client.CertmanagerV1().CertificateRequests(namespace).Get(ctx, name, metav1.GetOptions{})
-		if err != nil {
-			return false, nil
-		}
-		for _, cond := range cr.Status.Conditions {
-			if cond.Type == cmv1.CertificateRequestConditionReady && cond.Status == cmmetav1.ConditionTrue {
-				return true, nil
+	_, err = wait.Waiter[*cmv1.CertificateRequest]{
+		Kind:      "CertificateRequest",
+		Name:      name,
+		Namespace: namespace,
+		Timeout:   timeout,
+		Get: func(ctx context.Context) (*cmv1.CertificateRequest, error) {
+			return client.CertmanagerV1().CertificateRequests(namespace).Get(ctx, name, metav1.GetOptions{})
+		},
+		Watch: func(ctx context.Context) (watch.Interface, error) {
+			return client.CertmanagerV1().CertificateRequests(namespace).Watch(ctx, metav1.SingleObject(metav1.ObjectMeta{Name: name}))
+		},
+		Ready: func(cr *cmv1.CertificateRequest) bool {
+			return HasCondition(cr.Status.Conditions, cmv1.CertificateRequestConditionReady, cmmetav1.ConditionTrue)
+		},
+		Failed: certificateRequestFailed,
+		Events: wait.KubeEvents(t, options),
+	}.Wait(t, opts...)
+	return err
+}
+
+// certificateRequestFailed reports a CertificateRequest that has been denied, is invalid, or has
+// failed to be signed as a terminal state. cert-manager never re-processes such a request.
+func certificateRequestFailed(cr *cmv1.CertificateRequest) error {
+	for _, cond := range cr.Status.Conditions {
+		switch {
+		case cond.Type == cmv1.CertificateRequestConditionReady && cond.Status == cmmetav1.ConditionFalse &&
+			(cond.Reason == cmv1.CertificateRequestReasonFailed || cond.Reason == cmv1.CertificateRequestReasonDenied),
+			cond.Type == cmv1.CertificateRequestConditionInvalidRequest && cond.Status == cmmetav1.ConditionTrue,
+			cond.Type == cmv1.CertificateRequestConditionDenied && cond.Status == cmmetav1.ConditionTrue:
+			return &wait.TerminalStateError{
+				Phase:   fmt.Sprintf("%s=%s", cond.Type, cond.Status),
+				Reason:  cond.Reason,
+				Message: cond.Message,
 			}
 		}
-		return false, nil
-	})
+	}
+	return nil
 }
 
 // WaitForCertificateRequestReady waits until the specified CertificateRequest resource in the given namespace
@@ -99,7 +126,7 @@ func WaitForCertificateRequestReadyE(t testing.TestingT, options *k8s.KubectlOpt
 //   - timeout: The maximum duration to wait for the CertificateRequest to become ready.
 //
 // WaitForCertificateRequestReady waits for the resource condition to be satisfied.
-func WaitForCertificateRequestReady(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) {
-	err := WaitForCertificateRequestReadyE(t, options, name, namespace, timeout)
+func WaitForCertificateRequestReady(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) {
+	err := WaitForCertificateRequestReadyE(t, options, name, namespace, timeout, opts...)
 	require.NoError(t, err)
 }
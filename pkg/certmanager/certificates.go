@@ -13,7 +13,9 @@ import (
 	"github.com/stretchr/testify/require"
 
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/util/wait"
+
+	"github.com/davidcollom/terratest-utils/pkg/wait"
+	"k8s.io/apimachinery/pkg/watch"
 )
 
 // ListCertificates retrieves all cert-manager Certificate resources in the specified namespace.
@@ -53,8 +55,8 @@ func ListCertificatesE(t testing.TestingT, options *k8s.KubectlOptions, namespac
 }
 
 // WaitForCertificateReady waits until the specified cert-manager Certificate resource is in the Ready state.
-// It polls the Certificate status at regular intervals until the Ready condition is true or the timeout is reached.
-// If the Certificate does not become Ready within the timeout, the test fails.
+// It watches the Certificate status until the Ready condition is true or the timeout is reached.
+// If the Certificate does not become Ready within the timeout, or its issuance fails, the test fails.
 // Parameters:
 //   - t: The testing context.
 //   - options: Kubectl options containing the Kubernetes REST config.
@@ -63,32 +65,93 @@ func ListCertificatesE(t testing.TestingT, options *k8s.KubectlOptions, namespac
 //   - timeout: The maximum duration to wait for the Certificate to become Ready.
 //
 // WaitForCertificateReady waits for the resource condition to be satisfied.
-func WaitForCertificateReady(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) {
-	err := WaitForCertificateReadyE(t, options, name, namespace, timeout)
+func WaitForCertificateReady(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) {
+	err := WaitForCertificateReadyE(t, options, name, namespace, timeout, opts...)
 	require.NoError(t, err, "Certificate %s/%s was not Ready in time", namespace, name)
 }
 
 // WaitForCertificateReadyE waits for the resource condition to be satisfied.
-func WaitForCertificateReadyE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) error {
+// It returns a *wait.TerminalStateError as soon as the Certificate's issuance fails.
+func WaitForCertificateReadyE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) error {
 	client, err := NewClient(t, options)
 	if err != nil {
 		return err
 	}
 
-	ctx := context.Background()
-	return wait.PollUntilContextTimeout(ctx, 2*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
-		cert, err := client.CertmanagerV1().Certificates(namespace).Get(ctx, name, v1.GetOptions{})
-		if err != nil {
-			return false, nil // retry
-		}
-
-		for _, cond := range cert.Status.Conditions {
-			if cond.Type == certv1.CertificateConditionReady && cond.Status == cmmetav1.ConditionTrue {
-				return true, nil
+	_, err = wait.Waiter[*certv1.Certificate]{
+		Kind:      "Certificate",
+		Name:      name,
+		Namespace: namespace,
+		Timeout:   timeout,
+		Get: func(ctx context.Context) (*certv1.Certificate, error) {
+			return client.CertmanagerV1().Certificates(namespace).Get(ctx, name, v1.GetOptions{})
+		},
+		Watch: func(ctx context.Context) (watch.Interface, error) {
+			return client.CertmanagerV1().Certificates(namespace).Watch(ctx, v1.SingleObject(v1.ObjectMeta{Name: name}))
+		},
+		Ready: func(cert *certv1.Certificate) bool {
+			for _, cond := range cert.Status.Conditions {
+				if cond.Type == certv1.CertificateConditionReady && cond.Status == cmmetav1.ConditionTrue {
+					return true
+				}
 			}
+			return false
+		},
+		Failed: certificateFailed,
+		Events: wait.KubeEvents(t, options),
+	}.Wait(t, opts...)
+	return err
+}
+
+// certificateFailed reports a failed issuance as a terminal state. cert-manager backs off for at
+// least an hour before retrying a failed issuance, far longer than any test should wait.
+func certificateFailed(cert *certv1.Certificate) error {
+	for _, cond := range cert.Status.Conditions {
+		if cond.Type == certv1.CertificateConditionIssuing && cond.Status == cmmetav1.ConditionFalse &&
+			cond.Reason == certv1.CertificateRequestReasonFailed {
+			return &wait.TerminalStateError{Phase: string(cond.Type) + "=False", Reason: cond.Reason, Message: cond.Message}
 		}
-		return false, nil
-	})
+	}
+	return nil
+}
+
+// WaitForCertificateDeleted waits until the specified cert-manager Certificate no longer exists or the timeout is reached.
+// If the Certificate is still present when the timeout is reached, the test fails with the finalizers holding it up.
+// Parameters:
+//   - t: The testing context.
+//   - options: Kubectl options containing the Kubernetes REST config.
+//   - name: The name of the Certificate resource.
+//   - namespace: The namespace of the Certificate resource.
+//   - timeout: The maximum duration to wait for the Certificate to be deleted.
+func WaitForCertificateDeleted(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) {
+	err := WaitForCertificateDeletedE(t, options, name, namespace, timeout, opts...)
+	require.NoError(t, err, "Certificate %s/%s was not deleted in time", namespace, name)
+}
+
+// WaitForCertificateDeletedE waits until the Certificate is gone. It does not delete the Certificate.
+// cert-manager leaves the Secret holding the certificate in place unless it runs with
+// --enable-certificate-owner-ref, in which case the Secret is garbage collected afterwards.
+func WaitForCertificateDeletedE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) error {
+	client, err := NewClient(t, options)
+	if err != nil {
+		return err
+	}
+
+	_, err = wait.Waiter[*certv1.Certificate]{
+		Kind:         "Certificate",
+		Name:         name,
+		Namespace:    namespace,
+		Timeout:      timeout,
+		UntilDeleted: true,
+		Get: func(ctx context.Context) (*certv1.Certificate, error) {
+			return client.CertmanagerV1().Certificates(namespace).Get(ctx, name, v1.GetOptions{})
+		},
+		Watch: func(ctx context.Context) (watch.Interface, error) {
+			return client.CertmanagerV1().Certificates(namespace).Watch(ctx, v1.SingleObject(v1.ObjectMeta{Name: name}))
+		},
+		Events: wait.KubeEvents(t, options),
+	}.Wait(t, opts...)
+	return err
 }
 
 // ValidateCertificateSecret verifies that the Kubernetes Secret referenced by the given
@@ -1,28 +1,31 @@
 // Package certmanager provides Terratest-style helpers for testing cert-manager
 // resources including Certificates, Issuers, ClusterIssuers, CertificateRequests,
-// ACME Orders, and Challenges. These helpers use client-go and polling logic to
+// ACME Orders, and Challenges. These helpers use client-go watches to
 // wait for readiness conditions and validate associated Secrets.
 package certmanager
 
 import (
 	"github.com/gruntwork-io/terratest/modules/testing"
 
+	acmev1 "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
 	cmv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmetav1 "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 	cmclientset "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
-	"github.com/davidcollom/terratest-utils/pkg/utils"
+	"github.com/davidcollom/terratest-utils/pkg/clients"
+	"github.com/davidcollom/terratest-utils/pkg/wait"
 	"github.com/gruntwork-io/terratest/modules/k8s"
 	"k8s.io/client-go/rest"
 )
 
 // NewClient creates and returns a new cert-manager clientset.Interface using the provided testing context and kubectl options.
 // If the RestConfig in options is nil, it attempts to generate a new rest.Config using the provided options.
+// The clientset is cached per cluster by pkg/clients, so repeated calls reuse the same connection.
 // Returns the cert-manager clientset.Interface or an error if the configuration could not be created.
 //
 // Parameters:
 //   - t: The testing context, used for error reporting and helper annotation.
 //   - options: The kubectl options containing cluster access configuration. If options.RestConfig is nil,
-//     the function will attempt to generate a rest.Config using utils.GetRestConfigE.
+//     the function will attempt to generate a rest.Config using clients.RestConfigE.
 //
 // Returns:
 //   - cmclientset.Interface: The cert-manager clientset for interacting with cert-manager resources.
@@ -37,18 +40,9 @@ import (
 var NewClient = newClient
 
 func newClient(t testing.TestingT, options *k8s.KubectlOptions) (cmclientset.Interface, error) {
-	var cfg *rest.Config
-	var err error
-	if options.RestConfig == nil {
-		cfg, err = utils.GetRestConfigE(t, options)
-		if err != nil {
-			return nil, err
-		}
-	} else {
-		cfg = options.RestConfig
-	}
-
-	return cmclientset.NewForConfig(cfg)
+	return clients.Get(t, options, "cert-manager", func(cfg *rest.Config) (cmclientset.Interface, error) {
+		return cmclientset.NewForConfig(cfg)
+	})
 }
 
 // HasCondition checks if a slice of CertificateRequestCondition contains a condition
@@ -73,3 +67,13 @@ func HasCondition(conds []cmv1.CertificateRequestCondition, condType cmv1.Certif
 	}
 	return false
 }
+
+// acmeStateFailed reports the final ACME states of an Order or Challenge (invalid, errored and
+// expired) as terminal. The ACME server never moves a resource out of these states.
+func acmeStateFailed(state acmev1.State, reason string) error {
+	switch state {
+	case acmev1.Invalid, acmev1.Errored, acmev1.Expired:
+		return &wait.TerminalStateError{Phase: string(state), Reason: reason}
+	}
+	return nil
+}
@@ -7,16 +7,23 @@ import (
 	cmv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmetav1 "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
 
+	"github.com/davidcollom/terratest-utils/pkg/k8s"
+	"github.com/davidcollom/terratest-utils/pkg/wait"
+	"github.com/davidcollom/terratest-utils/pkg/waittest"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 func TestWaitForCertificateRequestReady(t *testing.T) {
 	tests := []struct {
-		name        string
-		conditions  []cmv1.CertificateRequestCondition
-		expectError bool
+		name           string
+		conditions     []cmv1.CertificateRequestCondition
+		expectError    bool
+		expectTerminal bool
 	}{
 		{
 			name:        "No Conditions",
@@ -37,13 +44,26 @@ func TestWaitForCertificateRequestReady(t *testing.T) {
 			},
 			expectError: true,
 		},
+		{
+			name: "ready false reason failed",
+			conditions: []cmv1.CertificateRequestCondition{
+				{Type: cmv1.CertificateRequestConditionReady, Status: cmmetav1.ConditionFalse, Reason: cmv1.CertificateRequestReasonFailed},
+			},
+			expectError:    true,
+			expectTerminal: true,
+		},
+		{
+			name: "denied",
+			conditions: []cmv1.CertificateRequestCondition{
+				{Type: cmv1.CertificateRequestConditionDenied, Status: cmmetav1.ConditionTrue, Reason: "Foo", Message: "denied by policy"},
+			},
+			expectError:    true,
+			expectTerminal: true,
+		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			scheme := runtime.NewScheme()
-			_ = cmv1.AddToScheme(scheme)
-
 			NewTestClient(t, &cmv1.CertificateRequest{
 				ObjectMeta: metav1.ObjectMeta{
 					Name:      "test-cr",
@@ -54,7 +74,7 @@ func TestWaitForCertificateRequestReady(t *testing.T) {
 				},
 			})
 
-			err := WaitForCertificateRequestReadyE(t, k8soptions, "test-cr", "default", 10*time.Second)
+			err := WaitForCertificateRequestReadyE(t, k8soptions, "test-cr", "default", 10*time.Second, wait.WithClock(waittest.NewClock()))
 
 			if tc.expectError && err == nil {
 				assert.Error(t, err)
@@ -64,6 +84,103 @@ func TestWaitForCertificateRequestReady(t *testing.T) {
 				assert.NoError(t, err)
 				t.Fatalf("unexpected error: %v", err)
 			}
+			assert.Equal(t, tc.expectTerminal, wait.IsTerminalState(err))
 		})
 	}
 }
+
+func TestWaitForCertificateRequestReadyScripted(t *testing.T) {
+	request := func(conditions ...cmv1.CertificateRequestCondition) *cmv1.CertificateRequest {
+		return &cmv1.CertificateRequest{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-cr", Namespace: "default"},
+			Status:     cmv1.CertificateRequestStatus{Conditions: conditions},
+		}
+	}
+	pending := request(cmv1.CertificateRequestCondition{
+		Type: cmv1.CertificateRequestConditionReady, Status: cmmetav1.ConditionFalse, Reason: cmv1.CertificateRequestReasonPending, Message: "waiting for issuer",
+	})
+	ready := request(cmv1.CertificateRequestCondition{
+		Type: cmv1.CertificateRequestConditionReady, Status: cmmetav1.ConditionTrue,
+	})
+	failed := request(cmv1.CertificateRequestCondition{
+		Type: cmv1.CertificateRequestConditionReady, Status: cmmetav1.ConditionFalse, Reason: cmv1.CertificateRequestReasonFailed, Message: "issuer rejected the request",
+	})
+
+	t.Run("pending then ready after three polls", func(t *testing.T) {
+		clock := waittest.NewClock()
+		script := waittest.NewScript(clock, "default", "test-cr").Return(pending).Return(pending).Return(ready)
+		waittest.Prepend(t, NewTestClient(t), "certificaterequests", script)
+
+		err := WaitForCertificateRequestReadyE(t, k8soptions, "test-cr", "default", time.Minute, wait.WithClock(clock), wait.WithInterval(5*time.Second))
+		require.NoError(t, err)
+		assert.Equal(t, 3, script.Gets())
+		assert.Equal(t, 10*time.Second, clock.Elapsed())
+	})
+
+	t.Run("missing then pending then failed fails fast", func(t *testing.T) {
+		clock := waittest.NewClock()
+		script := waittest.NewScript(clock, "default", "test-cr").
+			ReturnError(apierrors.NewNotFound(schema.GroupResource{Group: "cert-manager.io", Resource: "certificaterequests"}, "test-cr")).
+			At(30*time.Second, pending).
+			At(2*time.Minute, failed)
+		waittest.Prepend(t, NewTestClient(t), "certificaterequests", script)
+
+		err := WaitForCertificateRequestReadyE(t, k8soptions, "test-cr", "default", 10*time.Minute, wait.WithClock(clock))
+		require.True(t, wait.IsTerminalState(err), "expected a terminal state error, got %v", err)
+		assert.Contains(t, err.Error(), "issuer rejected the request")
+		assert.Equal(t, 2*time.Minute, clock.Elapsed())
+	})
+
+	t.Run("timeout reports status, last error and events", func(t *testing.T) {
+		clock := waittest.NewClock()
+		script := waittest.NewScript(clock, "default", "test-cr").
+			Return(pending).
+			AtError(time.Minute, apierrors.NewServiceUnavailable("apiserver restarting"))
+		waittest.Prepend(t, NewTestClient(t), "certificaterequests", script)
+		k8s.NewTestClient(t, &corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: "test-cr.1", Namespace: "default"},
+			InvolvedObject: corev1.ObjectReference{Kind: "CertificateRequest", Name: "test-cr", Namespace: "default"},
+			Type:           corev1.EventTypeWarning,
+			Reason:         "IssuerNotReady",
+			Message:        "Referenced issuer does not have a Ready status condition",
+		})
+
+		err := WaitForCertificateRequestReadyE(t, k8soptions, "test-cr", "default", 5*time.Minute, wait.WithClock(clock))
+		var timeout *wait.TimeoutError
+		require.ErrorAs(t, err, &timeout)
+		assert.Equal(t, 5*time.Minute, clock.Elapsed())
+		require.Len(t, timeout.Status.Conditions, 1)
+		assert.Equal(t, "Pending", timeout.Status.Conditions[0].Reason)
+		assert.True(t, apierrors.IsServiceUnavailable(timeout.LastGetError))
+		require.Len(t, timeout.Events, 1)
+		assert.Equal(t, "IssuerNotReady", timeout.Events[0].Reason)
+	})
+}
+
+func TestWaitForCertificateDeleted(t *testing.T) {
+	deleting := &cmv1.Certificate{ObjectMeta: metav1.ObjectMeta{
+		Name:              "test-cert",
+		Namespace:         "default",
+		DeletionTimestamp: &metav1.Time{Time: time.Now()},
+		Finalizers:        []string{"example.com/revoke"},
+	}}
+	notFound := apierrors.NewNotFound(schema.GroupResource{Group: "cert-manager.io", Resource: "certificates"}, "test-cert")
+
+	t.Run("deleted", func(t *testing.T) {
+		clock := waittest.NewClock()
+		waittest.Prepend(t, NewTestClient(t), "certificates", waittest.NewScript(clock, "default", "test-cert").
+			Return(deleting).
+			AtError(time.Minute, notFound))
+
+		WaitForCertificateDeleted(t, k8soptions, "test-cert", "default", 5*time.Minute, wait.WithClock(clock))
+		assert.Equal(t, time.Minute, clock.Elapsed())
+	})
+
+	t.Run("timeout reports finalizers", func(t *testing.T) {
+		NewTestClient(t, deleting)
+
+		err := WaitForCertificateDeletedE(t, k8soptions, "test-cert", "default", 5*time.Minute, wait.WithClock(waittest.NewClock()))
+		require.True(t, wait.IsTimeout(err), "expected a timeout, got %v", err)
+		assert.Contains(t, err.Error(), "deletion blocked by finalizers: example.com/revoke")
+	})
+}
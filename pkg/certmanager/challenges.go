@@ -12,8 +12,9 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"github.com/davidcollom/terratest-utils/pkg/wait"
 	"github.com/gruntwork-io/terratest/modules/k8s"
-	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
 )
 
 // ListChallenges retrieves a list of ACME Challenge resources from the specified namespace
@@ -53,8 +54,8 @@ func ListChallengesE(t testing.TestingT, options *k8s.KubectlOptions, namespace
 }
 
 // WaitForChallengeValid waits until the specified ACME Challenge resource in the given namespace
-// reaches the "Valid" state or the timeout is exceeded. It polls the challenge status at regular
-// intervals using the cert-manager clientset. If the challenge does not become valid within the
+// reaches the "Valid" state or the timeout is exceeded. It watches the challenge status, falling
+// back to polling, using the cert-manager clientset. If the challenge does not become valid within the
 // timeout, the test fails with a fatal error.
 //
 // Parameters:
@@ -67,24 +68,37 @@ func ListChallengesE(t testing.TestingT, options *k8s.KubectlOptions, namespace
 //
 // Fails the test if the challenge does not reach the "Valid" state within the timeout.
 // WaitForChallengeValid waits for the resource condition to be satisfied.
-func WaitForChallengeValid(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) {
-	err := WaitForChallengeValidE(t, options, name, namespace, timeout)
+func WaitForChallengeValid(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) {
+	err := WaitForChallengeValidE(t, options, name, namespace, timeout, opts...)
 	require.NoError(t, err, "ACME Challenge %s/%s not in Valid state", namespace, name)
 }
 
 // WaitForChallengeValidE waits for the resource condition to be satisfied.
-func WaitForChallengeValidE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) error {
+// It returns a *wait.TerminalStateError as soon as the Challenge becomes invalid, errored or expired.
+func WaitForChallengeValidE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) error {
 	client, err := NewClient(t, options)
 	if err != nil {
 		return err
 	}
 
-	ctx := context.Background()
-	return wait.PollUntilContextTimeout(ctx, 2*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
-		challenge, err := client.AcmeV1().Challenges(namespace).Get(context.TODO(), name, metav1.GetOptions{})
-		if err != nil {
-			return false, nil
-		}
-		return challenge.Status.State == acmev1.Valid, nil
-	})
+	_, err = wait.Waiter[*acmev1.Challenge]{
+		Kind:      "Challenge",
+		Name:      name,
+		Namespace: namespace,
+		Timeout:   timeout,
+		Get: func(ctx context.Context) (*acmev1.Challenge, error) {
+			return client.AcmeV1().Challenges(namespace).Get(ctx, name, metav1.GetOptions{})
+		},
+		Watch: func(ctx context.Context) (watch.Interface, error) {
+			return client.AcmeV1().Challenges(namespace).Watch(ctx, metav1.SingleObject(metav1.ObjectMeta{Name: name}))
+		},
+		Ready: func(challenge *acmev1.Challenge) bool {
+			return challenge.Status.State == acmev1.Valid
+		},
+		Failed: func(challenge *acmev1.Challenge) error {
+			return acmeStateFailed(challenge.Status.State, challenge.Status.Reason)
+		},
+		Events: wait.KubeEvents(t, options),
+	}.Wait(t, opts...)
+	return err
 }
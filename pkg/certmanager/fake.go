@@ -0,0 +1,32 @@
+package certmanager
+
+import (
+	cmclientset "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned"
+	fakecm "github.com/cert-manager/cert-manager/pkg/client/clientset/versioned/fake"
+
+	"github.com/davidcollom/terratest-utils/pkg/utils"
+	"github.com/gruntwork-io/terratest/modules/k8s"
+	"github.com/gruntwork-io/terratest/modules/testing"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// NewTestClient creates a fake cert-manager clientset seeded with objs and makes NewClient return it
+// for the rest of the test, so that every helper in this package runs against the fake. NewClient is
+// restored when the test finishes.
+//
+// Example usage:
+//
+//	certmanager.NewTestClient(t, &cmv1.Certificate{...})
+//	certmanager.WaitForCertificateReady(t, &k8s.KubectlOptions{}, "my-cert", "default", time.Second)
+func NewTestClient(t utils.CleanupT, objs ...runtime.Object) cmclientset.Interface {
+	client := fakecm.NewClientset(objs...)
+
+	NewClient = func(t testing.TestingT, options *k8s.KubectlOptions) (cmclientset.Interface, error) {
+		return client, nil
+	}
+	t.Cleanup(func() {
+		NewClient = newClient
+	})
+
+	return client
+}
@@ -8,10 +8,11 @@ import (
 
 	cmv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
 	cmmetav1 "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/davidcollom/terratest-utils/pkg/wait"
 	"github.com/gruntwork-io/terratest/modules/k8s"
 	"github.com/stretchr/testify/require"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
 )
 
 // ListIssuers retrieves a list of cert-manager Issuer resources from the specified namespace.
@@ -51,7 +52,7 @@ func ListIssuersE(t testing.TestingT, options *k8s.KubectlOptions, namespace str
 }
 
 // WaitForIssuerReady waits until the specified cert-manager Issuer resource is in the Ready condition within the given timeout.
-// It polls the Issuer status every 2 seconds and fails the test if the Issuer does not become Ready within the timeout period.
+// It watches the Issuer status, falling back to polling, and fails the test if the Issuer does not become Ready within the timeout period.
 // Parameters:
 //   - t: The testing context.
 //   - options: Kubectl options containing the Kubernetes REST config.
@@ -61,31 +62,35 @@ func ListIssuersE(t testing.TestingT, options *k8s.KubectlOptions, namespace str
 //
 // Fails the test if the Issuer is not Ready within the timeout or if there is an error creating the cert-manager clientset.
 // WaitForIssuerReady waits for the resource condition to be satisfied.
-func WaitForIssuerReady(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) {
-	err := WaitForIssuerReadyE(t, options, name, namespace, timeout)
+func WaitForIssuerReady(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) {
+	err := WaitForIssuerReadyE(t, options, name, namespace, timeout, opts...)
 	require.NoError(t, err, "Issuer %s/%s not Ready", namespace, name)
 }
 
 // WaitForIssuerReadyE waits for the resource condition to be satisfied.
-func WaitForIssuerReadyE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) error {
+func WaitForIssuerReadyE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) error {
 	client, err := NewClient(t, options)
 	if err != nil {
 		return err
 	}
 
-	ctx := context.Background()
-	return wait.PollUntilContextTimeout(ctx, 2*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
-		issuer, err := client.CertmanagerV1().Issuers(namespace).Get(ctx, name, metav1.GetOptions{})
-		if err != nil {
-			return false, nil
-		}
-		for _, cond := range issuer.Status.Conditions {
-			if cond.Type == cmv1.IssuerConditionReady && cond.Status == cmmetav1.ConditionTrue {
-				return true, nil
-			}
-		}
-		return false, nil
-	})
+	_, err = wait.Waiter[*cmv1.Issuer]{
+		Kind:      "Issuer",
+		Name:      name,
+		Namespace: namespace,
+		Timeout:   timeout,
+		Get: func(ctx context.Context) (*cmv1.Issuer, error) {
+			return client.CertmanagerV1().Issuers(namespace).Get(ctx, name, metav1.GetOptions{})
+		},
+		Watch: func(ctx context.Context) (watch.Interface, error) {
+			return client.CertmanagerV1().Issuers(namespace).Watch(ctx, metav1.SingleObject(metav1.ObjectMeta{Name: name}))
+		},
+		Ready: func(issuer *cmv1.Issuer) bool {
+			return isIssuerReady(issuer.Status)
+		},
+		Events: wait.KubeEvents(t, options),
+	}.Wait(t, opts...)
+	return err
 }
 
 // ListClusterIssuers retrieves a list of cert-manager ClusterIssuer resources from the Kubernetes cluster
@@ -124,7 +129,7 @@ func ListClusterIssuersE(t testing.TestingT, options *k8s.KubectlOptions) ([]cmv
 }
 
 // WaitForClusterIssuerReady waits until the specified cert-manager ClusterIssuer resource is in the Ready state.
-// It polls the ClusterIssuer status at regular intervals until the Ready condition is true or the timeout is reached.
+// It watches the ClusterIssuer status until the Ready condition is true or the timeout is reached.
 // If the ClusterIssuer does not become Ready within the timeout, the test fails.
 //
 // Parameters:
@@ -136,29 +141,42 @@ func ListClusterIssuersE(t testing.TestingT, options *k8s.KubectlOptions) ([]cmv
 //
 // This function requires a cert-manager clientset and uses the provided REST config to interact with the Kubernetes API.
 // WaitForClusterIssuerReady waits for the resource condition to be satisfied.
-func WaitForClusterIssuerReady(t testing.TestingT, options *k8s.KubectlOptions, name string, timeout time.Duration) {
-	err := WaitForClusterIssuerReadyE(t, options, name, timeout)
+func WaitForClusterIssuerReady(t testing.TestingT, options *k8s.KubectlOptions, name string, timeout time.Duration, opts ...wait.WaitOption) {
+	err := WaitForClusterIssuerReadyE(t, options, name, timeout, opts...)
 	require.NoError(t, err, "ClusterIssuer %s not Ready", name)
 }
 
 // WaitForClusterIssuerReadyE waits for the resource condition to be satisfied.
-func WaitForClusterIssuerReadyE(t testing.TestingT, options *k8s.KubectlOptions, name string, timeout time.Duration) error {
+func WaitForClusterIssuerReadyE(t testing.TestingT, options *k8s.KubectlOptions, name string, timeout time.Duration, opts ...wait.WaitOption) error {
 	client, err := NewClient(t, options)
 	if err != nil {
 		return err
 	}
 
-	ctx := context.Background()
-	return wait.PollUntilContextTimeout(ctx, 2*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
-		issuer, err := client.CertmanagerV1().ClusterIssuers().Get(ctx, name, metav1.GetOptions{})
-		if err != nil {
-			return false, nil
-		}
-		for _, cond := range issuer.Status.Conditions {
-			if cond.Type == cmv1.IssuerConditionReady && cond.Status == cmmetav1.ConditionTrue {
-				return true, nil
-			}
+	_, err = wait.Waiter[*cmv1.ClusterIssuer]{
+		Kind:    "ClusterIssuer",
+		Name:    name,
+		Timeout: timeout,
+		Get: func(ctx context.Context) (*cmv1.ClusterIssuer, error) {
+			return client.CertmanagerV1().ClusterIssuers().Get(ctx, name, metav1.GetOptions{})
+		},
+		Watch: func(ctx context.Context) (watch.Interface, error) {
+			return client.CertmanagerV1().ClusterIssuers().Watch(ctx, metav1.SingleObject(metav1.ObjectMeta{Name: name}))
+		},
+		Ready: func(issuer *cmv1.ClusterIssuer) bool {
+			return isIssuerReady(issuer.Status)
+		},
+		Events: wait.KubeEvents(t, options),
+	}.Wait(t, opts...)
+	return err
+}
+
+// isIssuerReady reports whether an Issuer or ClusterIssuer status carries a Ready=True condition.
+func isIssuerReady(status cmv1.IssuerStatus) bool {
+	for _, cond := range status.Conditions {
+		if cond.Type == cmv1.IssuerConditionReady && cond.Status == cmmetav1.ConditionTrue {
+			return true
 		}
-		return false, nil
-	})
+	}
+	return false
 }
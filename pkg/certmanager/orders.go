@@ -6,12 +6,13 @@ import (
 
 	"github.com/gruntwork-io/terratest/modules/testing"
 
+	"github.com/davidcollom/terratest-utils/pkg/wait"
 	"github.com/gruntwork-io/terratest/modules/k8s"
 	"github.com/stretchr/testify/require"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/util/wait"
 
 	acmev1 "github.com/cert-manager/cert-manager/pkg/apis/acme/v1"
+	"k8s.io/apimachinery/pkg/watch"
 )
 
 // ListOrders retrieves a list of ACME Order resources from the specified namespace using the cert-manager client.
@@ -50,7 +51,7 @@ func ListOrdersE(t testing.TestingT, options *k8s.KubectlOptions, namespace stri
 }
 
 // WaitForOrderValid waits until the specified ACME Order resource in the given namespace reaches the "Valid" state or the timeout is exceeded.
-// It polls the Order status every 2 seconds using the cert-manager clientset.
+// It watches the Order status, falling back to polling, using the cert-manager clientset.
 // If the Order does not reach the "Valid" state within the timeout, the test fails with a fatal error.
 //
 // Parameters:
@@ -63,24 +64,37 @@ func ListOrdersE(t testing.TestingT, options *k8s.KubectlOptions, namespace stri
 //
 // Fails the test if the Order does not reach the "Valid" state within the specified timeout.
 // WaitForOrderValid waits for the resource condition to be satisfied.
-func WaitForOrderValid(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) {
-	err := WaitForOrderValidE(t, options, name, namespace, timeout)
+func WaitForOrderValid(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) {
+	err := WaitForOrderValidE(t, options, name, namespace, timeout, opts...)
 	require.NoError(t, err, "ACME Order %s/%s not in Valid state", namespace, name)
 }
 
 // WaitForOrderValidE waits for the resource condition to be satisfied.
-func WaitForOrderValidE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) error {
+// It returns a *wait.TerminalStateError as soon as the Order becomes invalid, errored or expired.
+func WaitForOrderValidE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) error {
 	client, err := NewClient(t, options)
 	if err != nil {
 		return err
 	}
 
-	ctx := context.Background()
-	return wait.PollUntilContextTimeout(ctx, 2*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
-		order, err := client.AcmeV1().Orders(namespace).Get(ctx, name, metav1.GetOptions{})
-		if err != nil {
-			return false, nil
-		}
-		return order.Status.State == acmev1.Valid, nil
-	})
+	_, err = wait.Waiter[*acmev1.Order]{
+		Kind:      "Order",
+		Name:      name,
+		Namespace: namespace,
+		Timeout:   timeout,
+		Get: func(ctx context.Context) (*acmev1.Order, error) {
+			return client.AcmeV1().Orders(namespace).Get(ctx, name, metav1.GetOptions{})
+		},
+		Watch: func(ctx context.Context) (watch.Interface, error) {
+			return client.AcmeV1().Orders(namespace).Watch(ctx, metav1.SingleObject(metav1.ObjectMeta{Name: name}))
+		},
+		Ready: func(order *acmev1.Order) bool {
+			return order.Status.State == acmev1.Valid
+		},
+		Failed: func(order *acmev1.Order) error {
+			return acmeStateFailed(order.Status.State, order.Status.Reason)
+		},
+		Events: wait.KubeEvents(t, options),
+	}.Wait(t, opts...)
+	return err
 }
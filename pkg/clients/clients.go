@@ -0,0 +1,177 @@
+// Package clients provides a process-wide cache of Kubernetes REST configs and clientsets shared by
+// every helper in terratest-utils.
+//
+// Building a clientset means parsing kubeconfig, building a scheme and opening new connections, so a
+// platform test that calls thousands of helpers would otherwise repeat that work on every call.
+// Clients are cached per cluster: by the RestConfig pointer when options.RestConfig is set, and by
+// kubeconfig path and context name otherwise (or once for the in-cluster config when
+// options.InClusterAuth is set). Each client type is cached under its own name.
+//
+// Example usage:
+//
+//	client, err := clients.Get(t, options, "cert-manager", func(cfg *rest.Config) (cmclientset.Interface, error) {
+//	    return cmclientset.NewForConfig(cfg)
+//	})
+//
+// Cached clients live for the rest of the test binary. Call Invalidate when a cluster is recreated
+// or its kubeconfig is rewritten, and Reset to drop every cached client.
+package clients
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/gruntwork-io/terratest/modules/k8s"
+	"github.com/gruntwork-io/terratest/modules/testing"
+	"k8s.io/client-go/rest"
+)
+
+// cluster identifies the cluster a KubectlOptions points at.
+type cluster struct {
+	inCluster   bool
+	restConfig  *rest.Config
+	configPath  string
+	contextName string
+}
+
+// key identifies a cached client.
+type key struct {
+	cluster
+	name string
+}
+
+var (
+	mu          sync.Mutex
+	restConfigs = map[cluster]*rest.Config{}
+	cache       = map[key]any{}
+)
+
+// clusterFor returns the cache key for the cluster described by options.
+func clusterFor(t testing.TestingT, options *k8s.KubectlOptions) (cluster, error) {
+	if options.InClusterAuth {
+		return cluster{inCluster: true}, nil
+	}
+	if options.RestConfig != nil {
+		return cluster{restConfig: options.RestConfig}, nil
+	}
+	configPath, err := options.GetConfigPath(t)
+	if err != nil {
+		return cluster{}, err
+	}
+	return cluster{configPath: configPath, contextName: options.ContextName}, nil
+}
+
+// RestConfigE returns the REST config for the cluster described by options. options.RestConfig is
+// returned as is when set; otherwise the in-cluster config (when options.InClusterAuth is set) or the
+// kubeconfig is loaded once and the result is cached.
+//
+// Parameters:
+//   - t: The testing context.
+//   - options: The kubectl options describing the cluster.
+//
+// Returns:
+//   - *rest.Config: The REST config for the cluster.
+//   - error: An error if the kubeconfig could not be loaded.
+func RestConfigE(t testing.TestingT, options *k8s.KubectlOptions) (*rest.Config, error) {
+	c, err := clusterFor(t, options)
+	if err != nil {
+		return nil, err
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	return restConfigFor(c)
+}
+
+// restConfigFor loads and caches the REST config for c. mu must be held.
+func restConfigFor(c cluster) (*rest.Config, error) {
+	if c.restConfig != nil {
+		return c.restConfig, nil
+	}
+	if cfg, ok := restConfigs[c]; ok {
+		return cfg, nil
+	}
+	var (
+		cfg *rest.Config
+		err error
+	)
+	if c.inCluster {
+		cfg, err = rest.InClusterConfig()
+	} else {
+		cfg, err = k8s.LoadApiClientConfigE(c.configPath, c.contextName)
+	}
+	if err != nil {
+		return nil, err
+	}
+	restConfigs[c] = cfg
+	return cfg, nil
+}
+
+// Get returns the client called name for the cluster described by options, calling newClient to
+// build it on first use. name distinguishes clients of the same Go type, such as controller-runtime
+// clients built with different schemes, so it must be unique per constructor.
+//
+// Parameters:
+//   - t: The testing context.
+//   - options: The kubectl options describing the cluster.
+//   - name: The name the client is cached under, e.g. "cert-manager".
+//   - newClient: Builds the client from a REST config.
+//
+// Returns:
+//   - T: The cached or newly built client.
+//   - error: An error if the REST config or client could not be created.
+func Get[T any](t testing.TestingT, options *k8s.KubectlOptions, name string, newClient func(cfg *rest.Config) (T, error)) (T, error) {
+	var zero T
+	c, err := clusterFor(t, options)
+	if err != nil {
+		return zero, err
+	}
+	k := key{cluster: c, name: name}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if cached, ok := cache[k]; ok {
+		client, ok := cached.(T)
+		if !ok {
+			return zero, fmt.Errorf("client %q is cached as %T, not %T", name, cached, zero)
+		}
+		return client, nil
+	}
+
+	cfg, err := restConfigFor(c)
+	if err != nil {
+		return zero, err
+	}
+	client, err := newClient(cfg)
+	if err != nil {
+		return zero, err
+	}
+	cache[k] = client
+	return client, nil
+}
+
+// Invalidate drops the REST config and every client cached for the cluster described by options,
+// so that the next call rebuilds them. Use it after recreating a cluster or rewriting its kubeconfig.
+func Invalidate(t testing.TestingT, options *k8s.KubectlOptions) {
+	c, err := clusterFor(t, options)
+	if err != nil {
+		return
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	delete(restConfigs, c)
+	for k := range cache {
+		if k.cluster == c {
+			delete(cache, k)
+		}
+	}
+}
+
+// Reset drops every cached REST config and client.
+func Reset() {
+	mu.Lock()
+	defer mu.Unlock()
+	clear(restConfigs)
+	clear(cache)
+}
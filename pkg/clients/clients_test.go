@@ -0,0 +1,97 @@
+package clients
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gruntwork-io/terratest/modules/k8s"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+const kubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: test
+  cluster:
+    server: https://example.com
+contexts:
+- name: test
+  context:
+    cluster: test
+    user: test
+current-context: test
+users:
+- name: test
+  user:
+    token: abc
+`
+
+func newKubernetesClient(cfg *rest.Config) (kubernetes.Interface, error) {
+	return kubernetes.NewForConfig(cfg)
+}
+
+func TestGet(t *testing.T) {
+	t.Cleanup(Reset)
+
+	options := &k8s.KubectlOptions{RestConfig: &rest.Config{Host: "https://example.com"}}
+	builds := 0
+	newClient := func(cfg *rest.Config) (kubernetes.Interface, error) {
+		builds++
+		return newKubernetesClient(cfg)
+	}
+
+	first, err := Get(t, options, "kubernetes", newClient)
+	require.NoError(t, err)
+	second, err := Get(t, options, "kubernetes", newClient)
+	require.NoError(t, err)
+	assert.Same(t, first, second)
+	assert.Equal(t, 1, builds)
+
+	// A different name is a different client, even for the same type.
+	other, err := Get(t, options, "other", newClient)
+	require.NoError(t, err)
+	assert.NotSame(t, first, other)
+
+	// A different RestConfig is a different cluster.
+	_, err = Get(t, &k8s.KubectlOptions{RestConfig: &rest.Config{Host: "https://example.com"}}, "kubernetes", newClient)
+	require.NoError(t, err)
+	assert.Equal(t, 3, builds)
+
+	// A name reused with another type is an error rather than a panic.
+	_, err = Get(t, options, "kubernetes", func(cfg *rest.Config) (*rest.Config, error) { return cfg, nil })
+	assert.Error(t, err)
+
+	Invalidate(t, options)
+	rebuilt, err := Get(t, options, "kubernetes", newClient)
+	require.NoError(t, err)
+	assert.NotSame(t, first, rebuilt)
+	assert.Equal(t, 4, builds)
+}
+
+func TestRestConfigE(t *testing.T) {
+	t.Cleanup(Reset)
+
+	path := filepath.Join(t.TempDir(), "config")
+	require.NoError(t, os.WriteFile(path, []byte(kubeconfig), 0o600))
+	options := k8s.NewKubectlOptions("test", path, "default")
+
+	first, err := RestConfigE(t, options)
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com", first.Host)
+
+	second, err := RestConfigE(t, options)
+	require.NoError(t, err)
+	assert.Same(t, first, second)
+
+	Invalidate(t, options)
+	third, err := RestConfigE(t, options)
+	require.NoError(t, err)
+	assert.NotSame(t, first, third)
+
+	_, err = RestConfigE(t, k8s.NewKubectlOptions("missing", path, "default"))
+	assert.Error(t, err)
+}
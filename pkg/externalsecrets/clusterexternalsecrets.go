@@ -10,9 +10,9 @@ import (
 	"github.com/gruntwork-io/terratest/modules/k8s"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/davidcollom/terratest-utils/pkg/wait"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/util/wait"
 )
 
 // ListClusterExternalSecrets retrieves a list of ClusterExternalSecret resources from the specified namespace
@@ -53,7 +53,7 @@ func ListClusterExternalSecretsE(t testing.TestingT, options *k8s.KubectlOptions
 }
 
 // WaitForClusterExternalSecretReady waits until the specified ClusterExternalSecret resource in the given namespace
-// becomes ready within the provided timeout duration. It polls the resource status at regular intervals and fails the test
+// becomes ready within the provided timeout duration. It watches the resource status, falling back to polling, and fails the test
 // if the resource does not become ready in time. This function requires a valid External Secrets client and uses the
 // provided k8s.KubectlOptions for cluster access.
 //
@@ -66,31 +66,35 @@ func ListClusterExternalSecretsE(t testing.TestingT, options *k8s.KubectlOptions
 //
 // Fails the test if the ClusterExternalSecret does not become ready within the timeout.
 // WaitForClusterExternalSecretReady waits for the resource condition to be satisfied.
-func WaitForClusterExternalSecretReady(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) {
-	err := WaitForClusterExternalSecretReadyE(t, options, name, namespace, timeout)
+func WaitForClusterExternalSecretReady(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) {
+	err := WaitForClusterExternalSecretReadyE(t, options, name, namespace, timeout, opts...)
 	require.NoError(t, err, "ClusterExternalSecret %s/%s did not become Ready", namespace, name)
 }
 
 // WaitForClusterExternalSecretReadyE waits for the resource condition to be satisfied.
-func WaitForClusterExternalSecretReadyE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) error {
+func WaitForClusterExternalSecretReadyE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) error {
 	esoclient, err := NewESOClient(t, options)
 	if err != nil {
 		return err
 	}
 
-	ctx := context.Background()
-	return wait.PollUntilContextTimeout(ctx, 2*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
-		var eso esov1.ClusterExternalSecret
-		err := esoclient.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, &eso)
-		if err != nil {
-			return false, nil
-		}
-
-		if IsClusterExternalSecretReady(eso.Status) {
-			return true, nil
-		}
-		return false, nil
-	})
+	_, err = wait.Waiter[*esov1.ClusterExternalSecret]{
+		Kind:      "ClusterExternalSecret",
+		Name:      name,
+		Namespace: namespace,
+		Timeout:   timeout,
+		Get: func(ctx context.Context) (*esov1.ClusterExternalSecret, error) {
+			var eso esov1.ClusterExternalSecret
+			err := esoclient.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, &eso)
+			return &eso, err
+		},
+		Watch: wait.ControllerRuntimeWatch(esoclient, &esov1.ClusterExternalSecretList{}, namespace, name),
+		Ready: func(eso *esov1.ClusterExternalSecret) bool {
+			return IsClusterExternalSecretReady(eso.Status)
+		},
+		Events: wait.KubeEvents(t, options),
+	}.Wait(t, opts...)
+	return err
 }
 
 // IsClusterExternalSecretReady checks if the ClusterExternalSecret resource is in a ready state.
@@ -2,7 +2,6 @@ package externalsecrets
 
 import (
 	"context"
-	"fmt"
 	"time"
 
 	"github.com/gruntwork-io/terratest/modules/testing"
@@ -13,8 +12,8 @@ import (
 
 	"github.com/gruntwork-io/terratest/modules/k8s"
 
+	"github.com/davidcollom/terratest-utils/pkg/wait"
 	"github.com/stretchr/testify/require"
-	"k8s.io/apimachinery/pkg/util/wait"
 )
 
 // ListClusterSecretStores retrieves a list of ClusterSecretStore resources from the specified namespace
@@ -54,7 +53,7 @@ func ListClusterSecretStoresE(t testing.TestingT, options *k8s.KubectlOptions, n
 }
 
 // WaitForClusterSecretStoreReady waits until the specified ClusterSecretStore resource is in a "Ready" state.
-// It polls the Kubernetes API at regular intervals until the ClusterSecretStore's status condition
+// It watches the Kubernetes API until the ClusterSecretStore's status condition
 // `ReasonStoreValid` is `ConditionTrue`, or until the provided timeout is reached.
 // If the ClusterSecretStore does not become ready within the timeout, the test fails.
 //
@@ -68,31 +67,38 @@ func ListClusterSecretStoresE(t testing.TestingT, options *k8s.KubectlOptions, n
 // This function is intended for use in integration tests to ensure that ClusterSecretStore resources
 // are fully initialized before proceeding.
 // WaitForClusterSecretStoreReady waits for the resource condition to be satisfied.
-func WaitForClusterSecretStoreReady(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) {
-	err := WaitForClusterSecretStoreReadyE(t, options, name, namespace, timeout)
+func WaitForClusterSecretStoreReady(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) {
+	err := WaitForClusterSecretStoreReadyE(t, options, name, namespace, timeout, opts...)
 	require.NoError(t, err, "SecretStore %s/%s did not become Ready", namespace, name)
 }
 
 // WaitForClusterSecretStoreReadyE waits for the resource condition to be satisfied.
-func WaitForClusterSecretStoreReadyE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) error {
+func WaitForClusterSecretStoreReadyE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) error {
 	esoclient, err := NewESOClient(t, options)
 	if err != nil {
 		return err
 	}
 
-	ctx := context.Background()
-	return wait.PollUntilContextTimeout(ctx, 2*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
-		var store esov1.ClusterSecretStore
-		err := esoclient.Get(ctx, ctrlclient.ObjectKey{Name: name, Namespace: namespace}, &store)
-		if err != nil {
-			fmt.Printf("SecretStore %s/%s not yet available: %v\n", namespace, name, err)
-			return false, nil // keep retrying
-		}
-		for _, cond := range store.Status.Conditions {
-			if cond.Type == esov1.ReasonStoreValid && cond.Status == corev1.ConditionTrue {
-				return true, nil
+	_, err = wait.Waiter[*esov1.ClusterSecretStore]{
+		Kind:      "ClusterSecretStore",
+		Name:      name,
+		Namespace: namespace,
+		Timeout:   timeout,
+		Get: func(ctx context.Context) (*esov1.ClusterSecretStore, error) {
+			var store esov1.ClusterSecretStore
+			err := esoclient.Get(ctx, ctrlclient.ObjectKey{Name: name, Namespace: namespace}, &store)
+			return &store, err
+		},
+		Watch: wait.ControllerRuntimeWatch(esoclient, &esov1.ClusterSecretStoreList{}, namespace, name),
+		Ready: func(store *esov1.ClusterSecretStore) bool {
+			for _, cond := range store.Status.Conditions {
+				if cond.Type == esov1.ReasonStoreValid && cond.Status == corev1.ConditionTrue {
+					return true
+				}
 			}
-		}
-		return false, nil
-	})
+			return false
+		},
+		Events: wait.KubeEvents(t, options),
+	}.Wait(t, opts...)
+	return err
 }
@@ -13,9 +13,10 @@ import (
 	"github.com/gruntwork-io/terratest/modules/k8s"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/davidcollom/terratest-utils/pkg/wait"
 	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/util/wait"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 )
 
 // ListExternalSecrets retrieves all ExternalSecret resources in the specified namespace using the provided
@@ -55,8 +56,8 @@ func ListExternalSecretsE(t testing.TestingT, options *k8s.KubectlOptions, names
 }
 
 // WaitForExternalSecretReady waits until the specified ExternalSecret resource in the given namespace
-// becomes ready within the provided timeout duration. It polls the resource status at regular intervals
-// and fails the test if the resource does not become ready in time.
+// becomes ready within the provided timeout duration. It watches the resource status, falling back
+// to polling, and fails the test if the resource does not become ready in time.
 //
 // Parameters:
 //
@@ -69,31 +70,126 @@ func ListExternalSecretsE(t testing.TestingT, options *k8s.KubectlOptions, names
 // The function uses the External Secrets Operator client to fetch the resource and checks its readiness
 // using IsExternalSecretReady. If the resource does not become ready within the timeout, the test fails.
 // WaitForExternalSecretReady waits for the resource condition to be satisfied.
-func WaitForExternalSecretReady(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) {
-	err := WaitForExternalSecretReadyE(t, options, name, namespace, timeout)
+func WaitForExternalSecretReady(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) {
+	err := WaitForExternalSecretReadyE(t, options, name, namespace, timeout, opts...)
 	require.NoError(t, err, "ExternalSecret %s/%s did not become Ready", namespace, name)
 }
 
 // WaitForExternalSecretReadyE waits for the resource condition to be satisfied.
-func WaitForExternalSecretReadyE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) error {
+func WaitForExternalSecretReadyE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) error {
 	esoclient, err := NewESOClient(t, options)
 	if err != nil {
 		return err
 	}
 
-	ctx := context.Background()
-	return wait.PollUntilContextTimeout(ctx, 2*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
-		var eso esov1.ExternalSecret
-		err := esoclient.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, &eso)
-		if err != nil {
-			return false, nil
+	_, err = wait.Waiter[*esov1.ExternalSecret]{
+		Kind:      "ExternalSecret",
+		Name:      name,
+		Namespace: namespace,
+		Timeout:   timeout,
+		Get: func(ctx context.Context) (*esov1.ExternalSecret, error) {
+			var eso esov1.ExternalSecret
+			err := esoclient.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, &eso)
+			return &eso, err
+		},
+		Watch: wait.ControllerRuntimeWatch(esoclient, &esov1.ExternalSecretList{}, namespace, name),
+		Ready: func(eso *esov1.ExternalSecret) bool {
+			return IsExternalSecretReady(eso.Status)
+		},
+		Events: wait.KubeEvents(t, options),
+	}.Wait(t, opts...)
+	return err
+}
+
+// WaitForExternalSecretDeleted waits until the specified ExternalSecret resource in the given namespace,
+// and the Secret it owns, no longer exist within the provided timeout, failing the test otherwise.
+//
+// Parameters:
+//
+//	t        - The testing context.
+//	options  - Kubectl options containing the REST config for Kubernetes client.
+//	name     - The name of the ExternalSecret resource.
+//	namespace- The namespace where the ExternalSecret is located.
+//	timeout  - The maximum duration to wait for both to be deleted.
+func WaitForExternalSecretDeleted(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) {
+	err := WaitForExternalSecretDeletedE(t, options, name, namespace, timeout, opts...)
+	require.NoError(t, err, "ExternalSecret %s/%s was not deleted", namespace, name)
+}
+
+// WaitForExternalSecretDeletedE waits until the ExternalSecret is gone and then, when its target
+// Secret is created with the default Owner creation policy, until the garbage collector has deleted
+// that Secret too. Both waits share timeout. It does not delete the ExternalSecret. The target is
+// read from the ExternalSecret when the wait starts; if it is already gone, a Secret with the
+// ExternalSecret's name is assumed. If the ExternalSecret is only deleted as timeout runs out, a
+// *wait.TimeoutError for it is returned without waiting for the Secret.
+func WaitForExternalSecretDeletedE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) error {
+	esoclient, err := NewESOClient(t, options)
+	if err != nil {
+		return err
+	}
+	remaining := wait.Remaining(timeout, opts...)
+
+	target, owned := name, true
+	var eso esov1.ExternalSecret
+	err = esoclient.Get(context.Background(), client.ObjectKey{Name: name, Namespace: namespace}, &eso)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	if err == nil {
+		if eso.Spec.Target.Name != "" {
+			target = eso.Spec.Target.Name
 		}
+		owned = eso.Spec.Target.CreationPolicy == "" || eso.Spec.Target.CreationPolicy == esov1.CreatePolicyOwner
+	}
 
-		if IsExternalSecretReady(eso.Status) {
-			return true, nil
+	last, err := wait.Waiter[*esov1.ExternalSecret]{
+		Kind:         "ExternalSecret",
+		Name:         name,
+		Namespace:    namespace,
+		Timeout:      timeout,
+		UntilDeleted: true,
+		Get: func(ctx context.Context) (*esov1.ExternalSecret, error) {
+			var eso esov1.ExternalSecret
+			err := esoclient.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, &eso)
+			return &eso, err
+		},
+		Watch:  wait.ControllerRuntimeWatch(esoclient, &esov1.ExternalSecretList{}, namespace, name),
+		Events: wait.KubeEvents(t, options),
+	}.Wait(t, opts...)
+	if err != nil || !owned {
+		return err
+	}
+	left := remaining()
+	if left <= 0 {
+		terr := &wait.TimeoutError{
+			Kind:         "ExternalSecret",
+			Name:         name,
+			Namespace:    namespace,
+			Timeout:      timeout,
+			UntilDeleted: true,
+			Err:          context.DeadlineExceeded,
 		}
-		return false, nil
-	})
+		if last != nil {
+			terr.Object, terr.Status = last, wait.StatusOf(last)
+		}
+		return terr
+	}
+
+	_, err = wait.Waiter[*corev1.Secret]{
+		Kind:         "Secret",
+		Name:         target,
+		Namespace:    namespace,
+		Timeout:      left,
+		UntilDeleted: true,
+		Get: func(ctx context.Context) (*corev1.Secret, error) {
+			var secret corev1.Secret
+			err := esoclient.Get(ctx, client.ObjectKey{Name: target, Namespace: namespace}, &secret)
+			return &secret, err
+		},
+		Watch:  wait.ControllerRuntimeWatch(esoclient, &corev1.SecretList{}, namespace, target),
+		Events: wait.KubeEvents(t, options),
+	}.Wait(t, opts...)
+	return err
 }
 
 // IsExternalSecretReady checks if the provided ExternalSecret resource has a condition
@@ -0,0 +1,133 @@
+package externalsecrets
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/davidcollom/terratest-utils/pkg/wait"
+	"github.com/davidcollom/terratest-utils/pkg/waittest"
+	esov1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
+	"github.com/gruntwork-io/terratest/modules/k8s"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func deletingExternalSecret(policy esov1.ExternalSecretCreationPolicy) *esov1.ExternalSecret {
+	return &esov1.ExternalSecret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "db",
+			Namespace:         "default",
+			DeletionTimestamp: &metav1.Time{Time: time.Now()},
+			Finalizers:        []string{"example.com/cleanup"},
+		},
+		Spec: esov1.ExternalSecretSpec{Target: esov1.ExternalSecretTarget{Name: "db-credentials", CreationPolicy: policy}},
+	}
+}
+
+var (
+	targetSecret           = &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "db-credentials", Namespace: "default"}}
+	externalSecretNotFound = apierrors.NewNotFound(schema.GroupResource{Group: "external-secrets.io", Resource: "externalsecrets"}, "db")
+	targetSecretNotFound   = apierrors.NewNotFound(schema.GroupResource{Resource: "secrets"}, "db-credentials")
+	externalSecretTimedOut = "timed out after 5m0s waiting for ExternalSecret default/db to be deleted"
+)
+
+// scripts answers Gets of the ExternalSecret and of its target Secret with their own Script.
+func scripts(externalSecret, secret *waittest.Script) interceptor.Funcs {
+	esoGet, secretGet := externalSecret.Interceptor().Get, secret.Interceptor().Get
+	return interceptor.Funcs{
+		Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+			if _, ok := obj.(*corev1.Secret); ok {
+				return secretGet(ctx, c, key, obj, opts...)
+			}
+			return esoGet(ctx, c, key, obj, opts...)
+		},
+	}
+}
+
+func TestWaitForExternalSecretDeleted(t *testing.T) {
+	options := &k8s.KubectlOptions{}
+
+	t.Run("deleted", func(t *testing.T) {
+		clock := waittest.NewClock()
+		NewTestClientWithInterceptors(t, scripts(
+			waittest.NewScript(clock, "default", "db").
+				At(0, deletingExternalSecret("")).
+				AtError(time.Minute, externalSecretNotFound),
+			waittest.NewScript(clock, "default", "db-credentials").
+				At(0, targetSecret).
+				AtError(2*time.Minute, targetSecretNotFound),
+		))
+
+		WaitForExternalSecretDeleted(t, options, "db", "default", 5*time.Minute, wait.WithClock(clock))
+		assert.Equal(t, 2*time.Minute, clock.Elapsed())
+	})
+
+	t.Run("timeout reports finalizers", func(t *testing.T) {
+		NewTestClient(t, deletingExternalSecret(esov1.CreatePolicyOwner), targetSecret)
+
+		err := WaitForExternalSecretDeletedE(t, options, "db", "default", 5*time.Minute, wait.WithClock(waittest.NewClock()))
+		require.True(t, wait.IsTimeout(err), "expected a timeout, got %v", err)
+		assert.Contains(t, err.Error(), externalSecretTimedOut)
+		assert.Contains(t, err.Error(), "deletion blocked by finalizers: example.com/cleanup")
+	})
+
+	t.Run("owned Secret outlives its ExternalSecret", func(t *testing.T) {
+		clock := waittest.NewClock()
+		NewTestClientWithInterceptors(t, scripts(
+			waittest.NewScript(clock, "default", "db").
+				At(0, deletingExternalSecret(esov1.CreatePolicyOwner)).
+				AtError(time.Minute, externalSecretNotFound),
+			waittest.NewScript(clock, "default", "db-credentials").
+				At(0, targetSecret),
+		))
+
+		err := WaitForExternalSecretDeletedE(t, options, "db", "default", 5*time.Minute, wait.WithClock(clock))
+		require.True(t, wait.IsTimeout(err), "expected a timeout, got %v", err)
+		assert.Contains(t, err.Error(), "waiting for Secret default/db-credentials to be deleted")
+		assert.Equal(t, 5*time.Minute, clock.Elapsed())
+	})
+
+	t.Run("orphaned Secret is not waited for", func(t *testing.T) {
+		clock := waittest.NewClock()
+		NewTestClientWithInterceptors(t, scripts(
+			waittest.NewScript(clock, "default", "db").
+				At(0, deletingExternalSecret(esov1.CreatePolicyOrphan)).
+				AtError(time.Minute, externalSecretNotFound),
+			waittest.NewScript(clock, "default", "db-credentials").
+				At(0, targetSecret),
+		))
+
+		WaitForExternalSecretDeleted(t, options, "db", "default", 5*time.Minute, wait.WithClock(clock))
+		assert.Equal(t, time.Minute, clock.Elapsed())
+	})
+
+	t.Run("no time left for the Secret", func(t *testing.T) {
+		clock := waittest.NewClock()
+		NewTestClientWithInterceptors(t, interceptor.Funcs{
+			Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				if _, ok := obj.(*corev1.Secret); ok {
+					return c.Get(ctx, key, obj, opts...)
+				}
+				if clock.Elapsed() < time.Minute {
+					deletingExternalSecret("").DeepCopyInto(obj.(*esov1.ExternalSecret))
+					return nil
+				}
+				// The ExternalSecret is gone, but only after the shared timeout has run out.
+				clock.Step(5 * time.Minute)
+				return externalSecretNotFound
+			},
+		}, targetSecret)
+
+		err := WaitForExternalSecretDeletedE(t, options, "db", "default", 5*time.Minute, wait.WithClock(clock))
+		require.True(t, wait.IsTimeout(err), "expected a timeout, got %v", err)
+		assert.Contains(t, err.Error(), externalSecretTimedOut)
+		assert.NotContains(t, err.Error(), "Secret default/db-credentials")
+	})
+}
@@ -3,17 +3,19 @@ package externalsecrets
 import (
 	"github.com/gruntwork-io/terratest/modules/testing"
 
-	"github.com/davidcollom/terratest-utils/pkg/utils"
+	"github.com/davidcollom/terratest-utils/pkg/clients"
 	esov1 "github.com/external-secrets/external-secrets/apis/externalsecrets/v1"
 	"github.com/gruntwork-io/terratest/modules/k8s"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // NewESOClient creates and returns a new controller-runtime client for interacting with ExternalSecrets resources.
-// It initializes a runtime scheme and adds the ExternalSecrets API types to it.
+// It initializes a runtime scheme and adds the ExternalSecrets API types to it. The client, and with it the
+// scheme, is built once per cluster and cached by pkg/clients.
 // Returns the client or an error if the client could not be created.
 //
 // Parameters:
@@ -22,23 +24,22 @@ import (
 //
 // Returns:
 //
-//	client.Client - The initialized client for ExternalSecrets resources.
+//	client.Client - The initialized client for ExternalSecrets resources. It also implements
+//	                client.WithWatch so that the WaitFor* helpers can watch rather than poll.
 //	error         - An error if the client could not be created.
 var NewESOClient = newESOClient
 
 func newESOClient(t testing.TestingT, options *k8s.KubectlOptions) (client.Client, error) {
-	var cfg *rest.Config
-	var err error
-	if options.RestConfig == nil {
-		cfg, err = utils.GetRestConfigE(t, options)
-		if err != nil {
-			return nil, err
-		}
-	} else {
-		cfg = options.RestConfig
-	}
+	return clients.Get(t, options, "external-secrets", func(cfg *rest.Config) (client.Client, error) {
+		return client.NewWithWatch(cfg, client.Options{Scheme: newScheme()})
+	})
+}
 
+// newScheme returns a runtime scheme with the ExternalSecrets API types registered, along with the core
+// API so that the Secrets an ExternalSecret manages can be read with the same client.
+func newScheme() *runtime.Scheme {
 	scheme := runtime.NewScheme()
 	_ = esov1.AddToScheme(scheme)
-	return client.New(cfg, client.Options{Scheme: scheme})
+	_ = corev1.AddToScheme(scheme)
+	return scheme
 }
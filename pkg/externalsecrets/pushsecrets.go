@@ -2,7 +2,6 @@ package externalsecrets
 
 import (
 	"context"
-	"fmt"
 	"time"
 
 	"github.com/gruntwork-io/terratest/modules/testing"
@@ -11,8 +10,8 @@ import (
 	"github.com/gruntwork-io/terratest/modules/k8s"
 	corev1 "k8s.io/api/core/v1"
 
+	"github.com/davidcollom/terratest-utils/pkg/wait"
 	"github.com/stretchr/testify/require"
-	"k8s.io/apimachinery/pkg/util/wait"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -57,7 +56,7 @@ func ListPushSecretsE(t testing.TestingT, options *k8s.KubectlOptions, namespace
 }
 
 // WaitForPushSecretReady waits until the specified PushSecret resource in the given namespace becomes Ready within the provided timeout.
-// It polls the Kubernetes API at regular intervals to check the status of the PushSecret's conditions.
+// It watches the Kubernetes API to check the status of the PushSecret's conditions.
 // If the PushSecret does not become Ready within the timeout, the test fails.
 // Parameters:
 //   - t: The testing context.
@@ -67,28 +66,35 @@ func ListPushSecretsE(t testing.TestingT, options *k8s.KubectlOptions, namespace
 //   - timeout: The maximum duration to wait for the PushSecret to become Ready.
 //
 // WaitForPushSecretReady waits for the resource condition to be satisfied.
-func WaitForPushSecretReady(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) {
-	err := WaitForPushSecretReadyE(t, options, name, namespace, timeout)
+func WaitForPushSecretReady(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) {
+	err := WaitForPushSecretReadyE(t, options, name, namespace, timeout, opts...)
 	require.NoError(t, err, "PushSecret %s/%s did not become Ready", namespace, name)
 }
 
 // WaitForPushSecretReadyE waits for the resource condition to be satisfied.
-func WaitForPushSecretReadyE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) error {
+func WaitForPushSecretReadyE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) error {
 	esoclient, err := NewESOClient(t, options)
 	if err != nil {
 		return err
 	}
 
-	ctx := context.Background()
-	return wait.PollUntilContextTimeout(ctx, 2*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
-		var ps esov1alpha1.PushSecret
-		err := esoclient.Get(ctx, ctrlclient.ObjectKey{Name: name, Namespace: namespace}, &ps)
-		if err != nil {
-			fmt.Printf("PushSecret %s/%s not found yet: %v\n", namespace, name, err)
-			return false, nil
-		}
-		return hasReadyCondition(ps.Status.Conditions), nil
-	})
+	_, err = wait.Waiter[*esov1alpha1.PushSecret]{
+		Kind:      "PushSecret",
+		Name:      name,
+		Namespace: namespace,
+		Timeout:   timeout,
+		Get: func(ctx context.Context) (*esov1alpha1.PushSecret, error) {
+			var ps esov1alpha1.PushSecret
+			err := esoclient.Get(ctx, ctrlclient.ObjectKey{Name: name, Namespace: namespace}, &ps)
+			return &ps, err
+		},
+		Watch: wait.ControllerRuntimeWatch(esoclient, &esov1alpha1.PushSecretList{}, namespace, name),
+		Ready: func(ps *esov1alpha1.PushSecret) bool {
+			return hasReadyCondition(ps.Status.Conditions)
+		},
+		Events: wait.KubeEvents(t, options),
+	}.Wait(t, opts...)
+	return err
 }
 
 // hasReadyCondition checks if the provided slice of PushSecretStatusCondition contains
@@ -2,7 +2,6 @@ package externalsecrets
 
 import (
 	"context"
-	"fmt"
 	"time"
 
 	"github.com/gruntwork-io/terratest/modules/testing"
@@ -13,8 +12,8 @@ import (
 
 	"github.com/gruntwork-io/terratest/modules/k8s"
 
+	"github.com/davidcollom/terratest-utils/pkg/wait"
 	"github.com/stretchr/testify/require"
-	"k8s.io/apimachinery/pkg/util/wait"
 )
 
 // ListSecretStores retrieves a list of External Secrets SecretStore resources from the specified Kubernetes namespace.
@@ -54,7 +53,7 @@ func ListSecretStoresE(t testing.TestingT, options *k8s.KubectlOptions, namespac
 }
 
 // WaitForSecretStoreReady waits until the specified SecretStore resource in the given namespace becomes Ready.
-// It polls the SecretStore status at regular intervals until the Ready condition is met or the timeout is reached.
+// It watches the SecretStore status until the Ready condition is met or the timeout is reached.
 // If the SecretStore does not become Ready within the timeout, the test fails.
 //
 // Parameters:
@@ -67,31 +66,38 @@ func ListSecretStoresE(t testing.TestingT, options *k8s.KubectlOptions, namespac
 //
 // This function requires the External Secrets Operator client to be available and the SecretStore resource to be present.
 // WaitForSecretStoreReady waits for the resource condition to be satisfied.
-func WaitForSecretStoreReady(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) {
-	err := WaitForSecretStoreReadyE(t, options, name, namespace, timeout)
+func WaitForSecretStoreReady(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) {
+	err := WaitForSecretStoreReadyE(t, options, name, namespace, timeout, opts...)
 	require.NoError(t, err, "SecretStore %s/%s did not become Ready", namespace, name)
 }
 
 // WaitForSecretStoreReadyE waits for the resource condition to be satisfied.
-func WaitForSecretStoreReadyE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) error {
+func WaitForSecretStoreReadyE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) error {
 	esoclient, err := NewESOClient(t, options)
 	if err != nil {
 		return err
 	}
 
-	ctx := context.Background()
-	return wait.PollUntilContextTimeout(ctx, 2*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
-		var store esov1.SecretStore
-		err := esoclient.Get(ctx, ctrlclient.ObjectKey{Name: name, Namespace: namespace}, &store)
-		if err != nil {
-			fmt.Printf("SecretStore %s/%s not yet available: %v\n", namespace, name, err)
-			return false, nil // keep retrying
-		}
-		for _, cond := range store.Status.Conditions {
-			if cond.Type == esov1.SecretStoreReady && cond.Status == corev1.ConditionTrue {
-				return true, nil
+	_, err = wait.Waiter[*esov1.SecretStore]{
+		Kind:      "SecretStore",
+		Name:      name,
+		Namespace: namespace,
+		Timeout:   timeout,
+		Get: func(ctx context.Context) (*esov1.SecretStore, error) {
+			var store esov1.SecretStore
+			err := esoclient.Get(ctx, ctrlclient.ObjectKey{Name: name, Namespace: namespace}, &store)
+			return &store, err
+		},
+		Watch: wait.ControllerRuntimeWatch(esoclient, &esov1.SecretStoreList{}, namespace, name),
+		Ready: func(store *esov1.SecretStore) bool {
+			for _, cond := range store.Status.Conditions {
+				if cond.Type == esov1.SecretStoreReady && cond.Status == corev1.ConditionTrue {
+					return true
+				}
 			}
-		}
-		return false, nil
-	})
+			return false
+		},
+		Events: wait.KubeEvents(t, options),
+	}.Wait(t, opts...)
+	return err
 }
@@ -12,7 +12,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
-	"k8s.io/apimachinery/pkg/util/wait"
+	"github.com/davidcollom/terratest-utils/pkg/wait"
 )
 
 // ListBuckets retrieves a list of Flux Buckets in the specified Kubernetes namespace.
@@ -56,7 +56,7 @@ func ListBucketsE(t testing.TestingT, options *k8s.KubectlOptions, namespace str
 }
 
 // WaitForBucketReady waits until the specified Flux Bucket resource reaches the "Ready" condition within the given timeout.
-// It polls the Kubernetes API at regular intervals to check the Bucket's status.
+// It watches the Kubernetes API, falling back to polling, to check the Bucket's status.
 // If the Bucket does not become ready within the timeout, the test fails.
 // Parameters:
 //
@@ -67,26 +67,37 @@ func ListBucketsE(t testing.TestingT, options *k8s.KubectlOptions, namespace str
 //	timeout  - The maximum duration to wait for the Bucket to become ready.
 //
 // WaitForBucketReady waits for the resource condition to be satisfied.
-func WaitForBucketReady(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) {
-	err := WaitForBucketReadyE(t, options, name, namespace, timeout)
+func WaitForBucketReady(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) {
+	err := WaitForBucketReadyE(t, options, name, namespace, timeout, opts...)
 	require.NoError(t, err, "Bucket %s/%s did not become Ready", namespace, name)
 }
 
 // WaitForBucketReadyE waits for the resource condition to be satisfied.
-func WaitForBucketReadyE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) error {
+// It returns a *wait.TerminalStateError as soon as the resource reports Stalled=True.
+func WaitForBucketReadyE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) error {
 	fluxclient, err := NewFluxClient(t, options)
 	if err != nil {
 		return err
 	}
 
-	ctx := context.Background()
-	return wait.PollUntilContextTimeout(ctx, 2*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
-
-		var bucket sourcev1.Bucket
-		err = fluxclient.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, &bucket)
-		if err != nil {
-			return false, nil
-		}
-		return hasReadyCondition(bucket.Status.Conditions), nil
-	})
+	_, err = wait.Waiter[*sourcev1.Bucket]{
+		Kind:      "Bucket",
+		Name:      name,
+		Namespace: namespace,
+		Timeout:   timeout,
+		Get: func(ctx context.Context) (*sourcev1.Bucket, error) {
+			var bucket sourcev1.Bucket
+			err := fluxclient.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, &bucket)
+			return &bucket, err
+		},
+		Watch: wait.ControllerRuntimeWatch(fluxclient, &sourcev1.BucketList{}, namespace, name),
+		Ready: func(bucket *sourcev1.Bucket) bool {
+			return hasReadyCondition(bucket.Status.Conditions)
+		},
+		Failed: func(bucket *sourcev1.Bucket) error {
+			return stalledCondition(bucket.Status.Conditions)
+		},
+		Events: wait.KubeEvents(t, options),
+	}.Wait(t, opts...)
+	return err
 }
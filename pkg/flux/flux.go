@@ -1,6 +1,6 @@
 // Package flux provides Terratest-style helpers for testing Flux resources such as
 // Kustomizations, HelmReleases, GitRepositories, and HelmRepositories. These functions
-// wait for Flux CRDs to become Ready using status conditions and standard polling logic.
+// wait for Flux CRDs to become Ready using status conditions, watching the resources where possible.
 package flux
 
 import (
@@ -8,9 +8,11 @@ import (
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
-	"github.com/davidcollom/terratest-utils/pkg/utils"
+	"github.com/davidcollom/terratest-utils/pkg/clients"
+	"github.com/davidcollom/terratest-utils/pkg/wait"
 	helmv2 "github.com/fluxcd/helm-controller/api/v2"
 	kustomizev1 "github.com/fluxcd/kustomize-controller/api/v1"
 	sourcev1 "github.com/fluxcd/source-controller/api/v1"
@@ -41,30 +43,46 @@ func hasReadyCondition(conds []metav1.Condition) bool {
 	return false
 }
 
+// stalledCondition returns a *wait.TerminalStateError when the provided conditions include a
+// "Stalled" condition with a status of metav1.ConditionTrue. Flux controllers set Stalled when
+// reconciliation cannot succeed without a change to the object, e.g. an invalid spec or a
+// HelmRelease that has exhausted its remediation retries, so waiting for Ready is pointless.
+func stalledCondition(conds []metav1.Condition) error {
+	for _, cond := range conds {
+		if cond.Type == "Stalled" && cond.Status == metav1.ConditionTrue {
+			return &wait.TerminalStateError{Phase: "Stalled=True", Reason: cond.Reason, Message: cond.Message}
+		}
+	}
+	return nil
+}
+
 // NewFluxClient creates and returns a new controller-runtime client for interacting with Flux resources.
 // It initializes a new runtime scheme, adds the Flux Kustomize, Helm, and Source controller APIs to the scheme,
-// and constructs the client using the provided Kubernetes REST configuration.
+// and constructs the client using the provided Kubernetes REST configuration. The client, and with it the
+// scheme, is built once per cluster and cached by pkg/clients.
 //
 // Parameters:
 //   - t: The testing context.
 //   - options: The KubectlOptions containing the Kubernetes REST config.
 //
 // Returns:
-//   - client.Client: A controller-runtime client configured for Flux resources.
+//   - client.Client: A controller-runtime client configured for Flux resources. It also implements
+//     client.WithWatch so that the WaitFor* helpers can watch rather than poll.
 //   - error: An error if the client could not be created.
 var NewFluxClient = newFluxClient
 
 func newFluxClient(t testing.TestingT, options *k8s.KubectlOptions) (client.Client, error) {
-	cfg, err := utils.GetRestConfigE(t, options)
-
-	if err != nil {
-		return nil, err
-	}
+	return clients.Get(t, options, "flux", func(cfg *rest.Config) (client.Client, error) {
+		return client.NewWithWatch(cfg, client.Options{Scheme: newScheme()})
+	})
+}
 
+// newScheme returns a runtime scheme with the Flux Kustomize, Helm, and Source controller APIs registered.
+func newScheme() *runtime.Scheme {
 	scheme := runtime.NewScheme()
 	_ = kustomizev1.AddToScheme(scheme)
 	_ = helmv2.AddToScheme(scheme)
 	_ = sourcev1.AddToScheme(scheme)
 
-	return client.New(cfg, client.Options{Scheme: scheme})
+	return scheme
 }
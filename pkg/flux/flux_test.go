@@ -0,0 +1,101 @@
+package flux
+
+import (
+	"testing"
+	"time"
+
+	"github.com/davidcollom/terratest-utils/pkg/wait"
+	"github.com/davidcollom/terratest-utils/pkg/waittest"
+	helmv2 "github.com/fluxcd/helm-controller/api/v2"
+	kustomizev1 "github.com/fluxcd/kustomize-controller/api/v1"
+	"github.com/gruntwork-io/terratest/modules/k8s"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func kustomization(conds ...metav1.Condition) *kustomizev1.Kustomization {
+	return &kustomizev1.Kustomization{
+		ObjectMeta: metav1.ObjectMeta{Name: "apps", Namespace: "flux-system"},
+		Status:     kustomizev1.KustomizationStatus{Conditions: conds},
+	}
+}
+
+func TestWaitForKustomizationReadyScripted(t *testing.T) {
+	reconciling := metav1.Condition{Type: "Ready", Status: metav1.ConditionUnknown, Reason: "Progressing"}
+
+	t.Run("ready", func(t *testing.T) {
+		clock := waittest.NewClock()
+		script := waittest.NewScript(clock, "flux-system", "apps").
+			At(0, kustomization(reconciling)).
+			At(time.Minute, kustomization(metav1.Condition{Type: "Ready", Status: metav1.ConditionTrue, Reason: "ReconciliationSucceeded"}))
+		NewTestClientWithInterceptors(t, script.Interceptor())
+
+		err := WaitForKustomizationReadyE(t, &k8s.KubectlOptions{}, "apps", "flux-system", 10*time.Minute, wait.WithClock(clock))
+		require.NoError(t, err)
+		assert.Equal(t, time.Minute, clock.Elapsed())
+	})
+
+	t.Run("stalled", func(t *testing.T) {
+		clock := waittest.NewClock()
+		script := waittest.NewScript(clock, "flux-system", "apps").
+			At(0, kustomization(reconciling)).
+			At(2*time.Minute, kustomization(
+				metav1.Condition{Type: "Ready", Status: metav1.ConditionFalse, Reason: "BuildFailed"},
+				metav1.Condition{Type: "Stalled", Status: metav1.ConditionTrue, Reason: "BuildFailed", Message: "kustomization path not found"},
+			))
+		NewTestClientWithInterceptors(t, script.Interceptor())
+
+		err := WaitForKustomizationReadyE(t, &k8s.KubectlOptions{}, "apps", "flux-system", 10*time.Minute, wait.WithClock(clock))
+		require.True(t, wait.IsTerminalState(err), "expected a terminal state error, got %v", err)
+		assert.Equal(t, `Kustomization flux-system/apps reached terminal state "Stalled=True": BuildFailed: kustomization path not found`, err.Error())
+		assert.Equal(t, 2*time.Minute, clock.Elapsed())
+	})
+}
+
+func TestWaitForHelmReleaseReadyStalled(t *testing.T) {
+	NewTestClient(t, &helmv2.HelmRelease{
+		ObjectMeta: metav1.ObjectMeta{Name: "podinfo", Namespace: "flux-system"},
+		Status: helmv2.HelmReleaseStatus{Conditions: []metav1.Condition{
+			{Type: "Ready", Status: metav1.ConditionFalse, Reason: "UpgradeFailed"},
+			{Type: "Stalled", Status: metav1.ConditionTrue, Reason: "RetriesExceeded", Message: "Failed to upgrade after 3 attempts"},
+		}},
+	})
+
+	err := WaitForHelmReleaseReadyE(t, &k8s.KubectlOptions{}, "podinfo", "flux-system", 10*time.Minute, wait.WithClock(waittest.NewClock()))
+	require.True(t, wait.IsTerminalState(err), "expected a terminal state error, got %v", err)
+	assert.Contains(t, err.Error(), "RetriesExceeded: Failed to upgrade after 3 attempts")
+}
+
+func TestWaitForKustomizationDeleted(t *testing.T) {
+	deleting := &kustomizev1.Kustomization{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "apps",
+			Namespace:         "flux-system",
+			DeletionTimestamp: &metav1.Time{Time: time.Now()},
+			Finalizers:        []string{"finalizers.fluxcd.io"},
+		},
+	}
+	notFound := apierrors.NewNotFound(schema.GroupResource{Group: "kustomize.toolkit.fluxcd.io", Resource: "kustomizations"}, "apps")
+
+	t.Run("deleted", func(t *testing.T) {
+		clock := waittest.NewClock()
+		script := waittest.NewScript(clock, "flux-system", "apps").
+			At(0, deleting).
+			AtError(time.Minute, notFound)
+		NewTestClientWithInterceptors(t, script.Interceptor())
+
+		WaitForKustomizationDeleted(t, &k8s.KubectlOptions{}, "apps", "flux-system", 5*time.Minute, wait.WithClock(clock))
+		assert.Equal(t, time.Minute, clock.Elapsed())
+	})
+
+	t.Run("timeout reports finalizers", func(t *testing.T) {
+		NewTestClient(t, deleting)
+
+		err := WaitForKustomizationDeletedE(t, &k8s.KubectlOptions{}, "apps", "flux-system", 5*time.Minute, wait.WithClock(waittest.NewClock()))
+		require.True(t, wait.IsTimeout(err), "expected a timeout, got %v", err)
+		assert.Contains(t, err.Error(), "deletion blocked by finalizers: finalizers.fluxcd.io")
+	})
+}
@@ -11,7 +11,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
-	"k8s.io/apimachinery/pkg/util/wait"
+	"github.com/davidcollom/terratest-utils/pkg/wait"
 )
 
 // ListGitRepositories retrieves all Flux GitRepository resources within the specified Kubernetes namespace.
@@ -54,7 +54,7 @@ func ListGitRepositoriesE(t testing.TestingT, options *k8s.KubectlOptions, names
 }
 
 // WaitForGitRepositoryReady waits until the specified Flux GitRepository resource becomes Ready within the given timeout.
-// It polls the resource status every 2 seconds and fails the test if the resource does not become Ready in time.
+// It watches the resource status, falling back to polling, and fails the test if the resource does not become Ready in time.
 //
 // Parameters:
 //
@@ -66,26 +66,37 @@ func ListGitRepositoriesE(t testing.TestingT, options *k8s.KubectlOptions, names
 //
 // Fails the test if the GitRepository does not reach the Ready condition within the timeout.
 // WaitForGitRepositoryReady waits for the resource condition to be satisfied.
-func WaitForGitRepositoryReady(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) {
-	err := WaitForGitRepositoryReadyE(t, options, name, namespace, timeout)
+func WaitForGitRepositoryReady(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) {
+	err := WaitForGitRepositoryReadyE(t, options, name, namespace, timeout, opts...)
 	require.NoError(t, err, "GitRepository %s/%s did not become Ready", namespace, name)
 }
 
 // WaitForGitRepositoryReadyE waits for the resource condition to be satisfied.
-func WaitForGitRepositoryReadyE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) error {
+// It returns a *wait.TerminalStateError as soon as the resource reports Stalled=True.
+func WaitForGitRepositoryReadyE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) error {
 	fluxclient, err := NewFluxClient(t, options)
 	if err != nil {
 		return err
 	}
 
-	ctx := context.Background()
-	return wait.PollUntilContextTimeout(ctx, 2*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
-
-		var repo sourcev1.GitRepository
-		err = fluxclient.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, &repo)
-		if err != nil {
-			return false, nil
-		}
-		return hasReadyCondition(repo.Status.Conditions), nil
-	})
+	_, err = wait.Waiter[*sourcev1.GitRepository]{
+		Kind:      "GitRepository",
+		Name:      name,
+		Namespace: namespace,
+		Timeout:   timeout,
+		Get: func(ctx context.Context) (*sourcev1.GitRepository, error) {
+			var repo sourcev1.GitRepository
+			err := fluxclient.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, &repo)
+			return &repo, err
+		},
+		Watch: wait.ControllerRuntimeWatch(fluxclient, &sourcev1.GitRepositoryList{}, namespace, name),
+		Ready: func(repo *sourcev1.GitRepository) bool {
+			return hasReadyCondition(repo.Status.Conditions)
+		},
+		Failed: func(repo *sourcev1.GitRepository) error {
+			return stalledCondition(repo.Status.Conditions)
+		},
+		Events: wait.KubeEvents(t, options),
+	}.Wait(t, opts...)
+	return err
 }
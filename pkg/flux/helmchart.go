@@ -12,7 +12,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
-	"k8s.io/apimachinery/pkg/util/wait"
+	"github.com/davidcollom/terratest-utils/pkg/wait"
 )
 
 // ListHelmCharts retrieves a list of HelmChart resources from the specified namespace using the provided
@@ -68,26 +68,37 @@ func ListHelmChartsE(t testing.TestingT, options *k8s.KubectlOptions, namespace
 //
 // Fails the test if the HelmChart does not reach the Ready condition within the timeout.
 // WaitForHelmChartReady waits for the resource condition to be satisfied.
-func WaitForHelmChartReady(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) {
-	err := WaitForHelmChartReadyE(t, options, name, namespace, timeout)
+func WaitForHelmChartReady(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) {
+	err := WaitForHelmChartReadyE(t, options, name, namespace, timeout, opts...)
 	require.NoError(t, err, "HelmChart %s/%s did not become Ready", namespace, name)
 }
 
 // WaitForHelmChartReadyE waits for the resource condition to be satisfied.
-func WaitForHelmChartReadyE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) error {
+// It returns a *wait.TerminalStateError as soon as the resource reports Stalled=True.
+func WaitForHelmChartReadyE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) error {
 	fluxclient, err := NewFluxClient(t, options)
 	if err != nil {
 		return err
 	}
 
-	ctx := context.Background()
-	return wait.PollUntilContextTimeout(ctx, 2*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
-
-		var chart sourcev1.HelmChart
-		err = fluxclient.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, &chart)
-		if err != nil {
-			return false, nil
-		}
-		return hasReadyCondition(chart.Status.Conditions), nil
-	})
+	_, err = wait.Waiter[*sourcev1.HelmChart]{
+		Kind:      "HelmChart",
+		Name:      name,
+		Namespace: namespace,
+		Timeout:   timeout,
+		Get: func(ctx context.Context) (*sourcev1.HelmChart, error) {
+			var chart sourcev1.HelmChart
+			err := fluxclient.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, &chart)
+			return &chart, err
+		},
+		Watch: wait.ControllerRuntimeWatch(fluxclient, &sourcev1.HelmChartList{}, namespace, name),
+		Ready: func(chart *sourcev1.HelmChart) bool {
+			return hasReadyCondition(chart.Status.Conditions)
+		},
+		Failed: func(chart *sourcev1.HelmChart) error {
+			return stalledCondition(chart.Status.Conditions)
+		},
+		Events: wait.KubeEvents(t, options),
+	}.Wait(t, opts...)
+	return err
 }
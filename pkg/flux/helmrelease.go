@@ -12,7 +12,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
-	"k8s.io/apimachinery/pkg/util/wait"
+	"github.com/davidcollom/terratest-utils/pkg/wait"
 )
 
 // ListHelmReleases retrieves all HelmRelease resources in the specified namespace using the provided kubectl options.
@@ -54,8 +54,8 @@ func ListHelmReleasesE(t testing.TestingT, options *k8s.KubectlOptions, namespac
 }
 
 // WaitForHelmReleaseReady waits until the specified HelmRelease resource in the given namespace
-// reaches the Ready condition or the timeout is exceeded. It polls the resource status at regular
-// intervals and fails the test if the resource does not become Ready within the timeout period.
+// reaches the Ready condition or the timeout is exceeded. It watches the resource status, falling
+// back to polling, and fails the test if the resource does not become Ready within the timeout period.
 //
 // Parameters:
 //
@@ -66,26 +66,37 @@ func ListHelmReleasesE(t testing.TestingT, options *k8s.KubectlOptions, namespac
 //	timeout  - The maximum duration to wait for the HelmRelease to become Ready.
 //
 // WaitForHelmReleaseReady waits for the resource condition to be satisfied.
-func WaitForHelmReleaseReady(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) {
-	err := WaitForHelmReleaseReadyE(t, options, name, namespace, timeout)
+func WaitForHelmReleaseReady(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) {
+	err := WaitForHelmReleaseReadyE(t, options, name, namespace, timeout, opts...)
 	require.NoError(t, err, "HelmRelease %s/%s did not become Ready", namespace, name)
 }
 
 // WaitForHelmReleaseReadyE waits for the resource condition to be satisfied.
-func WaitForHelmReleaseReadyE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) error {
+// It returns a *wait.TerminalStateError as soon as the resource reports Stalled=True.
+func WaitForHelmReleaseReadyE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) error {
 	fluxclient, err := NewFluxClient(t, options)
 	if err != nil {
 		return err
 	}
 
-	ctx := context.Background()
-	return wait.PollUntilContextTimeout(ctx, 2*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
-
-		var release helmv2.HelmRelease
-		err = fluxclient.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, &release)
-		if err != nil {
-			return false, nil
-		}
-		return hasReadyCondition(release.Status.Conditions), nil
-	})
+	_, err = wait.Waiter[*helmv2.HelmRelease]{
+		Kind:      "HelmRelease",
+		Name:      name,
+		Namespace: namespace,
+		Timeout:   timeout,
+		Get: func(ctx context.Context) (*helmv2.HelmRelease, error) {
+			var release helmv2.HelmRelease
+			err := fluxclient.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, &release)
+			return &release, err
+		},
+		Watch: wait.ControllerRuntimeWatch(fluxclient, &helmv2.HelmReleaseList{}, namespace, name),
+		Ready: func(release *helmv2.HelmRelease) bool {
+			return hasReadyCondition(release.Status.Conditions)
+		},
+		Failed: func(release *helmv2.HelmRelease) error {
+			return stalledCondition(release.Status.Conditions)
+		},
+		Events: wait.KubeEvents(t, options),
+	}.Wait(t, opts...)
+	return err
 }
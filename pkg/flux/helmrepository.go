@@ -12,7 +12,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
-	"k8s.io/apimachinery/pkg/util/wait"
+	"github.com/davidcollom/terratest-utils/pkg/wait"
 )
 
 // ListHelmRepositories retrieves all HelmRepository resources in the specified namespace using the provided
@@ -55,7 +55,7 @@ func ListHelmRepositoriesE(t testing.TestingT, options *k8s.KubectlOptions, name
 }
 
 // WaitForHelmRepositoryReady waits until the specified Flux HelmRepository resource becomes Ready within the given timeout.
-// It polls the resource status every 2 seconds and fails the test if the resource does not become Ready in time.
+// It watches the resource status, falling back to polling, and fails the test if the resource does not become Ready in time.
 // Parameters:
 //   - t: The testing context.
 //   - options: Kubectl options containing the Kubernetes REST config.
@@ -65,26 +65,37 @@ func ListHelmRepositoriesE(t testing.TestingT, options *k8s.KubectlOptions, name
 //
 // Fails the test if the HelmRepository does not reach the Ready condition within the timeout.
 // WaitForHelmRepositoryReady waits for the resource condition to be satisfied.
-func WaitForHelmRepositoryReady(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) {
-	err := WaitForHelmRepositoryReadyE(t, options, name, namespace, timeout)
+func WaitForHelmRepositoryReady(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) {
+	err := WaitForHelmRepositoryReadyE(t, options, name, namespace, timeout, opts...)
 	require.NoError(t, err, "HelmRepository %s/%s did not become Ready", namespace, name)
 }
 
 // WaitForHelmRepositoryReadyE waits for the resource condition to be satisfied.
-func WaitForHelmRepositoryReadyE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) error {
+// It returns a *wait.TerminalStateError as soon as the resource reports Stalled=True.
+func WaitForHelmRepositoryReadyE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) error {
 	fluxclient, err := NewFluxClient(t, options)
 	if err != nil {
 		return err
 	}
 
-	ctx := context.Background()
-	return wait.PollUntilContextTimeout(ctx, 2*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
-
-		var helmrepo sourcev1.HelmRepository
-		err = fluxclient.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, &helmrepo)
-		if err != nil {
-			return false, nil
-		}
-		return hasReadyCondition(helmrepo.Status.Conditions), nil
-	})
+	_, err = wait.Waiter[*sourcev1.HelmRepository]{
+		Kind:      "HelmRepository",
+		Name:      name,
+		Namespace: namespace,
+		Timeout:   timeout,
+		Get: func(ctx context.Context) (*sourcev1.HelmRepository, error) {
+			var helmrepo sourcev1.HelmRepository
+			err := fluxclient.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, &helmrepo)
+			return &helmrepo, err
+		},
+		Watch: wait.ControllerRuntimeWatch(fluxclient, &sourcev1.HelmRepositoryList{}, namespace, name),
+		Ready: func(helmrepo *sourcev1.HelmRepository) bool {
+			return hasReadyCondition(helmrepo.Status.Conditions)
+		},
+		Failed: func(helmrepo *sourcev1.HelmRepository) error {
+			return stalledCondition(helmrepo.Status.Conditions)
+		},
+		Events: wait.KubeEvents(t, options),
+	}.Wait(t, opts...)
+	return err
 }
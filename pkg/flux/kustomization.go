@@ -12,7 +12,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
-	"k8s.io/apimachinery/pkg/util/wait"
+	"github.com/davidcollom/terratest-utils/pkg/wait"
 )
 
 // ListKustomization retrieves all Flux Kustomization resources in the specified namespace.
@@ -56,7 +56,7 @@ func ListKustomizationE(t testing.TestingT, options *k8s.KubectlOptions, namespa
 }
 
 // WaitForKustomizationReady waits until the specified Flux Kustomization resource reaches the Ready condition within the given timeout.
-// It polls the resource status at regular intervals and fails the test if the resource does not become Ready in time.
+// It watches the resource status, falling back to polling, and fails the test if the resource does not become Ready in time.
 // Parameters:
 //   - t: The testing context.
 //   - options: Kubectl options containing the Kubernetes REST config.
@@ -66,26 +66,78 @@ func ListKustomizationE(t testing.TestingT, options *k8s.KubectlOptions, namespa
 //
 // The function will fail the test if the Kustomization does not become Ready within the timeout.
 // WaitForKustomizationReady waits for the resource condition to be satisfied.
-func WaitForKustomizationReady(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) {
-	err := WaitForKustomizationReadyE(t, options, name, namespace, timeout)
+func WaitForKustomizationReady(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) {
+	err := WaitForKustomizationReadyE(t, options, name, namespace, timeout, opts...)
 	require.NoError(t, err, "Kustomization %s/%s did not become Ready", namespace, name)
 }
 
 // WaitForKustomizationReadyE waits for the resource condition to be satisfied.
-func WaitForKustomizationReadyE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) error {
+// It returns a *wait.TerminalStateError as soon as the resource reports Stalled=True.
+func WaitForKustomizationReadyE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) error {
 	fluxclient, err := NewFluxClient(t, options)
 	if err != nil {
 		return err
 	}
 
-	ctx := context.Background()
-	return wait.PollUntilContextTimeout(ctx, 2*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
-
-		var kust kustomizev1.Kustomization
-		err = fluxclient.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, &kust)
-		if err != nil {
-			return false, nil
-		}
-		return hasReadyCondition(kust.Status.Conditions), nil
-	})
+	_, err = wait.Waiter[*kustomizev1.Kustomization]{
+		Kind:      "Kustomization",
+		Name:      name,
+		Namespace: namespace,
+		Timeout:   timeout,
+		Get: func(ctx context.Context) (*kustomizev1.Kustomization, error) {
+			var kust kustomizev1.Kustomization
+			err := fluxclient.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, &kust)
+			return &kust, err
+		},
+		Watch: wait.ControllerRuntimeWatch(fluxclient, &kustomizev1.KustomizationList{}, namespace, name),
+		Ready: func(kust *kustomizev1.Kustomization) bool {
+			return hasReadyCondition(kust.Status.Conditions)
+		},
+		Failed: func(kust *kustomizev1.Kustomization) error {
+			return stalledCondition(kust.Status.Conditions)
+		},
+		Events: wait.KubeEvents(t, options),
+	}.Wait(t, opts...)
+	return err
+}
+
+// WaitForKustomizationDeleted waits until the specified Flux Kustomization no longer exists within the given timeout.
+// Parameters:
+//   - t: The testing context.
+//   - options: Kubectl options containing the Kubernetes REST config.
+//   - name: The name of the Kustomization resource.
+//   - namespace: The namespace of the Kustomization resource.
+//   - timeout: The maximum duration to wait for the resource to be deleted.
+//
+// The function will fail the test if the Kustomization still exists when the timeout is reached.
+func WaitForKustomizationDeleted(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) {
+	err := WaitForKustomizationDeletedE(t, options, name, namespace, timeout, opts...)
+	require.NoError(t, err, "Kustomization %s/%s was not deleted", namespace, name)
+}
+
+// WaitForKustomizationDeletedE waits until the Kustomization is gone. It does not delete the
+// Kustomization. With spec.prune enabled, kustomize-controller's finalizer holds the Kustomization
+// until the objects in its inventory have been deleted, so its disappearance shows they were pruned;
+// on timeout the error lists the finalizers and the Ready condition explaining what is stuck.
+func WaitForKustomizationDeletedE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) error {
+	fluxclient, err := NewFluxClient(t, options)
+	if err != nil {
+		return err
+	}
+
+	_, err = wait.Waiter[*kustomizev1.Kustomization]{
+		Kind:         "Kustomization",
+		Name:         name,
+		Namespace:    namespace,
+		Timeout:      timeout,
+		UntilDeleted: true,
+		Get: func(ctx context.Context) (*kustomizev1.Kustomization, error) {
+			var kust kustomizev1.Kustomization
+			err := fluxclient.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, &kust)
+			return &kust, err
+		},
+		Watch:  wait.ControllerRuntimeWatch(fluxclient, &kustomizev1.KustomizationList{}, namespace, name),
+		Events: wait.KubeEvents(t, options),
+	}.Wait(t, opts...)
+	return err
 }
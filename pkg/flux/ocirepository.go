@@ -12,7 +12,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
-	"k8s.io/apimachinery/pkg/util/wait"
+	"github.com/davidcollom/terratest-utils/pkg/wait"
 )
 
 // ListOCIRepositories retrieves a list of OCIRepository resources from the specified namespace
@@ -56,7 +56,7 @@ func ListOCIRepositoriesE(t testing.TestingT, options *k8s.KubectlOptions, names
 }
 
 // WaitForOCIRepositoryReady waits until the specified Flux OCIRepository resource becomes Ready within the given timeout.
-// It polls the resource status at regular intervals and fails the test if the resource does not become Ready in time.
+// It watches the resource status, falling back to polling, and fails the test if the resource does not become Ready in time.
 //
 // Parameters:
 //
@@ -68,26 +68,37 @@ func ListOCIRepositoriesE(t testing.TestingT, options *k8s.KubectlOptions, names
 //
 // Fails the test if the OCIRepository does not reach the Ready condition within the timeout.
 // WaitForOCIRepositoryReady waits for the resource condition to be satisfied.
-func WaitForOCIRepositoryReady(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) {
-	err := WaitForOCIRepositoryReadyE(t, options, name, namespace, timeout)
+func WaitForOCIRepositoryReady(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) {
+	err := WaitForOCIRepositoryReadyE(t, options, name, namespace, timeout, opts...)
 	require.NoError(t, err, "OCIRepository %s/%s did not become Ready", namespace, name)
 }
 
 // WaitForOCIRepositoryReadyE waits for the resource condition to be satisfied.
-func WaitForOCIRepositoryReadyE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) error {
+// It returns a *wait.TerminalStateError as soon as the resource reports Stalled=True.
+func WaitForOCIRepositoryReadyE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) error {
 	fluxclient, err := NewFluxClient(t, options)
 	if err != nil {
 		return err
 	}
 
-	ctx := context.Background()
-	return wait.PollUntilContextTimeout(ctx, 2*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
-
-		var ocirepo sourcev1.OCIRepository
-		err = fluxclient.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, &ocirepo)
-		if err != nil {
-			return false, nil
-		}
-		return hasReadyCondition(ocirepo.Status.Conditions), nil
-	})
+	_, err = wait.Waiter[*sourcev1.OCIRepository]{
+		Kind:      "OCIRepository",
+		Name:      name,
+		Namespace: namespace,
+		Timeout:   timeout,
+		Get: func(ctx context.Context) (*sourcev1.OCIRepository, error) {
+			var ocirepo sourcev1.OCIRepository
+			err := fluxclient.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, &ocirepo)
+			return &ocirepo, err
+		},
+		Watch: wait.ControllerRuntimeWatch(fluxclient, &sourcev1.OCIRepositoryList{}, namespace, name),
+		Ready: func(ocirepo *sourcev1.OCIRepository) bool {
+			return hasReadyCondition(ocirepo.Status.Conditions)
+		},
+		Failed: func(ocirepo *sourcev1.OCIRepository) error {
+			return stalledCondition(ocirepo.Status.Conditions)
+		},
+		Events: wait.KubeEvents(t, options),
+	}.Wait(t, opts...)
+	return err
 }
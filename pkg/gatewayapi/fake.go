@@ -0,0 +1,32 @@
+package gatewayapi
+
+import (
+	gwclientset "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned"
+	fakegw "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned/fake"
+
+	"github.com/davidcollom/terratest-utils/pkg/utils"
+	"github.com/gruntwork-io/terratest/modules/k8s"
+	"github.com/gruntwork-io/terratest/modules/testing"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// NewTestClient creates a fake Gateway API clientset seeded with objs and makes NewClient return it
+// for the rest of the test, so that every helper in this package runs against the fake. NewClient is
+// restored when the test finishes.
+//
+// Example usage:
+//
+//	gatewayapi.NewTestClient(t, &gwv1.HTTPRoute{...})
+//	gatewayapi.WaitForHTTPRouteReady(t, &k8s.KubectlOptions{}, "podinfo", "default", time.Second)
+func NewTestClient(t utils.CleanupT, objs ...runtime.Object) gwclientset.Interface {
+	client := fakegw.NewSimpleClientset(objs...)
+
+	NewClient = func(t testing.TestingT, options *k8s.KubectlOptions) (gwclientset.Interface, error) {
+		return client, nil
+	}
+	t.Cleanup(func() {
+		NewClient = newClient
+	})
+
+	return client
+}
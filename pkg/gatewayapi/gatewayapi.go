@@ -0,0 +1,141 @@
+// Package gatewayapi provides Terratest-style helpers for testing Kubernetes Gateway API resources:
+// GatewayClasses, Gateways, HTTPRoutes, GRPCRoutes, TCPRoutes, TLSRoutes and ReferenceGrants. The
+// WaitFor* helpers read the Accepted, Programmed and ResolvedRefs conditions that implementations
+// such as Istio, Envoy Gateway or Cilium report per Gateway listener and per route parentRef, so a
+// test can verify that a route was actually attached to its Gateway rather than merely created.
+package gatewayapi
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/gruntwork-io/terratest/modules/testing"
+
+	"github.com/davidcollom/terratest-utils/pkg/clients"
+	"github.com/davidcollom/terratest-utils/pkg/wait"
+	"github.com/gruntwork-io/terratest/modules/k8s"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gwclientset "sigs.k8s.io/gateway-api/pkg/client/clientset/versioned"
+)
+
+// NewClient returns the Gateway API clientset for the cluster described by options. The clientset is
+// cached per cluster by pkg/clients. The helpers in this package use it, so tests can replace it,
+// e.g. with NewTestClient, to inject a fake.
+//
+// Parameters:
+//   - t: The testing context.
+//   - options: The kubectl options describing the cluster.
+//
+// Returns:
+//   - gwclientset.Interface: The Gateway API clientset.
+//   - error: An error if the REST config or clientset could not be created.
+var NewClient = newClient
+
+func newClient(t testing.TestingT, options *k8s.KubectlOptions) (gwclientset.Interface, error) {
+	return clients.Get(t, options, "gateway-api", func(cfg *rest.Config) (gwclientset.Interface, error) {
+		return gwclientset.NewForConfig(cfg)
+	})
+}
+
+// conditionTrue reports whether conds has a condition of type condType that is True and was set for
+// generation. Conditions without an observedGeneration are taken to be current.
+func conditionTrue(conds []metav1.Condition, condType string, generation int64) bool {
+	cond := meta.FindStatusCondition(conds, condType)
+	return cond != nil && cond.Status == metav1.ConditionTrue && cond.ObservedGeneration >= generation
+}
+
+// rejected returns the Accepted=False condition of conds when it was set for generation and its
+// reason is one of terminal, or nil.
+func rejected(conds []metav1.Condition, generation int64, terminal ...string) *metav1.Condition {
+	cond := meta.FindStatusCondition(conds, "Accepted")
+	if cond == nil || cond.Status != metav1.ConditionFalse || cond.ObservedGeneration < generation {
+		return nil
+	}
+	if slices.Contains(terminal, cond.Reason) {
+		return cond
+	}
+	return nil
+}
+
+// routeReady reports whether every parentRef of a route in namespace has a parent status, from any
+// implementation, with Accepted=True and ResolvedRefs=True for the route's generation. A route
+// without parentRefs is never ready, as it is not attached to anything.
+func routeReady(namespace string, generation int64, parentRefs []gwv1.ParentReference, status gwv1.RouteStatus) bool {
+	if len(parentRefs) == 0 {
+		return false
+	}
+	for _, ref := range parentRefs {
+		parent := parentStatus(namespace, ref, status)
+		if parent == nil ||
+			!conditionTrue(parent.Conditions, string(gwv1.RouteConditionAccepted), generation) ||
+			!conditionTrue(parent.Conditions, string(gwv1.RouteConditionResolvedRefs), generation) {
+			return false
+		}
+	}
+	return true
+}
+
+// routeFailed reports a parentRef that its Gateway rejected because the route does not fit any of
+// its listeners as a terminal state. Reasons that can clear up on their own, such as Pending, or a
+// backend that does not exist yet, are not terminal.
+func routeFailed(namespace string, generation int64, parentRefs []gwv1.ParentReference, status gwv1.RouteStatus) error {
+	for _, ref := range parentRefs {
+		parent := parentStatus(namespace, ref, status)
+		if parent == nil {
+			continue
+		}
+		cond := rejected(parent.Conditions, generation,
+			string(gwv1.RouteReasonNotAllowedByListeners),
+			string(gwv1.RouteReasonNoMatchingListenerHostname),
+			string(gwv1.RouteReasonNoMatchingParent),
+			string(gwv1.RouteReasonUnsupportedValue),
+		)
+		if cond != nil {
+			return &wait.TerminalStateError{
+				Phase:   "Accepted=False",
+				Reason:  cond.Reason,
+				Message: fmt.Sprintf("parentRef %s: %s", parentRefString(namespace, ref), cond.Message),
+			}
+		}
+	}
+	return nil
+}
+
+// parentStatus returns the first parent status for ref, or nil if no implementation has reported one.
+func parentStatus(namespace string, ref gwv1.ParentReference, status gwv1.RouteStatus) *gwv1.RouteParentStatus {
+	want := parentRefString(namespace, ref)
+	for i, parent := range status.Parents {
+		if parentRefString(namespace, parent.ParentRef) == want {
+			return &status.Parents[i]
+		}
+	}
+	return nil
+}
+
+// parentRefString formats ref with its defaults filled in, e.g. "Gateway/istio-ingress/public:https".
+func parentRefString(namespace string, ref gwv1.ParentReference) string {
+	group, kind := gwv1.GroupName, "Gateway"
+	if ref.Group != nil {
+		group = string(*ref.Group)
+	}
+	if ref.Kind != nil {
+		kind = string(*ref.Kind)
+	}
+	if ref.Namespace != nil {
+		namespace = string(*ref.Namespace)
+	}
+	s := fmt.Sprintf("%s/%s/%s", kind, namespace, ref.Name)
+	if group != gwv1.GroupName {
+		s = group + "." + s
+	}
+	if ref.SectionName != nil {
+		s += ":" + string(*ref.SectionName)
+	}
+	if ref.Port != nil {
+		s += fmt.Sprintf(":%d", *ref.Port)
+	}
+	return s
+}
@@ -0,0 +1,186 @@
+package gatewayapi
+
+import (
+	"testing"
+	"time"
+
+	"github.com/davidcollom/terratest-utils/pkg/wait"
+	"github.com/davidcollom/terratest-utils/pkg/waittest"
+	"github.com/gruntwork-io/terratest/modules/k8s"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+	gwv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+func condition(condType string, status metav1.ConditionStatus, reason string, generation int64) metav1.Condition {
+	return metav1.Condition{Type: condType, Status: status, Reason: reason, Message: condType + " " + reason, ObservedGeneration: generation}
+}
+
+func gateway(conds []metav1.Condition, listeners ...gwv1.ListenerStatus) *gwv1.Gateway {
+	return &gwv1.Gateway{
+		ObjectMeta: metav1.ObjectMeta{Name: "public", Namespace: "istio-ingress", Generation: 2},
+		Spec: gwv1.GatewaySpec{Listeners: []gwv1.Listener{
+			{Name: "http", Port: 80, Protocol: gwv1.HTTPProtocolType},
+			{Name: "https", Port: 443, Protocol: gwv1.HTTPSProtocolType},
+		}},
+		Status: gwv1.GatewayStatus{Conditions: conds, Listeners: listeners},
+	}
+}
+
+func listener(name gwv1.SectionName, generation int64, resolvedRefs metav1.ConditionStatus) gwv1.ListenerStatus {
+	return gwv1.ListenerStatus{Name: name, Conditions: []metav1.Condition{
+		condition("Accepted", metav1.ConditionTrue, "Accepted", generation),
+		condition("Programmed", metav1.ConditionTrue, "Programmed", generation),
+		condition("ResolvedRefs", resolvedRefs, "ResolvedRefs", generation),
+	}}
+}
+
+func TestIsGatewayReady(t *testing.T) {
+	programmed := []metav1.Condition{
+		condition("Accepted", metav1.ConditionTrue, "Accepted", 2),
+		condition("Programmed", metav1.ConditionTrue, "Programmed", 2),
+	}
+
+	tests := []struct {
+		name     string
+		gateway  *gwv1.Gateway
+		ready    bool
+		terminal bool
+	}{
+		{name: "programmed", gateway: gateway(programmed, listener("http", 2, metav1.ConditionTrue), listener("https", 2, metav1.ConditionTrue)), ready: true},
+		{name: "listener missing", gateway: gateway(programmed, listener("http", 2, metav1.ConditionTrue))},
+		{name: "certificate not resolved", gateway: gateway(programmed, listener("http", 2, metav1.ConditionTrue), listener("https", 2, metav1.ConditionFalse))},
+		{name: "stale listener status", gateway: gateway(programmed, listener("http", 1, metav1.ConditionTrue), listener("https", 2, metav1.ConditionTrue))},
+		{name: "stale gateway status", gateway: gateway([]metav1.Condition{
+			condition("Accepted", metav1.ConditionTrue, "Accepted", 1),
+			condition("Programmed", metav1.ConditionTrue, "Programmed", 1),
+		}, listener("http", 2, metav1.ConditionTrue), listener("https", 2, metav1.ConditionTrue))},
+		{name: "pending", gateway: gateway([]metav1.Condition{condition("Accepted", metav1.ConditionFalse, "Pending", 2)})},
+		{name: "invalid", gateway: gateway([]metav1.Condition{condition("Accepted", metav1.ConditionFalse, "ListenersNotValid", 2)}), terminal: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.ready, IsGatewayReady(tc.gateway))
+			assert.Equal(t, tc.terminal, wait.IsTerminalState(gatewayFailed(tc.gateway)))
+		})
+	}
+}
+
+func TestWaitForGatewayClassAccepted(t *testing.T) {
+	class := func(conds ...metav1.Condition) *gwv1.GatewayClass {
+		return &gwv1.GatewayClass{
+			ObjectMeta: metav1.ObjectMeta{Name: "istio", Generation: 1},
+			Spec:       gwv1.GatewayClassSpec{ControllerName: "istio.io/gateway-controller"},
+			Status:     gwv1.GatewayClassStatus{Conditions: conds},
+		}
+	}
+
+	t.Run("accepted", func(t *testing.T) {
+		clock := waittest.NewClock()
+		waittest.Prepend(t, NewTestClient(t), "gatewayclasses", waittest.NewScript(clock, "", "istio").
+			Return(class(condition("Accepted", metav1.ConditionUnknown, "Pending", 1))).
+			At(10*time.Second, class(condition("Accepted", metav1.ConditionTrue, "Accepted", 1))))
+
+		WaitForGatewayClassAccepted(t, &k8s.KubectlOptions{}, "istio", time.Minute, wait.WithClock(clock))
+		assert.Equal(t, 10*time.Second, clock.Elapsed())
+	})
+
+	t.Run("invalid parameters", func(t *testing.T) {
+		NewTestClient(t, class(condition("Accepted", metav1.ConditionFalse, "InvalidParameters", 1)))
+
+		err := WaitForGatewayClassAcceptedE(t, &k8s.KubectlOptions{}, "istio", time.Minute, wait.WithClock(waittest.NewClock()))
+		require.True(t, wait.IsTerminalState(err), "expected a terminal state error, got %v", err)
+		assert.Contains(t, err.Error(), "InvalidParameters")
+	})
+}
+
+func httpRoute(parentRefs []gwv1.ParentReference, parents ...gwv1.RouteParentStatus) *gwv1.HTTPRoute {
+	return &gwv1.HTTPRoute{
+		ObjectMeta: metav1.ObjectMeta{Name: "podinfo", Namespace: "default", Generation: 1},
+		Spec:       gwv1.HTTPRouteSpec{CommonRouteSpec: gwv1.CommonRouteSpec{ParentRefs: parentRefs}},
+		Status:     gwv1.HTTPRouteStatus{RouteStatus: gwv1.RouteStatus{Parents: parents}},
+	}
+}
+
+func parent(ref gwv1.ParentReference, accepted metav1.ConditionStatus, reason string, resolvedRefs metav1.ConditionStatus) gwv1.RouteParentStatus {
+	return gwv1.RouteParentStatus{
+		ParentRef:      ref,
+		ControllerName: "istio.io/gateway-controller",
+		Conditions: []metav1.Condition{
+			condition("Accepted", accepted, reason, 1),
+			condition("ResolvedRefs", resolvedRefs, "ResolvedRefs", 1),
+		},
+	}
+}
+
+func TestIsHTTPRouteReady(t *testing.T) {
+	public := gwv1.ParentReference{Name: "public", Namespace: ptr.To[gwv1.Namespace]("istio-ingress"), SectionName: ptr.To[gwv1.SectionName]("https")}
+	// Implementations report parentRefs with their defaults filled in.
+	reported := public
+	reported.Group = ptr.To[gwv1.Group](gwv1.GroupName)
+	reported.Kind = ptr.To[gwv1.Kind]("Gateway")
+	internal := gwv1.ParentReference{Name: "internal"}
+
+	tests := []struct {
+		name     string
+		route    *gwv1.HTTPRoute
+		ready    bool
+		terminal bool
+	}{
+		{name: "no parentRefs", route: httpRoute(nil)},
+		{name: "attached", route: httpRoute([]gwv1.ParentReference{public}, parent(reported, metav1.ConditionTrue, "Accepted", metav1.ConditionTrue)), ready: true},
+		{name: "one parent not reported", route: httpRoute([]gwv1.ParentReference{public, internal}, parent(reported, metav1.ConditionTrue, "Accepted", metav1.ConditionTrue))},
+		{name: "backend not found", route: httpRoute([]gwv1.ParentReference{public}, parent(reported, metav1.ConditionTrue, "Accepted", metav1.ConditionFalse))},
+		{name: "other section", route: httpRoute([]gwv1.ParentReference{public}, parent(internal, metav1.ConditionTrue, "Accepted", metav1.ConditionTrue))},
+		{name: "pending", route: httpRoute([]gwv1.ParentReference{public}, parent(reported, metav1.ConditionFalse, "Pending", metav1.ConditionTrue))},
+		{name: "not allowed by listeners", route: httpRoute([]gwv1.ParentReference{public}, parent(reported, metav1.ConditionFalse, "NotAllowedByListeners", metav1.ConditionTrue)), terminal: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.ready, IsHTTPRouteReady(tc.route))
+			err := routeFailed(tc.route.Namespace, tc.route.Generation, tc.route.Spec.ParentRefs, tc.route.Status.RouteStatus)
+			assert.Equal(t, tc.terminal, wait.IsTerminalState(err))
+			if tc.terminal {
+				assert.Contains(t, err.Error(), "parentRef Gateway/istio-ingress/public:https")
+			}
+		})
+	}
+}
+
+func TestWaitForHTTPRouteReady(t *testing.T) {
+	public := gwv1.ParentReference{Name: "public"}
+
+	clock := waittest.NewClock()
+	waittest.Prepend(t, NewTestClient(t), "httproutes", waittest.NewScript(clock, "default", "podinfo").
+		Return(httpRoute([]gwv1.ParentReference{public})).
+		At(20*time.Second, httpRoute([]gwv1.ParentReference{public}, parent(public, metav1.ConditionTrue, "Accepted", metav1.ConditionFalse))).
+		At(time.Minute, httpRoute([]gwv1.ParentReference{public}, parent(public, metav1.ConditionTrue, "Accepted", metav1.ConditionTrue))))
+
+	WaitForHTTPRouteReady(t, &k8s.KubectlOptions{}, "podinfo", "default", 5*time.Minute, wait.WithClock(clock))
+	assert.Equal(t, time.Minute, clock.Elapsed())
+}
+
+func TestWaitForTCPRouteReady(t *testing.T) {
+	db := gwv1.ParentReference{Name: "db", Port: ptr.To[gwv1.PortNumber](5432)}
+	NewTestClient(t,
+		&gwv1alpha2.TCPRoute{
+			ObjectMeta: metav1.ObjectMeta{Name: "postgres", Namespace: "db"},
+			Spec:       gwv1alpha2.TCPRouteSpec{CommonRouteSpec: gwv1.CommonRouteSpec{ParentRefs: []gwv1.ParentReference{db}}},
+			Status: gwv1alpha2.TCPRouteStatus{RouteStatus: gwv1.RouteStatus{Parents: []gwv1.RouteParentStatus{
+				parent(db, metav1.ConditionFalse, "NoMatchingParent", metav1.ConditionTrue),
+			}}},
+		},
+		&gwv1beta1.ReferenceGrant{ObjectMeta: metav1.ObjectMeta{Name: "allow-db-routes", Namespace: "db"}},
+	)
+
+	err := WaitForTCPRouteReadyE(t, &k8s.KubectlOptions{}, "postgres", "db", time.Minute, wait.WithClock(waittest.NewClock()))
+	require.True(t, wait.IsTerminalState(err), "expected a terminal state error, got %v", err)
+	assert.Contains(t, err.Error(), "parentRef Gateway/db/db:5432")
+
+	assert.Len(t, ListTCPRoutes(t, &k8s.KubectlOptions{}, "db"), 1)
+	assert.Equal(t, "allow-db-routes", GetReferenceGrant(t, &k8s.KubectlOptions{}, "allow-db-routes", "db").Name)
+}
@@ -0,0 +1,135 @@
+package gatewayapi
+
+import (
+	"context"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/testing"
+
+	"github.com/davidcollom/terratest-utils/pkg/wait"
+	"github.com/gruntwork-io/terratest/modules/k8s"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// ListGatewayClasses retrieves the GatewayClasses of the cluster, failing the test if they cannot be listed.
+//
+// Parameters:
+//   - t: The testing context.
+//   - options: The kubectl options used to configure the client.
+//
+// Returns:
+//   - A slice of gwv1.GatewayClass objects.
+func ListGatewayClasses(t testing.TestingT, options *k8s.KubectlOptions) []gwv1.GatewayClass {
+	classes, err := ListGatewayClassesE(t, options)
+	require.NoError(t, err, "Failed to list GatewayClasses")
+	return classes
+}
+
+// ListGatewayClassesE lists matching resources.
+func ListGatewayClassesE(t testing.TestingT, options *k8s.KubectlOptions) ([]gwv1.GatewayClass, error) {
+	client, err := NewClient(t, options)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := client.GatewayV1().GatewayClasses().List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return list.Items, nil
+}
+
+// GetGatewayClass retrieves the specified GatewayClass, failing the test if it cannot be retrieved.
+//
+// Parameters:
+//   - t: The testing context.
+//   - options: The kubectl options used to configure the client.
+//   - name: The name of the GatewayClass.
+//
+// Returns:
+//   - *gwv1.GatewayClass: The retrieved GatewayClass.
+func GetGatewayClass(t testing.TestingT, options *k8s.KubectlOptions, name string) *gwv1.GatewayClass {
+	class, err := GetGatewayClassE(t, options, name)
+	require.NoError(t, err, "Failed to get GatewayClass %s", name)
+	return class
+}
+
+// GetGatewayClassE gets a resource by name.
+func GetGatewayClassE(t testing.TestingT, options *k8s.KubectlOptions, name string) (*gwv1.GatewayClass, error) {
+	client, err := NewClient(t, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.GatewayV1().GatewayClasses().Get(context.Background(), name, metav1.GetOptions{})
+}
+
+// WaitForGatewayClassAccepted waits until the specified GatewayClass has been accepted by its controller or the timeout is reached, failing the test otherwise.
+//
+// Example usage:
+//
+//	gatewayapi.WaitForGatewayClassAccepted(t, options, "istio", 2*time.Minute)
+//
+// Parameters:
+//   - t: The testing context.
+//   - options: The kubectl options used to configure the client.
+//   - name: The name of the GatewayClass.
+//   - timeout: The maximum duration to wait.
+func WaitForGatewayClassAccepted(t testing.TestingT, options *k8s.KubectlOptions, name string, timeout time.Duration, opts ...wait.WaitOption) {
+	err := WaitForGatewayClassAcceptedE(t, options, name, timeout, opts...)
+	require.NoError(t, err, "GatewayClass %s was not Accepted", name)
+}
+
+// WaitForGatewayClassAcceptedE waits until the controller named by the GatewayClass reports it
+// Accepted=True, using IsGatewayClassAccepted. It returns a *wait.TerminalStateError as soon as the
+// controller rejects the class's parameters or does not support the installed CRD version.
+func WaitForGatewayClassAcceptedE(t testing.TestingT, options *k8s.KubectlOptions, name string, timeout time.Duration, opts ...wait.WaitOption) error {
+	client, err := NewClient(t, options)
+	if err != nil {
+		return err
+	}
+
+	_, err = wait.Waiter[*gwv1.GatewayClass]{
+		Kind:    "GatewayClass",
+		Name:    name,
+		Timeout: timeout,
+		Get: func(ctx context.Context) (*gwv1.GatewayClass, error) {
+			return client.GatewayV1().GatewayClasses().Get(ctx, name, metav1.GetOptions{})
+		},
+		Watch: func(ctx context.Context) (watch.Interface, error) {
+			return client.GatewayV1().GatewayClasses().Watch(ctx, metav1.SingleObject(metav1.ObjectMeta{Name: name}))
+		},
+		Ready:  IsGatewayClassAccepted,
+		Failed: gatewayClassFailed,
+		Events: wait.KubeEvents(t, options),
+	}.Wait(t, opts...)
+	return err
+}
+
+// IsGatewayClassAccepted reports whether the GatewayClass has an Accepted=True condition for its
+// current generation.
+//
+// Parameters:
+//   - class: A pointer to the gwv1.GatewayClass object to check.
+//
+// Returns:
+//   - bool: True if the GatewayClass is accepted, false otherwise.
+func IsGatewayClassAccepted(class *gwv1.GatewayClass) bool {
+	return conditionTrue(class.Status.Conditions, string(gwv1.GatewayClassConditionStatusAccepted), class.Generation)
+}
+
+// gatewayClassFailed reports a GatewayClass its controller rejected as a terminal state.
+func gatewayClassFailed(class *gwv1.GatewayClass) error {
+	cond := rejected(class.Status.Conditions, class.Generation,
+		string(gwv1.GatewayClassReasonInvalidParameters),
+		string(gwv1.GatewayClassReasonUnsupportedVersion),
+	)
+	if cond != nil {
+		return &wait.TerminalStateError{Phase: "Accepted=False", Reason: cond.Reason, Message: cond.Message}
+	}
+	return nil
+}
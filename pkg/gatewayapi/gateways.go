@@ -0,0 +1,173 @@
+package gatewayapi
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/testing"
+
+	"github.com/davidcollom/terratest-utils/pkg/wait"
+	"github.com/gruntwork-io/terratest/modules/k8s"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// ListGateways retrieves the Gateways in the specified namespace, failing the test if they cannot be listed.
+//
+// Parameters:
+//   - t: The testing context.
+//   - options: The kubectl options used to configure the client.
+//   - namespace: The namespace from which to list Gateways.
+//
+// Returns:
+//   - A slice of gwv1.Gateway objects.
+func ListGateways(t testing.TestingT, options *k8s.KubectlOptions, namespace string) []gwv1.Gateway {
+	gateways, err := ListGatewaysE(t, options, namespace)
+	require.NoError(t, err, "Failed to list Gateways in namespace %s", namespace)
+	return gateways
+}
+
+// ListGatewaysE lists matching resources.
+func ListGatewaysE(t testing.TestingT, options *k8s.KubectlOptions, namespace string) ([]gwv1.Gateway, error) {
+	client, err := NewClient(t, options)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := client.GatewayV1().Gateways(namespace).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return list.Items, nil
+}
+
+// GetGateway retrieves the specified Gateway, failing the test if it cannot be retrieved.
+//
+// Parameters:
+//   - t: The testing context.
+//   - options: The kubectl options used to configure the client.
+//   - name: The name of the Gateway.
+//   - namespace: The namespace of the Gateway.
+//
+// Returns:
+//   - *gwv1.Gateway: The retrieved Gateway.
+func GetGateway(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string) *gwv1.Gateway {
+	gateway, err := GetGatewayE(t, options, name, namespace)
+	require.NoError(t, err, "Failed to get Gateway %s/%s", namespace, name)
+	return gateway
+}
+
+// GetGatewayE gets a resource by name.
+func GetGatewayE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string) (*gwv1.Gateway, error) {
+	client, err := NewClient(t, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.GatewayV1().Gateways(namespace).Get(context.Background(), name, metav1.GetOptions{})
+}
+
+// WaitForGatewayReady waits until the specified Gateway and all of its listeners are programmed or the timeout is reached, failing the test otherwise.
+//
+// Example usage:
+//
+//	gatewayapi.WaitForGatewayReady(t, options, "public", "istio-ingress", 5*time.Minute)
+//	addr := gatewayapi.GetGateway(t, options, "public", "istio-ingress").Status.Addresses[0].Value
+//
+// Parameters:
+//   - t: The testing context.
+//   - options: The kubectl options used to configure the client.
+//   - name: The name of the Gateway.
+//   - namespace: The namespace of the Gateway.
+//   - timeout: The maximum duration to wait.
+func WaitForGatewayReady(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) {
+	err := WaitForGatewayReadyE(t, options, name, namespace, timeout, opts...)
+	require.NoError(t, err, "Gateway %s/%s was not Programmed", namespace, name)
+}
+
+// WaitForGatewayReadyE waits until the specified Gateway is ready, using IsGatewayReady. It returns a
+// *wait.TerminalStateError as soon as the implementation rejects the Gateway, e.g. because its
+// listeners are invalid or it asks for an address that cannot be used.
+func WaitForGatewayReadyE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) error {
+	client, err := NewClient(t, options)
+	if err != nil {
+		return err
+	}
+
+	_, err = wait.Waiter[*gwv1.Gateway]{
+		Kind:      "Gateway",
+		Name:      name,
+		Namespace: namespace,
+		Timeout:   timeout,
+		Get: func(ctx context.Context) (*gwv1.Gateway, error) {
+			return client.GatewayV1().Gateways(namespace).Get(ctx, name, metav1.GetOptions{})
+		},
+		Watch: func(ctx context.Context) (watch.Interface, error) {
+			return client.GatewayV1().Gateways(namespace).Watch(ctx, metav1.SingleObject(metav1.ObjectMeta{Name: name}))
+		},
+		Ready:  IsGatewayReady,
+		Failed: gatewayFailed,
+		Events: wait.KubeEvents(t, options),
+	}.Wait(t, opts...)
+	return err
+}
+
+// IsGatewayReady reports whether the Gateway is Accepted and Programmed for its current generation
+// and each of its listeners is Accepted, Programmed and has ResolvedRefs, i.e. its certificates and
+// allowed route kinds are valid and traffic for it is being served.
+//
+// Parameters:
+//   - gateway: A pointer to the gwv1.Gateway object to check.
+//
+// Returns:
+//   - bool: True if the Gateway and all of its listeners are ready, false otherwise.
+func IsGatewayReady(gateway *gwv1.Gateway) bool {
+	generation := gateway.Generation
+	if !conditionTrue(gateway.Status.Conditions, string(gwv1.GatewayConditionAccepted), generation) ||
+		!conditionTrue(gateway.Status.Conditions, string(gwv1.GatewayConditionProgrammed), generation) {
+		return false
+	}
+	for _, listener := range gateway.Spec.Listeners {
+		status := listenerStatus(gateway, listener.Name)
+		if status == nil ||
+			!conditionTrue(status.Conditions, string(gwv1.ListenerConditionAccepted), generation) ||
+			!conditionTrue(status.Conditions, string(gwv1.ListenerConditionProgrammed), generation) ||
+			!conditionTrue(status.Conditions, string(gwv1.ListenerConditionResolvedRefs), generation) {
+			return false
+		}
+	}
+	return true
+}
+
+// gatewayFailed reports a Gateway the implementation rejected as a terminal state.
+func gatewayFailed(gateway *gwv1.Gateway) error {
+	cond := rejected(gateway.Status.Conditions, gateway.Generation,
+		string(gwv1.GatewayReasonInvalid),
+		string(gwv1.GatewayReasonListenersNotValid),
+		string(gwv1.GatewayReasonUnsupportedAddress),
+	)
+	if cond == nil {
+		return nil
+	}
+	message := cond.Message
+	for _, listener := range gateway.Status.Listeners {
+		if c := rejected(listener.Conditions, gateway.Generation, string(gwv1.ListenerReasonPortUnavailable), string(gwv1.ListenerReasonUnsupportedProtocol)); c != nil {
+			message += fmt.Sprintf("; listener %s: %s", listener.Name, c.Message)
+		}
+	}
+	return &wait.TerminalStateError{Phase: "Accepted=False", Reason: cond.Reason, Message: message}
+}
+
+// listenerStatus returns the status of the Gateway's listener called name, or nil.
+func listenerStatus(gateway *gwv1.Gateway, name gwv1.SectionName) *gwv1.ListenerStatus {
+	for i, listener := range gateway.Status.Listeners {
+		if listener.Name == name {
+			return &gateway.Status.Listeners[i]
+		}
+	}
+	return nil
+}
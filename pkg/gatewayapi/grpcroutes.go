@@ -0,0 +1,126 @@
+package gatewayapi
+
+import (
+	"context"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/testing"
+
+	"github.com/davidcollom/terratest-utils/pkg/wait"
+	"github.com/gruntwork-io/terratest/modules/k8s"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	gwv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+// ListGRPCRoutes retrieves the GRPCRoutes in the specified namespace, failing the test if they cannot be listed.
+//
+// Parameters:
+//   - t: The testing context.
+//   - options: The kubectl options used to configure the client.
+//   - namespace: The namespace from which to list GRPCRoutes.
+//
+// Returns:
+//   - A slice of gwv1alpha2.GRPCRoute objects.
+func ListGRPCRoutes(t testing.TestingT, options *k8s.KubectlOptions, namespace string) []gwv1alpha2.GRPCRoute {
+	routes, err := ListGRPCRoutesE(t, options, namespace)
+	require.NoError(t, err, "Failed to list GRPCRoutes in namespace %s", namespace)
+	return routes
+}
+
+// ListGRPCRoutesE lists matching resources.
+func ListGRPCRoutesE(t testing.TestingT, options *k8s.KubectlOptions, namespace string) ([]gwv1alpha2.GRPCRoute, error) {
+	client, err := NewClient(t, options)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := client.GatewayV1alpha2().GRPCRoutes(namespace).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return list.Items, nil
+}
+
+// GetGRPCRoute retrieves the specified GRPCRoute, failing the test if it cannot be retrieved.
+//
+// Parameters:
+//   - t: The testing context.
+//   - options: The kubectl options used to configure the client.
+//   - name: The name of the GRPCRoute.
+//   - namespace: The namespace of the GRPCRoute.
+//
+// Returns:
+//   - *gwv1alpha2.GRPCRoute: The retrieved GRPCRoute.
+func GetGRPCRoute(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string) *gwv1alpha2.GRPCRoute {
+	route, err := GetGRPCRouteE(t, options, name, namespace)
+	require.NoError(t, err, "Failed to get GRPCRoute %s/%s", namespace, name)
+	return route
+}
+
+// GetGRPCRouteE gets a resource by name.
+func GetGRPCRouteE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string) (*gwv1alpha2.GRPCRoute, error) {
+	client, err := NewClient(t, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.GatewayV1alpha2().GRPCRoutes(namespace).Get(context.Background(), name, metav1.GetOptions{})
+}
+
+// WaitForGRPCRouteReady waits until the specified GRPCRoute is attached to all of its parents or the timeout is reached, failing the test otherwise.
+//
+// Parameters:
+//   - t: The testing context.
+//   - options: The kubectl options used to configure the client.
+//   - name: The name of the GRPCRoute.
+//   - namespace: The namespace of the GRPCRoute.
+//   - timeout: The maximum duration to wait.
+func WaitForGRPCRouteReady(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) {
+	err := WaitForGRPCRouteReadyE(t, options, name, namespace, timeout, opts...)
+	require.NoError(t, err, "GRPCRoute %s/%s was not attached to its parents", namespace, name)
+}
+
+// WaitForGRPCRouteReadyE waits until the specified GRPCRoute is ready, using IsGRPCRouteReady. It returns a
+// *wait.TerminalStateError as soon as a parent rejects the route, e.g. because none of the Gateway's
+// listeners allow routes from its namespace or match its hostnames.
+func WaitForGRPCRouteReadyE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) error {
+	client, err := NewClient(t, options)
+	if err != nil {
+		return err
+	}
+
+	_, err = wait.Waiter[*gwv1alpha2.GRPCRoute]{
+		Kind:      "GRPCRoute",
+		Name:      name,
+		Namespace: namespace,
+		Timeout:   timeout,
+		Get: func(ctx context.Context) (*gwv1alpha2.GRPCRoute, error) {
+			return client.GatewayV1alpha2().GRPCRoutes(namespace).Get(ctx, name, metav1.GetOptions{})
+		},
+		Watch: func(ctx context.Context) (watch.Interface, error) {
+			return client.GatewayV1alpha2().GRPCRoutes(namespace).Watch(ctx, metav1.SingleObject(metav1.ObjectMeta{Name: name}))
+		},
+		Ready: IsGRPCRouteReady,
+		Failed: func(route *gwv1alpha2.GRPCRoute) error {
+			return routeFailed(route.Namespace, route.Generation, route.Spec.ParentRefs, route.Status.RouteStatus)
+		},
+		Events: wait.KubeEvents(t, options),
+	}.Wait(t, opts...)
+	return err
+}
+
+// IsGRPCRouteReady reports whether every parentRef of the GRPCRoute has been reported Accepted=True and
+// ResolvedRefs=True for the route's current generation, i.e. each parent Gateway, or listener when
+// the parentRef names a section or port, has attached the route and all of its backends resolve.
+//
+// Parameters:
+//   - route: A pointer to the gwv1alpha2.GRPCRoute object to check.
+//
+// Returns:
+//   - bool: True if the route is attached to all of its parents, false otherwise.
+func IsGRPCRouteReady(route *gwv1alpha2.GRPCRoute) bool {
+	return routeReady(route.Namespace, route.Generation, route.Spec.ParentRefs, route.Status.RouteStatus)
+}
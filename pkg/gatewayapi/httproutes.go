@@ -0,0 +1,131 @@
+package gatewayapi
+
+import (
+	"context"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/testing"
+
+	"github.com/davidcollom/terratest-utils/pkg/wait"
+	"github.com/gruntwork-io/terratest/modules/k8s"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	gwv1 "sigs.k8s.io/gateway-api/apis/v1"
+)
+
+// ListHTTPRoutes retrieves the HTTPRoutes in the specified namespace, failing the test if they cannot be listed.
+//
+// Parameters:
+//   - t: The testing context.
+//   - options: The kubectl options used to configure the client.
+//   - namespace: The namespace from which to list HTTPRoutes.
+//
+// Returns:
+//   - A slice of gwv1.HTTPRoute objects.
+func ListHTTPRoutes(t testing.TestingT, options *k8s.KubectlOptions, namespace string) []gwv1.HTTPRoute {
+	routes, err := ListHTTPRoutesE(t, options, namespace)
+	require.NoError(t, err, "Failed to list HTTPRoutes in namespace %s", namespace)
+	return routes
+}
+
+// ListHTTPRoutesE lists matching resources.
+func ListHTTPRoutesE(t testing.TestingT, options *k8s.KubectlOptions, namespace string) ([]gwv1.HTTPRoute, error) {
+	client, err := NewClient(t, options)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := client.GatewayV1().HTTPRoutes(namespace).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return list.Items, nil
+}
+
+// GetHTTPRoute retrieves the specified HTTPRoute, failing the test if it cannot be retrieved.
+//
+// Parameters:
+//   - t: The testing context.
+//   - options: The kubectl options used to configure the client.
+//   - name: The name of the HTTPRoute.
+//   - namespace: The namespace of the HTTPRoute.
+//
+// Returns:
+//   - *gwv1.HTTPRoute: The retrieved HTTPRoute.
+func GetHTTPRoute(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string) *gwv1.HTTPRoute {
+	route, err := GetHTTPRouteE(t, options, name, namespace)
+	require.NoError(t, err, "Failed to get HTTPRoute %s/%s", namespace, name)
+	return route
+}
+
+// GetHTTPRouteE gets a resource by name.
+func GetHTTPRouteE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string) (*gwv1.HTTPRoute, error) {
+	client, err := NewClient(t, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.GatewayV1().HTTPRoutes(namespace).Get(context.Background(), name, metav1.GetOptions{})
+}
+
+// WaitForHTTPRouteReady waits until the specified HTTPRoute is attached to all of its parents or the timeout is reached, failing the test otherwise.
+//
+// Example usage:
+//
+//	gatewayapi.WaitForGatewayReady(t, options, "public", "istio-ingress", 5*time.Minute)
+//	gatewayapi.WaitForHTTPRouteReady(t, options, "podinfo", "default", 2*time.Minute)
+//
+// Parameters:
+//   - t: The testing context.
+//   - options: The kubectl options used to configure the client.
+//   - name: The name of the HTTPRoute.
+//   - namespace: The namespace of the HTTPRoute.
+//   - timeout: The maximum duration to wait.
+func WaitForHTTPRouteReady(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) {
+	err := WaitForHTTPRouteReadyE(t, options, name, namespace, timeout, opts...)
+	require.NoError(t, err, "HTTPRoute %s/%s was not attached to its parents", namespace, name)
+}
+
+// WaitForHTTPRouteReadyE waits until the specified HTTPRoute is ready, using IsHTTPRouteReady. It returns a
+// *wait.TerminalStateError as soon as a parent rejects the route, e.g. because none of the Gateway's
+// listeners allow routes from its namespace or match its hostnames.
+func WaitForHTTPRouteReadyE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) error {
+	client, err := NewClient(t, options)
+	if err != nil {
+		return err
+	}
+
+	_, err = wait.Waiter[*gwv1.HTTPRoute]{
+		Kind:      "HTTPRoute",
+		Name:      name,
+		Namespace: namespace,
+		Timeout:   timeout,
+		Get: func(ctx context.Context) (*gwv1.HTTPRoute, error) {
+			return client.GatewayV1().HTTPRoutes(namespace).Get(ctx, name, metav1.GetOptions{})
+		},
+		Watch: func(ctx context.Context) (watch.Interface, error) {
+			return client.GatewayV1().HTTPRoutes(namespace).Watch(ctx, metav1.SingleObject(metav1.ObjectMeta{Name: name}))
+		},
+		Ready: IsHTTPRouteReady,
+		Failed: func(route *gwv1.HTTPRoute) error {
+			return routeFailed(route.Namespace, route.Generation, route.Spec.ParentRefs, route.Status.RouteStatus)
+		},
+		Events: wait.KubeEvents(t, options),
+	}.Wait(t, opts...)
+	return err
+}
+
+// IsHTTPRouteReady reports whether every parentRef of the HTTPRoute has been reported Accepted=True and
+// ResolvedRefs=True for the route's current generation, i.e. each parent Gateway, or listener when
+// the parentRef names a section or port, has attached the route and all of its backends resolve.
+//
+// Parameters:
+//   - route: A pointer to the gwv1.HTTPRoute object to check.
+//
+// Returns:
+//   - bool: True if the route is attached to all of its parents, false otherwise.
+func IsHTTPRouteReady(route *gwv1.HTTPRoute) bool {
+	return routeReady(route.Namespace, route.Generation, route.Spec.ParentRefs, route.Status.RouteStatus)
+}
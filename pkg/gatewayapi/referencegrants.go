@@ -0,0 +1,71 @@
+package gatewayapi
+
+import (
+	"context"
+
+	"github.com/gruntwork-io/terratest/modules/testing"
+
+	"github.com/gruntwork-io/terratest/modules/k8s"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	gwv1beta1 "sigs.k8s.io/gateway-api/apis/v1beta1"
+)
+
+// ListReferenceGrants retrieves the ReferenceGrants in the specified namespace, failing the test if
+// they cannot be listed. ReferenceGrants have no status to wait for; whether a route may use the
+// backends they grant access to is reported by the route's ResolvedRefs condition, which
+// WaitForHTTPRouteReady and the other route waits check.
+//
+// Parameters:
+//   - t: The testing context.
+//   - options: The kubectl options used to configure the client.
+//   - namespace: The namespace from which to list ReferenceGrants.
+//
+// Returns:
+//   - A slice of gwv1beta1.ReferenceGrant objects.
+func ListReferenceGrants(t testing.TestingT, options *k8s.KubectlOptions, namespace string) []gwv1beta1.ReferenceGrant {
+	grants, err := ListReferenceGrantsE(t, options, namespace)
+	require.NoError(t, err, "Failed to list ReferenceGrants in namespace %s", namespace)
+	return grants
+}
+
+// ListReferenceGrantsE lists matching resources.
+func ListReferenceGrantsE(t testing.TestingT, options *k8s.KubectlOptions, namespace string) ([]gwv1beta1.ReferenceGrant, error) {
+	client, err := NewClient(t, options)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := client.GatewayV1beta1().ReferenceGrants(namespace).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return list.Items, nil
+}
+
+// GetReferenceGrant retrieves the specified ReferenceGrant, failing the test if it cannot be retrieved.
+//
+// Parameters:
+//   - t: The testing context.
+//   - options: The kubectl options used to configure the client.
+//   - name: The name of the ReferenceGrant.
+//   - namespace: The namespace of the ReferenceGrant, i.e. of the objects it grants access to.
+//
+// Returns:
+//   - *gwv1beta1.ReferenceGrant: The retrieved ReferenceGrant.
+func GetReferenceGrant(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string) *gwv1beta1.ReferenceGrant {
+	grant, err := GetReferenceGrantE(t, options, name, namespace)
+	require.NoError(t, err, "Failed to get ReferenceGrant %s/%s", namespace, name)
+	return grant
+}
+
+// GetReferenceGrantE gets a resource by name.
+func GetReferenceGrantE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string) (*gwv1beta1.ReferenceGrant, error) {
+	client, err := NewClient(t, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.GatewayV1beta1().ReferenceGrants(namespace).Get(context.Background(), name, metav1.GetOptions{})
+}
@@ -0,0 +1,126 @@
+package gatewayapi
+
+import (
+	"context"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/testing"
+
+	"github.com/davidcollom/terratest-utils/pkg/wait"
+	"github.com/gruntwork-io/terratest/modules/k8s"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	gwv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+// ListTCPRoutes retrieves the TCPRoutes in the specified namespace, failing the test if they cannot be listed.
+//
+// Parameters:
+//   - t: The testing context.
+//   - options: The kubectl options used to configure the client.
+//   - namespace: The namespace from which to list TCPRoutes.
+//
+// Returns:
+//   - A slice of gwv1alpha2.TCPRoute objects.
+func ListTCPRoutes(t testing.TestingT, options *k8s.KubectlOptions, namespace string) []gwv1alpha2.TCPRoute {
+	routes, err := ListTCPRoutesE(t, options, namespace)
+	require.NoError(t, err, "Failed to list TCPRoutes in namespace %s", namespace)
+	return routes
+}
+
+// ListTCPRoutesE lists matching resources.
+func ListTCPRoutesE(t testing.TestingT, options *k8s.KubectlOptions, namespace string) ([]gwv1alpha2.TCPRoute, error) {
+	client, err := NewClient(t, options)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := client.GatewayV1alpha2().TCPRoutes(namespace).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return list.Items, nil
+}
+
+// GetTCPRoute retrieves the specified TCPRoute, failing the test if it cannot be retrieved.
+//
+// Parameters:
+//   - t: The testing context.
+//   - options: The kubectl options used to configure the client.
+//   - name: The name of the TCPRoute.
+//   - namespace: The namespace of the TCPRoute.
+//
+// Returns:
+//   - *gwv1alpha2.TCPRoute: The retrieved TCPRoute.
+func GetTCPRoute(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string) *gwv1alpha2.TCPRoute {
+	route, err := GetTCPRouteE(t, options, name, namespace)
+	require.NoError(t, err, "Failed to get TCPRoute %s/%s", namespace, name)
+	return route
+}
+
+// GetTCPRouteE gets a resource by name.
+func GetTCPRouteE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string) (*gwv1alpha2.TCPRoute, error) {
+	client, err := NewClient(t, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.GatewayV1alpha2().TCPRoutes(namespace).Get(context.Background(), name, metav1.GetOptions{})
+}
+
+// WaitForTCPRouteReady waits until the specified TCPRoute is attached to all of its parents or the timeout is reached, failing the test otherwise.
+//
+// Parameters:
+//   - t: The testing context.
+//   - options: The kubectl options used to configure the client.
+//   - name: The name of the TCPRoute.
+//   - namespace: The namespace of the TCPRoute.
+//   - timeout: The maximum duration to wait.
+func WaitForTCPRouteReady(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) {
+	err := WaitForTCPRouteReadyE(t, options, name, namespace, timeout, opts...)
+	require.NoError(t, err, "TCPRoute %s/%s was not attached to its parents", namespace, name)
+}
+
+// WaitForTCPRouteReadyE waits until the specified TCPRoute is ready, using IsTCPRouteReady. It returns a
+// *wait.TerminalStateError as soon as a parent rejects the route, e.g. because none of the Gateway's
+// listeners allow routes from its namespace or match its hostnames.
+func WaitForTCPRouteReadyE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) error {
+	client, err := NewClient(t, options)
+	if err != nil {
+		return err
+	}
+
+	_, err = wait.Waiter[*gwv1alpha2.TCPRoute]{
+		Kind:      "TCPRoute",
+		Name:      name,
+		Namespace: namespace,
+		Timeout:   timeout,
+		Get: func(ctx context.Context) (*gwv1alpha2.TCPRoute, error) {
+			return client.GatewayV1alpha2().TCPRoutes(namespace).Get(ctx, name, metav1.GetOptions{})
+		},
+		Watch: func(ctx context.Context) (watch.Interface, error) {
+			return client.GatewayV1alpha2().TCPRoutes(namespace).Watch(ctx, metav1.SingleObject(metav1.ObjectMeta{Name: name}))
+		},
+		Ready: IsTCPRouteReady,
+		Failed: func(route *gwv1alpha2.TCPRoute) error {
+			return routeFailed(route.Namespace, route.Generation, route.Spec.ParentRefs, route.Status.RouteStatus)
+		},
+		Events: wait.KubeEvents(t, options),
+	}.Wait(t, opts...)
+	return err
+}
+
+// IsTCPRouteReady reports whether every parentRef of the TCPRoute has been reported Accepted=True and
+// ResolvedRefs=True for the route's current generation, i.e. each parent Gateway, or listener when
+// the parentRef names a section or port, has attached the route and all of its backends resolve.
+//
+// Parameters:
+//   - route: A pointer to the gwv1alpha2.TCPRoute object to check.
+//
+// Returns:
+//   - bool: True if the route is attached to all of its parents, false otherwise.
+func IsTCPRouteReady(route *gwv1alpha2.TCPRoute) bool {
+	return routeReady(route.Namespace, route.Generation, route.Spec.ParentRefs, route.Status.RouteStatus)
+}
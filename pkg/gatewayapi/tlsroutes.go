@@ -0,0 +1,126 @@
+package gatewayapi
+
+import (
+	"context"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/testing"
+
+	"github.com/davidcollom/terratest-utils/pkg/wait"
+	"github.com/gruntwork-io/terratest/modules/k8s"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	gwv1alpha2 "sigs.k8s.io/gateway-api/apis/v1alpha2"
+)
+
+// ListTLSRoutes retrieves the TLSRoutes in the specified namespace, failing the test if they cannot be listed.
+//
+// Parameters:
+//   - t: The testing context.
+//   - options: The kubectl options used to configure the client.
+//   - namespace: The namespace from which to list TLSRoutes.
+//
+// Returns:
+//   - A slice of gwv1alpha2.TLSRoute objects.
+func ListTLSRoutes(t testing.TestingT, options *k8s.KubectlOptions, namespace string) []gwv1alpha2.TLSRoute {
+	routes, err := ListTLSRoutesE(t, options, namespace)
+	require.NoError(t, err, "Failed to list TLSRoutes in namespace %s", namespace)
+	return routes
+}
+
+// ListTLSRoutesE lists matching resources.
+func ListTLSRoutesE(t testing.TestingT, options *k8s.KubectlOptions, namespace string) ([]gwv1alpha2.TLSRoute, error) {
+	client, err := NewClient(t, options)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := client.GatewayV1alpha2().TLSRoutes(namespace).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return list.Items, nil
+}
+
+// GetTLSRoute retrieves the specified TLSRoute, failing the test if it cannot be retrieved.
+//
+// Parameters:
+//   - t: The testing context.
+//   - options: The kubectl options used to configure the client.
+//   - name: The name of the TLSRoute.
+//   - namespace: The namespace of the TLSRoute.
+//
+// Returns:
+//   - *gwv1alpha2.TLSRoute: The retrieved TLSRoute.
+func GetTLSRoute(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string) *gwv1alpha2.TLSRoute {
+	route, err := GetTLSRouteE(t, options, name, namespace)
+	require.NoError(t, err, "Failed to get TLSRoute %s/%s", namespace, name)
+	return route
+}
+
+// GetTLSRouteE gets a resource by name.
+func GetTLSRouteE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string) (*gwv1alpha2.TLSRoute, error) {
+	client, err := NewClient(t, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.GatewayV1alpha2().TLSRoutes(namespace).Get(context.Background(), name, metav1.GetOptions{})
+}
+
+// WaitForTLSRouteReady waits until the specified TLSRoute is attached to all of its parents or the timeout is reached, failing the test otherwise.
+//
+// Parameters:
+//   - t: The testing context.
+//   - options: The kubectl options used to configure the client.
+//   - name: The name of the TLSRoute.
+//   - namespace: The namespace of the TLSRoute.
+//   - timeout: The maximum duration to wait.
+func WaitForTLSRouteReady(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) {
+	err := WaitForTLSRouteReadyE(t, options, name, namespace, timeout, opts...)
+	require.NoError(t, err, "TLSRoute %s/%s was not attached to its parents", namespace, name)
+}
+
+// WaitForTLSRouteReadyE waits until the specified TLSRoute is ready, using IsTLSRouteReady. It returns a
+// *wait.TerminalStateError as soon as a parent rejects the route, e.g. because none of the Gateway's
+// listeners allow routes from its namespace or match its hostnames.
+func WaitForTLSRouteReadyE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) error {
+	client, err := NewClient(t, options)
+	if err != nil {
+		return err
+	}
+
+	_, err = wait.Waiter[*gwv1alpha2.TLSRoute]{
+		Kind:      "TLSRoute",
+		Name:      name,
+		Namespace: namespace,
+		Timeout:   timeout,
+		Get: func(ctx context.Context) (*gwv1alpha2.TLSRoute, error) {
+			return client.GatewayV1alpha2().TLSRoutes(namespace).Get(ctx, name, metav1.GetOptions{})
+		},
+		Watch: func(ctx context.Context) (watch.Interface, error) {
+			return client.GatewayV1alpha2().TLSRoutes(namespace).Watch(ctx, metav1.SingleObject(metav1.ObjectMeta{Name: name}))
+		},
+		Ready: IsTLSRouteReady,
+		Failed: func(route *gwv1alpha2.TLSRoute) error {
+			return routeFailed(route.Namespace, route.Generation, route.Spec.ParentRefs, route.Status.RouteStatus)
+		},
+		Events: wait.KubeEvents(t, options),
+	}.Wait(t, opts...)
+	return err
+}
+
+// IsTLSRouteReady reports whether every parentRef of the TLSRoute has been reported Accepted=True and
+// ResolvedRefs=True for the route's current generation, i.e. each parent Gateway, or listener when
+// the parentRef names a section or port, has attached the route and all of its backends resolve.
+//
+// Parameters:
+//   - route: A pointer to the gwv1alpha2.TLSRoute object to check.
+//
+// Returns:
+//   - bool: True if the route is attached to all of its parents, false otherwise.
+func IsTLSRouteReady(route *gwv1alpha2.TLSRoute) bool {
+	return routeReady(route.Namespace, route.Generation, route.Spec.ParentRefs, route.Status.RouteStatus)
+}
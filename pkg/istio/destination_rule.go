@@ -6,11 +6,12 @@ import (
 
 	"github.com/gruntwork-io/terratest/modules/testing"
 
+	"github.com/davidcollom/terratest-utils/pkg/wait"
 	"github.com/gruntwork-io/terratest/modules/k8s"
 	"github.com/stretchr/testify/require"
 	isitonetworkingv1alpha3 "istio.io/client-go/pkg/apis/networking/v1alpha3"
 	v1meta "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
 )
 
 // ListDestinationRules retrieves all Istio DestinationRule resources in the specified namespace using the provided KubectlOptions.
@@ -45,7 +46,7 @@ func ListDestinationRulesE(t testing.TestingT, options *k8s.KubectlOptions, name
 }
 
 // WaitForDestinationRuleReady waits until the specified DestinationRule in the given namespace is Ready or the timeout is reached.
-// It polls the DestinationRule status every 2 seconds and checks for the Ready condition.
+// It watches the DestinationRule status, falling back to polling, and checks for the Ready condition.
 //
 // Parameters:
 //   - t: The testing context.
@@ -55,26 +56,30 @@ func ListDestinationRulesE(t testing.TestingT, options *k8s.KubectlOptions, name
 //   - timeout: The maximum duration to wait for the resource to become Ready.
 //
 // WaitForDestinationRuleReady waits for the resource condition to be satisfied.
-func WaitForDestinationRuleReady(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) {
-	err := WaitForDestinationRuleReadyE(t, options, name, namespace, timeout)
+func WaitForDestinationRuleReady(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) {
+	err := WaitForDestinationRuleReadyE(t, options, name, namespace, timeout, opts...)
 	require.NoError(t, err, "DestinationRule %s/%s did not become Ready", namespace, name)
 }
 
 // WaitForDestinationRuleReadyE waits for the resource condition to be satisfied.
-func WaitForDestinationRuleReadyE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) error {
-	options = k8s.NewKubectlOptions("", "", namespace)
+func WaitForDestinationRuleReadyE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) error {
 	istioClient := NewClient(t, options)
 
-	ctx := context.Background()
-	return wait.PollUntilContextTimeout(ctx, 2*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
-		var destinationRule *isitonetworkingv1alpha3.DestinationRule
-		destinationRule, err := istioClient.NetworkingV1alpha3().DestinationRules(namespace).Get(ctx, name, v1meta.GetOptions{})
-		if err != nil {
-			return false, nil
-		}
-		if destinationRule.Status.Conditions != nil {
-			return istioConditionReady(t, &destinationRule.Status), nil
-		}
-		return false, nil
-	})
+	_, err := wait.Waiter[*isitonetworkingv1alpha3.DestinationRule]{
+		Kind:      "DestinationRule",
+		Name:      name,
+		Namespace: namespace,
+		Timeout:   timeout,
+		Get: func(ctx context.Context) (*isitonetworkingv1alpha3.DestinationRule, error) {
+			return istioClient.NetworkingV1alpha3().DestinationRules(namespace).Get(ctx, name, v1meta.GetOptions{})
+		},
+		Watch: func(ctx context.Context) (watch.Interface, error) {
+			return istioClient.NetworkingV1alpha3().DestinationRules(namespace).Watch(ctx, v1meta.SingleObject(v1meta.ObjectMeta{Name: name}))
+		},
+		Ready: func(destinationRule *isitonetworkingv1alpha3.DestinationRule) bool {
+			return destinationRule.Status.Conditions != nil && istioConditionReady(t, &destinationRule.Status)
+		},
+		Events: wait.KubeEvents(t, options),
+	}.Wait(t, opts...)
+	return err
 }
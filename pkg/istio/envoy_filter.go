@@ -6,11 +6,12 @@ import (
 
 	"github.com/gruntwork-io/terratest/modules/testing"
 
+	"github.com/davidcollom/terratest-utils/pkg/wait"
 	"github.com/gruntwork-io/terratest/modules/k8s"
 	"github.com/stretchr/testify/require"
 	istionetworkingv1alpha3 "istio.io/client-go/pkg/apis/networking/v1alpha3"
 	v1meta "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
 )
 
 // ListEnvoyFilters retrieves all Istio EnvoyFilter resources in the specified namespace using the provided KubectlOptions.
@@ -45,7 +46,7 @@ func ListEnvoyFiltersE(t testing.TestingT, options *k8s.KubectlOptions, namespac
 }
 
 // WaitForEnvoyFilterReady waits until the specified EnvoyFilter in the given namespace is Ready or the timeout is reached.
-// It polls the EnvoyFilter status every 2 seconds and checks for the Ready condition.
+// It watches the EnvoyFilter status, falling back to polling, and checks for the Ready condition.
 //
 // Parameters:
 //   - t: The testing context.
@@ -55,26 +56,30 @@ func ListEnvoyFiltersE(t testing.TestingT, options *k8s.KubectlOptions, namespac
 //   - timeout: The maximum duration to wait for the resource to become Ready.
 //
 // WaitForEnvoyFilterReady waits for the resource condition to be satisfied.
-func WaitForEnvoyFilterReady(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) {
-	err := WaitForEnvoyFilterReadyE(t, options, name, namespace, timeout)
+func WaitForEnvoyFilterReady(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) {
+	err := WaitForEnvoyFilterReadyE(t, options, name, namespace, timeout, opts...)
 	require.NoError(t, err, "EnvoyFilter %s/%s did not become Ready", namespace, name)
 }
 
 // WaitForEnvoyFilterReadyE waits for the resource condition to be satisfied.
-func WaitForEnvoyFilterReadyE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) error {
-	options = k8s.NewKubectlOptions("", "", namespace)
+func WaitForEnvoyFilterReadyE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) error {
 	istioClient := NewClient(t, options)
 
-	ctx := context.Background()
-	return wait.PollUntilContextTimeout(ctx, 2*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
-		var envoyFilter *istionetworkingv1alpha3.EnvoyFilter
-		envoyFilter, err := istioClient.NetworkingV1alpha3().EnvoyFilters(namespace).Get(ctx, name, v1meta.GetOptions{})
-		if err != nil {
-			return false, nil
-		}
-		if envoyFilter.Status.Conditions != nil {
-			return istioConditionReady(t, &envoyFilter.Status), nil
-		}
-		return false, nil
-	})
+	_, err := wait.Waiter[*istionetworkingv1alpha3.EnvoyFilter]{
+		Kind:      "EnvoyFilter",
+		Name:      name,
+		Namespace: namespace,
+		Timeout:   timeout,
+		Get: func(ctx context.Context) (*istionetworkingv1alpha3.EnvoyFilter, error) {
+			return istioClient.NetworkingV1alpha3().EnvoyFilters(namespace).Get(ctx, name, v1meta.GetOptions{})
+		},
+		Watch: func(ctx context.Context) (watch.Interface, error) {
+			return istioClient.NetworkingV1alpha3().EnvoyFilters(namespace).Watch(ctx, v1meta.SingleObject(v1meta.ObjectMeta{Name: name}))
+		},
+		Ready: func(envoyFilter *istionetworkingv1alpha3.EnvoyFilter) bool {
+			return envoyFilter.Status.Conditions != nil && istioConditionReady(t, &envoyFilter.Status)
+		},
+		Events: wait.KubeEvents(t, options),
+	}.Wait(t, opts...)
+	return err
 }
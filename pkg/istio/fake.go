@@ -0,0 +1,32 @@
+package istio
+
+import (
+	istioClientset "istio.io/client-go/pkg/clientset/versioned"
+	fakeistio "istio.io/client-go/pkg/clientset/versioned/fake"
+
+	"github.com/davidcollom/terratest-utils/pkg/utils"
+	"github.com/gruntwork-io/terratest/modules/k8s"
+	"github.com/gruntwork-io/terratest/modules/testing"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// NewTestClient creates a fake Istio clientset seeded with objs and makes NewClient return it
+// for the rest of the test, so that every helper in this package runs against the fake. NewClient is
+// restored when the test finishes.
+//
+// Example usage:
+//
+//	istio.NewTestClient(t, &networkingv1alpha3.ServiceEntry{...})
+//	istio.WaitForServiceEntryReady(t, &k8s.KubectlOptions{}, "my-entry", "default", time.Second)
+func NewTestClient(t utils.CleanupT, objs ...runtime.Object) istioClientset.Interface {
+	client := fakeistio.NewSimpleClientset(objs...)
+
+	NewClient = func(t testing.TestingT, options *k8s.KubectlOptions) istioClientset.Interface {
+		return client
+	}
+	t.Cleanup(func() {
+		NewClient = newClient
+	})
+
+	return client
+}
@@ -6,11 +6,12 @@ import (
 
 	"github.com/gruntwork-io/terratest/modules/testing"
 
+	"github.com/davidcollom/terratest-utils/pkg/wait"
 	"github.com/gruntwork-io/terratest/modules/k8s"
 	"github.com/stretchr/testify/require"
 	istionetworkingv1alpha3 "istio.io/client-go/pkg/apis/networking/v1alpha3"
 	v1meta "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
 )
 
 // ListGateways retrieves all Istio Gateway resources in the specified namespace using the provided KubectlOptions.
@@ -45,7 +46,7 @@ func ListGatewaysE(t testing.TestingT, options *k8s.KubectlOptions, namespace st
 }
 
 // WaitForGatewayReady waits until the specified Gateway in the given namespace is Ready or the timeout is reached.
-// It polls the Gateway status every 2 seconds and checks for the Ready condition.
+// It watches the Gateway status, falling back to polling, and checks for the Ready condition.
 //
 // Parameters:
 //   - t: The testing context.
@@ -55,26 +56,30 @@ func ListGatewaysE(t testing.TestingT, options *k8s.KubectlOptions, namespace st
 //   - timeout: The maximum duration to wait for the resource to become Ready.
 //
 // WaitForGatewayReady waits for the resource condition to be satisfied.
-func WaitForGatewayReady(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) {
-	err := WaitForGatewayReadyE(t, options, name, namespace, timeout)
+func WaitForGatewayReady(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) {
+	err := WaitForGatewayReadyE(t, options, name, namespace, timeout, opts...)
 	require.NoError(t, err, "Gateway %s/%s did not become Ready", namespace, name)
 }
 
 // WaitForGatewayReadyE waits for the resource condition to be satisfied.
-func WaitForGatewayReadyE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) error {
-	options = k8s.NewKubectlOptions("", "", namespace)
+func WaitForGatewayReadyE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) error {
 	istioClient := NewClient(t, options)
 
-	ctx := context.Background()
-	return wait.PollUntilContextTimeout(ctx, 2*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
-		var gateway *istionetworkingv1alpha3.Gateway
-		gateway, err := istioClient.NetworkingV1alpha3().Gateways(namespace).Get(ctx, name, v1meta.GetOptions{})
-		if err != nil {
-			return false, nil
-		}
-		if gateway.Status.Conditions != nil {
-			return istioConditionReady(t, &gateway.Status), nil
-		}
-		return false, nil
-	})
+	_, err := wait.Waiter[*istionetworkingv1alpha3.Gateway]{
+		Kind:      "Gateway",
+		Name:      name,
+		Namespace: namespace,
+		Timeout:   timeout,
+		Get: func(ctx context.Context) (*istionetworkingv1alpha3.Gateway, error) {
+			return istioClient.NetworkingV1alpha3().Gateways(namespace).Get(ctx, name, v1meta.GetOptions{})
+		},
+		Watch: func(ctx context.Context) (watch.Interface, error) {
+			return istioClient.NetworkingV1alpha3().Gateways(namespace).Watch(ctx, v1meta.SingleObject(v1meta.ObjectMeta{Name: name}))
+		},
+		Ready: func(gateway *istionetworkingv1alpha3.Gateway) bool {
+			return gateway.Status.Conditions != nil && istioConditionReady(t, &gateway.Status)
+		},
+		Events: wait.KubeEvents(t, options),
+	}.Wait(t, opts...)
+	return err
 }
@@ -7,30 +7,31 @@ package istio
 import (
 	"github.com/gruntwork-io/terratest/modules/testing"
 
-	"github.com/davidcollom/terratest-utils/pkg/utils"
+	"github.com/davidcollom/terratest-utils/pkg/clients"
 	"github.com/gruntwork-io/terratest/modules/k8s"
 	"github.com/stretchr/testify/require"
 	istiometa "istio.io/api/meta/v1alpha1"
 	istionetworking "istio.io/api/networking/v1alpha3"
 	istioClientset "istio.io/client-go/pkg/clientset/versioned"
+	"k8s.io/client-go/rest"
 )
 
 // NewClient creates and returns a new Istio Client for use in tests.
 // It initializes the Kubernetes REST configuration and the Istio clientset,
-// failing the test if any errors occur during setup.
+// failing the test if any errors occur during setup. The clientset is cached
+// per cluster by pkg/clients.
 //
 // Parameters:
 //   - t: The testing context used for logging and error handling.
 //
 // Returns:
-//   - *Client: A pointer to the initialized Istio client.
+//   - istioClientset.Interface: The initialized Istio clientset.
 var NewClient = newClient
 
-func newClient(t testing.TestingT, options *k8s.KubectlOptions) *istioClientset.Clientset {
-	cfg, err := utils.GetRestConfigE(t, options)
-	require.NoError(t, err)
-
-	client, err := istioClientset.NewForConfig(cfg)
+func newClient(t testing.TestingT, options *k8s.KubectlOptions) istioClientset.Interface {
+	client, err := clients.Get(t, options, "istio", func(cfg *rest.Config) (istioClientset.Interface, error) {
+		return istioClientset.NewForConfig(cfg)
+	})
 	require.NoError(t, err, "Failed to create Istio client")
 
 	return client
@@ -6,11 +6,12 @@ import (
 
 	"github.com/gruntwork-io/terratest/modules/testing"
 
+	"github.com/davidcollom/terratest-utils/pkg/wait"
 	"github.com/gruntwork-io/terratest/modules/k8s"
 	"github.com/stretchr/testify/require"
 	istiosecurityv1 "istio.io/client-go/pkg/apis/security/v1"
 	v1meta "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
 )
 
 // ListPeerAuthentications retrieves all Istio PeerAuthentication resources in the specified namespace using the provided KubectlOptions.
@@ -45,7 +46,7 @@ func ListPeerAuthenticationsE(t testing.TestingT, options *k8s.KubectlOptions, n
 }
 
 // WaitForPeerAuthenticationReady waits until the specified PeerAuthentication in the given namespace is Ready or the timeout is reached.
-// It polls the PeerAuthentication status every 2 seconds and checks for the Ready condition.
+// It watches the PeerAuthentication status, falling back to polling, and checks for the Ready condition.
 //
 // Parameters:
 //   - t: The testing context.
@@ -55,26 +56,30 @@ func ListPeerAuthenticationsE(t testing.TestingT, options *k8s.KubectlOptions, n
 //   - timeout: The maximum duration to wait for the resource to become Ready.
 //
 // WaitForPeerAuthenticationReady waits for the resource condition to be satisfied.
-func WaitForPeerAuthenticationReady(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) {
-	err := WaitForPeerAuthenticationReadyE(t, options, name, namespace, timeout)
+func WaitForPeerAuthenticationReady(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) {
+	err := WaitForPeerAuthenticationReadyE(t, options, name, namespace, timeout, opts...)
 	require.NoError(t, err, "PeerAuthentication %s/%s did not become Ready", namespace, name)
 }
 
 // WaitForPeerAuthenticationReadyE waits for the resource condition to be satisfied.
-func WaitForPeerAuthenticationReadyE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) error {
-	options = k8s.NewKubectlOptions("", "", namespace)
+func WaitForPeerAuthenticationReadyE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) error {
 	istioClient := NewClient(t, options)
 
-	ctx := context.Background()
-	return wait.PollUntilContextTimeout(ctx, 2*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
-		var peerAuthentication *istiosecurityv1.PeerAuthentication
-		peerAuthentication, err := istioClient.SecurityV1().PeerAuthentications(namespace).Get(ctx, name, v1meta.GetOptions{})
-		if err != nil {
-			return false, nil
-		}
-		if peerAuthentication.Status.Conditions != nil {
-			return istioConditionReady(t, &peerAuthentication.Status), nil
-		}
-		return false, nil
-	})
+	_, err := wait.Waiter[*istiosecurityv1.PeerAuthentication]{
+		Kind:      "PeerAuthentication",
+		Name:      name,
+		Namespace: namespace,
+		Timeout:   timeout,
+		Get: func(ctx context.Context) (*istiosecurityv1.PeerAuthentication, error) {
+			return istioClient.SecurityV1().PeerAuthentications(namespace).Get(ctx, name, v1meta.GetOptions{})
+		},
+		Watch: func(ctx context.Context) (watch.Interface, error) {
+			return istioClient.SecurityV1().PeerAuthentications(namespace).Watch(ctx, v1meta.SingleObject(v1meta.ObjectMeta{Name: name}))
+		},
+		Ready: func(peerAuthentication *istiosecurityv1.PeerAuthentication) bool {
+			return peerAuthentication.Status.Conditions != nil && istioConditionReady(t, &peerAuthentication.Status)
+		},
+		Events: wait.KubeEvents(t, options),
+	}.Wait(t, opts...)
+	return err
 }
@@ -6,11 +6,12 @@ import (
 
 	"github.com/gruntwork-io/terratest/modules/testing"
 
+	"github.com/davidcollom/terratest-utils/pkg/wait"
 	"github.com/gruntwork-io/terratest/modules/k8s"
 	"github.com/stretchr/testify/require"
 	istiosecurityv1 "istio.io/client-go/pkg/apis/security/v1"
 	v1meta "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
 )
 
 // ListRequestAuthentications retrieves all Istio RequestAuthentication resources in the specified namespace using the provided KubectlOptions.
@@ -45,7 +46,7 @@ func ListRequestAuthenticationsE(t testing.TestingT, options *k8s.KubectlOptions
 }
 
 // WaitForRequestAuthenticationReady waits until the specified RequestAuthentication in the given namespace is Ready or the timeout is reached.
-// It polls the RequestAuthentication status every 2 seconds and checks for the Ready condition.
+// It watches the RequestAuthentication status, falling back to polling, and checks for the Ready condition.
 //
 // Parameters:
 //   - t: The testing context.
@@ -55,26 +56,30 @@ func ListRequestAuthenticationsE(t testing.TestingT, options *k8s.KubectlOptions
 //   - timeout: The maximum duration to wait for the resource to become Ready.
 //
 // WaitForRequestAuthenticationReady waits for the resource condition to be satisfied.
-func WaitForRequestAuthenticationReady(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) {
-	err := WaitForRequestAuthenticationReadyE(t, options, name, namespace, timeout)
+func WaitForRequestAuthenticationReady(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) {
+	err := WaitForRequestAuthenticationReadyE(t, options, name, namespace, timeout, opts...)
 	require.NoError(t, err, "RequestAuthentication %s/%s did not become Ready", namespace, name)
 }
 
 // WaitForRequestAuthenticationReadyE waits for the resource condition to be satisfied.
-func WaitForRequestAuthenticationReadyE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) error {
-	options = k8s.NewKubectlOptions("", "", namespace)
+func WaitForRequestAuthenticationReadyE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) error {
 	istioClient := NewClient(t, options)
 
-	ctx := context.Background()
-	return wait.PollUntilContextTimeout(ctx, 2*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
-		var requestAuthentication *istiosecurityv1.RequestAuthentication
-		requestAuthentication, err := istioClient.SecurityV1().RequestAuthentications(namespace).Get(ctx, name, v1meta.GetOptions{})
-		if err != nil {
-			return false, nil
-		}
-		if requestAuthentication.Status.Conditions != nil {
-			return istioConditionReady(t, &requestAuthentication.Status), nil
-		}
-		return false, nil
-	})
+	_, err := wait.Waiter[*istiosecurityv1.RequestAuthentication]{
+		Kind:      "RequestAuthentication",
+		Name:      name,
+		Namespace: namespace,
+		Timeout:   timeout,
+		Get: func(ctx context.Context) (*istiosecurityv1.RequestAuthentication, error) {
+			return istioClient.SecurityV1().RequestAuthentications(namespace).Get(ctx, name, v1meta.GetOptions{})
+		},
+		Watch: func(ctx context.Context) (watch.Interface, error) {
+			return istioClient.SecurityV1().RequestAuthentications(namespace).Watch(ctx, v1meta.SingleObject(v1meta.ObjectMeta{Name: name}))
+		},
+		Ready: func(requestAuthentication *istiosecurityv1.RequestAuthentication) bool {
+			return requestAuthentication.Status.Conditions != nil && istioConditionReady(t, &requestAuthentication.Status)
+		},
+		Events: wait.KubeEvents(t, options),
+	}.Wait(t, opts...)
+	return err
 }
@@ -6,11 +6,12 @@ import (
 
 	"github.com/gruntwork-io/terratest/modules/testing"
 
+	"github.com/davidcollom/terratest-utils/pkg/wait"
 	"github.com/gruntwork-io/terratest/modules/k8s"
 	"github.com/stretchr/testify/require"
 	istionetworkingv1alpha3 "istio.io/client-go/pkg/apis/networking/v1alpha3"
 	v1meta "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
 )
 
 // ListServiceEntries retrieves all Istio ServiceEntry resources in the specified namespace using the provided KubectlOptions.
@@ -45,7 +46,7 @@ func ListServiceEntriesE(t testing.TestingT, options *k8s.KubectlOptions, namesp
 }
 
 // WaitForServiceEntryReady waits until the specified ServiceEntry in the given namespace is Ready or the timeout is reached.
-// It polls the ServiceEntry status every 2 seconds and checks for the Ready condition.
+// It watches the ServiceEntry status, falling back to polling, and checks for the Ready condition.
 //
 // Parameters:
 //   - t: The testing context.
@@ -55,26 +56,30 @@ func ListServiceEntriesE(t testing.TestingT, options *k8s.KubectlOptions, namesp
 //   - timeout: The maximum duration to wait for the resource to become Ready.
 //
 // WaitForServiceEntryReady waits for the resource condition to be satisfied.
-func WaitForServiceEntryReady(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) {
-	err := WaitForServiceEntryReadyE(t, options, name, namespace, timeout)
+func WaitForServiceEntryReady(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) {
+	err := WaitForServiceEntryReadyE(t, options, name, namespace, timeout, opts...)
 	require.NoError(t, err, "ServiceEntry %s/%s did not become Ready", namespace, name)
 }
 
 // WaitForServiceEntryReadyE waits for the resource condition to be satisfied.
-func WaitForServiceEntryReadyE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) error {
-	options = k8s.NewKubectlOptions("", "", namespace)
+func WaitForServiceEntryReadyE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) error {
 	istioClient := NewClient(t, options)
 
-	ctx := context.Background()
-	return wait.PollUntilContextTimeout(ctx, 2*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
-		var serviceEntry *istionetworkingv1alpha3.ServiceEntry
-		serviceEntry, err := istioClient.NetworkingV1alpha3().ServiceEntries(namespace).Get(ctx, name, v1meta.GetOptions{})
-		if err != nil {
-			return false, nil
-		}
-		if serviceEntry.Status.Conditions != nil {
-			return serviceEntryConditionReady(t, &serviceEntry.Status), nil
-		}
-		return false, nil
-	})
+	_, err := wait.Waiter[*istionetworkingv1alpha3.ServiceEntry]{
+		Kind:      "ServiceEntry",
+		Name:      name,
+		Namespace: namespace,
+		Timeout:   timeout,
+		Get: func(ctx context.Context) (*istionetworkingv1alpha3.ServiceEntry, error) {
+			return istioClient.NetworkingV1alpha3().ServiceEntries(namespace).Get(ctx, name, v1meta.GetOptions{})
+		},
+		Watch: func(ctx context.Context) (watch.Interface, error) {
+			return istioClient.NetworkingV1alpha3().ServiceEntries(namespace).Watch(ctx, v1meta.SingleObject(v1meta.ObjectMeta{Name: name}))
+		},
+		Ready: func(serviceEntry *istionetworkingv1alpha3.ServiceEntry) bool {
+			return serviceEntry.Status.Conditions != nil && serviceEntryConditionReady(t, &serviceEntry.Status)
+		},
+		Events: wait.KubeEvents(t, options),
+	}.Wait(t, opts...)
+	return err
 }
@@ -6,11 +6,12 @@ import (
 
 	"github.com/gruntwork-io/terratest/modules/testing"
 
+	"github.com/davidcollom/terratest-utils/pkg/wait"
 	"github.com/gruntwork-io/terratest/modules/k8s"
 	"github.com/stretchr/testify/require"
 	istionetworkingv1alpha3 "istio.io/client-go/pkg/apis/networking/v1alpha3"
 	v1meta "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
 )
 
 // ListSidecars retrieves all Istio Sidecar resources in the specified namespace using the provided KubectlOptions.
@@ -45,7 +46,7 @@ func ListSidecarsE(t testing.TestingT, options *k8s.KubectlOptions, namespace st
 }
 
 // WaitForSidecarReady waits until the specified Sidecar in the given namespace is Ready or the timeout is reached.
-// It polls the Sidecar status every 2 seconds and checks for the Ready condition.
+// It watches the Sidecar status, falling back to polling, and checks for the Ready condition.
 //
 // Parameters:
 //   - t: The testing context.
@@ -55,26 +56,30 @@ func ListSidecarsE(t testing.TestingT, options *k8s.KubectlOptions, namespace st
 //   - timeout: The maximum duration to wait for the resource to become Ready.
 //
 // WaitForSidecarReady waits for the resource condition to be satisfied.
-func WaitForSidecarReady(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) {
-	err := WaitForSidecarReadyE(t, options, name, namespace, timeout)
+func WaitForSidecarReady(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) {
+	err := WaitForSidecarReadyE(t, options, name, namespace, timeout, opts...)
 	require.NoError(t, err, "Sidecar %s/%s did not become Ready", namespace, name)
 }
 
 // WaitForSidecarReadyE waits for the resource condition to be satisfied.
-func WaitForSidecarReadyE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) error {
-	options = k8s.NewKubectlOptions("", "", namespace)
+func WaitForSidecarReadyE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) error {
 	istioClient := NewClient(t, options)
 
-	ctx := context.Background()
-	return wait.PollUntilContextTimeout(ctx, 2*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
-		var sidecar *istionetworkingv1alpha3.Sidecar
-		sidecar, err := istioClient.NetworkingV1alpha3().Sidecars(namespace).Get(ctx, name, v1meta.GetOptions{})
-		if err != nil {
-			return false, nil
-		}
-		if sidecar.Status.Conditions != nil {
-			return istioConditionReady(t, &sidecar.Status), nil
-		}
-		return false, nil
-	})
+	_, err := wait.Waiter[*istionetworkingv1alpha3.Sidecar]{
+		Kind:      "Sidecar",
+		Name:      name,
+		Namespace: namespace,
+		Timeout:   timeout,
+		Get: func(ctx context.Context) (*istionetworkingv1alpha3.Sidecar, error) {
+			return istioClient.NetworkingV1alpha3().Sidecars(namespace).Get(ctx, name, v1meta.GetOptions{})
+		},
+		Watch: func(ctx context.Context) (watch.Interface, error) {
+			return istioClient.NetworkingV1alpha3().Sidecars(namespace).Watch(ctx, v1meta.SingleObject(v1meta.ObjectMeta{Name: name}))
+		},
+		Ready: func(sidecar *istionetworkingv1alpha3.Sidecar) bool {
+			return sidecar.Status.Conditions != nil && istioConditionReady(t, &sidecar.Status)
+		},
+		Events: wait.KubeEvents(t, options),
+	}.Wait(t, opts...)
+	return err
 }
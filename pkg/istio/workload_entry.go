@@ -6,11 +6,12 @@ import (
 
 	"github.com/gruntwork-io/terratest/modules/testing"
 
+	"github.com/davidcollom/terratest-utils/pkg/wait"
 	"github.com/gruntwork-io/terratest/modules/k8s"
 	"github.com/stretchr/testify/require"
 	istionetworkingv1alpha3 "istio.io/client-go/pkg/apis/networking/v1alpha3"
 	v1meta "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
 )
 
 // ListWorkloadEntries retrieves all Istio WorkloadEntry resources in the specified namespace using the provided KubectlOptions.
@@ -45,7 +46,7 @@ func ListWorkloadEntriesE(t testing.TestingT, options *k8s.KubectlOptions, names
 }
 
 // WaitForWorkloadEntryReady waits until the specified WorkloadEntry in the given namespace is Ready or the timeout is reached.
-// It polls the WorkloadEntry status every 2 seconds and checks for the Ready condition.
+// It watches the WorkloadEntry status, falling back to polling, and checks for the Ready condition.
 //
 // Parameters:
 //   - t: The testing context.
@@ -55,26 +56,30 @@ func ListWorkloadEntriesE(t testing.TestingT, options *k8s.KubectlOptions, names
 //   - timeout: The maximum duration to wait for the resource to become Ready.
 //
 // WaitForWorkloadEntryReady waits for the resource condition to be satisfied.
-func WaitForWorkloadEntryReady(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) {
-	err := WaitForWorkloadEntryReadyE(t, options, name, namespace, timeout)
+func WaitForWorkloadEntryReady(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) {
+	err := WaitForWorkloadEntryReadyE(t, options, name, namespace, timeout, opts...)
 	require.NoError(t, err, "WorkloadEntry %s/%s did not become Ready", namespace, name)
 }
 
 // WaitForWorkloadEntryReadyE waits for the resource condition to be satisfied.
-func WaitForWorkloadEntryReadyE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) error {
-	options = k8s.NewKubectlOptions("", "", namespace)
+func WaitForWorkloadEntryReadyE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) error {
 	istioClient := NewClient(t, options)
 
-	ctx := context.Background()
-	return wait.PollUntilContextTimeout(ctx, 2*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
-		var workloadEntry *istionetworkingv1alpha3.WorkloadEntry
-		workloadEntry, err := istioClient.NetworkingV1alpha3().WorkloadEntries(namespace).Get(ctx, name, v1meta.GetOptions{})
-		if err != nil {
-			return false, nil
-		}
-		if workloadEntry.Status.Conditions != nil {
-			return istioConditionReady(t, &workloadEntry.Status), nil
-		}
-		return false, nil
-	})
+	_, err := wait.Waiter[*istionetworkingv1alpha3.WorkloadEntry]{
+		Kind:      "WorkloadEntry",
+		Name:      name,
+		Namespace: namespace,
+		Timeout:   timeout,
+		Get: func(ctx context.Context) (*istionetworkingv1alpha3.WorkloadEntry, error) {
+			return istioClient.NetworkingV1alpha3().WorkloadEntries(namespace).Get(ctx, name, v1meta.GetOptions{})
+		},
+		Watch: func(ctx context.Context) (watch.Interface, error) {
+			return istioClient.NetworkingV1alpha3().WorkloadEntries(namespace).Watch(ctx, v1meta.SingleObject(v1meta.ObjectMeta{Name: name}))
+		},
+		Ready: func(workloadEntry *istionetworkingv1alpha3.WorkloadEntry) bool {
+			return workloadEntry.Status.Conditions != nil && istioConditionReady(t, &workloadEntry.Status)
+		},
+		Events: wait.KubeEvents(t, options),
+	}.Wait(t, opts...)
+	return err
 }
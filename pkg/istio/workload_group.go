@@ -6,11 +6,12 @@ import (
 
 	"github.com/gruntwork-io/terratest/modules/testing"
 
+	"github.com/davidcollom/terratest-utils/pkg/wait"
 	"github.com/gruntwork-io/terratest/modules/k8s"
 	"github.com/stretchr/testify/require"
 	istionetworkingv1alpha3 "istio.io/client-go/pkg/apis/networking/v1alpha3"
 	v1meta "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
 )
 
 // ListWorkloadGroups retrieves all Istio WorkloadGroup resources in the specified namespace using the provided KubectlOptions.
@@ -45,7 +46,7 @@ func ListWorkloadGroupsE(t testing.TestingT, options *k8s.KubectlOptions, namesp
 }
 
 // WaitForWorkloadGroupReady waits until the specified WorkloadGroup in the given namespace is Ready or the timeout is reached.
-// It polls the WorkloadGroup status every 2 seconds and checks for the Ready condition.
+// It watches the WorkloadGroup status, falling back to polling, and checks for the Ready condition.
 //
 // Parameters:
 //   - t: The testing context.
@@ -55,26 +56,30 @@ func ListWorkloadGroupsE(t testing.TestingT, options *k8s.KubectlOptions, namesp
 //   - timeout: The maximum duration to wait for the resource to become Ready.
 //
 // WaitForWorkloadGroupReady waits for the resource condition to be satisfied.
-func WaitForWorkloadGroupReady(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) {
-	err := WaitForWorkloadGroupReadyE(t, options, name, namespace, timeout)
+func WaitForWorkloadGroupReady(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) {
+	err := WaitForWorkloadGroupReadyE(t, options, name, namespace, timeout, opts...)
 	require.NoError(t, err, "WorkloadGroup %s/%s did not become Ready", namespace, name)
 }
 
 // WaitForWorkloadGroupReadyE waits for the resource condition to be satisfied.
-func WaitForWorkloadGroupReadyE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) error {
-	options = k8s.NewKubectlOptions("", "", namespace)
+func WaitForWorkloadGroupReadyE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) error {
 	istioClient := NewClient(t, options)
 
-	ctx := context.Background()
-	return wait.PollUntilContextTimeout(ctx, 2*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
-		var workloadGroup *istionetworkingv1alpha3.WorkloadGroup
-		workloadGroup, err := istioClient.NetworkingV1alpha3().WorkloadGroups(namespace).Get(ctx, name, v1meta.GetOptions{})
-		if err != nil {
-			return false, nil
-		}
-		if workloadGroup.Status.Conditions != nil {
-			return istioConditionReady(t, &workloadGroup.Status), nil
-		}
-		return false, nil
-	})
+	_, err := wait.Waiter[*istionetworkingv1alpha3.WorkloadGroup]{
+		Kind:      "WorkloadGroup",
+		Name:      name,
+		Namespace: namespace,
+		Timeout:   timeout,
+		Get: func(ctx context.Context) (*istionetworkingv1alpha3.WorkloadGroup, error) {
+			return istioClient.NetworkingV1alpha3().WorkloadGroups(namespace).Get(ctx, name, v1meta.GetOptions{})
+		},
+		Watch: func(ctx context.Context) (watch.Interface, error) {
+			return istioClient.NetworkingV1alpha3().WorkloadGroups(namespace).Watch(ctx, v1meta.SingleObject(v1meta.ObjectMeta{Name: name}))
+		},
+		Ready: func(workloadGroup *istionetworkingv1alpha3.WorkloadGroup) bool {
+			return workloadGroup.Status.Conditions != nil && istioConditionReady(t, &workloadGroup.Status)
+		},
+		Events: wait.KubeEvents(t, options),
+	}.Wait(t, opts...)
+	return err
 }
@@ -0,0 +1,564 @@
+package k8s
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/helm"
+	"github.com/gruntwork-io/terratest/modules/logger"
+	"github.com/gruntwork-io/terratest/modules/testing"
+
+	"github.com/davidcollom/terratest-utils/pkg/utils"
+	"github.com/davidcollom/terratest-utils/pkg/wait"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"sigs.k8s.io/kustomize/api/krusty"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+)
+
+// DefaultFieldManager is the field manager objects are applied with when Applier.FieldManager is not set.
+const DefaultFieldManager = "terratest-utils"
+
+// DefaultCRDEstablishTimeout is how long an Applier waits for the CRDs it applied to be established
+// before applying the rest of the objects.
+const DefaultCRDEstablishTimeout = time.Minute
+
+// ReadyWaitFunc waits for one applied object to be ready. It has the signature shared by the
+// WaitFor*E helpers of every package, so those can be registered directly.
+type ReadyWaitFunc func(t testing.TestingT, options *KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) error
+
+var (
+	readyWaitMu    sync.RWMutex
+	readyWaitFuncs = map[schema.GroupKind]ReadyWaitFunc{
+		{Group: "apps", Kind: "Deployment"}:  WaitForDeploymentReadyE,
+		{Group: "apps", Kind: "DaemonSet"}:   WaitForDaemonSetReadyE,
+		{Group: "apps", Kind: "ReplicaSet"}:  WaitForReplicaSetReadyE,
+		{Group: "apps", Kind: "StatefulSet"}: WaitForStatefulSetRolloutCompleteE,
+		{Group: "batch", Kind: "Job"}:        WaitForJobCompleteE,
+	}
+)
+
+// RegisterReadyWaitFunc makes an Applier wait for applied objects of kind gk with fn instead of
+// WaitForResourceReadyE. The built-in workloads are registered with their helpers in this package;
+// register the helpers of the other packages for the kinds they cover. Registering nil removes the
+// function.
+//
+// Example usage:
+//
+//	k8s.RegisterReadyWaitFunc(schema.GroupKind{Group: "helm.toolkit.fluxcd.io", Kind: "HelmRelease"}, flux.WaitForHelmReleaseReadyE)
+func RegisterReadyWaitFunc(gk schema.GroupKind, fn ReadyWaitFunc) {
+	readyWaitMu.Lock()
+	defer readyWaitMu.Unlock()
+	if fn == nil {
+		delete(readyWaitFuncs, gk)
+		return
+	}
+	readyWaitFuncs[gk] = fn
+}
+
+// readyWaitFuncFor returns the ReadyWaitFunc for objects of gvk.
+func readyWaitFuncFor(gvk schema.GroupVersionKind) ReadyWaitFunc {
+	readyWaitMu.RLock()
+	fn := readyWaitFuncs[gvk.GroupKind()]
+	readyWaitMu.RUnlock()
+	if fn != nil {
+		return fn
+	}
+	return func(t testing.TestingT, options *KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) error {
+		return WaitForResourceReadyE(t, options, gvk, name, namespace, timeout, opts...)
+	}
+}
+
+// Applier applies manifests with server-side apply and deletes the objects it created when the test
+// finishes. The zero value is ready to use; the package-level Apply* functions use it.
+type Applier struct {
+	// FieldManager owns the applied fields. Defaults to DefaultFieldManager.
+	FieldManager string
+	// Force takes ownership of fields managed by other field managers instead of failing on conflicts.
+	Force bool
+	// WaitTimeout, when set, makes the Applier wait for every applied object to be ready under a
+	// shared deadline of WaitTimeout, using the ReadyWaitFunc registered for its kind.
+	WaitTimeout time.Duration
+	// WaitOptions are passed to every wait.
+	WaitOptions []wait.WaitOption
+	// Concurrency is the number of objects waited on at once. Defaults to wait.DefaultConcurrency.
+	Concurrency int
+}
+
+// ApplyManifests applies the manifests at paths with the zero Applier, failing the test on error.
+// See Applier.ApplyManifests.
+func ApplyManifests(t utils.CleanupT, options *KubectlOptions, paths ...string) []*unstructured.Unstructured {
+	return Applier{}.ApplyManifests(t, options, paths...)
+}
+
+// ApplyManifestsE applies the manifests at paths with the zero Applier. See Applier.ApplyManifestsE.
+func ApplyManifestsE(t utils.CleanupT, options *KubectlOptions, paths ...string) ([]*unstructured.Unstructured, error) {
+	return Applier{}.ApplyManifestsE(t, options, paths...)
+}
+
+// ApplyManifestsFS applies the manifests in fsys that match patterns with the zero Applier, failing
+// the test on error. See Applier.ApplyManifestsFS.
+func ApplyManifestsFS(t utils.CleanupT, options *KubectlOptions, fsys fs.FS, patterns ...string) []*unstructured.Unstructured {
+	return Applier{}.ApplyManifestsFS(t, options, fsys, patterns...)
+}
+
+// ApplyManifestsFSE applies the manifests in fsys that match patterns with the zero Applier. See
+// Applier.ApplyManifestsFSE.
+func ApplyManifestsFSE(t utils.CleanupT, options *KubectlOptions, fsys fs.FS, patterns ...string) ([]*unstructured.Unstructured, error) {
+	return Applier{}.ApplyManifestsFSE(t, options, fsys, patterns...)
+}
+
+// ApplyKustomization builds the kustomization in dir and applies the result with the zero Applier,
+// failing the test on error. See Applier.ApplyKustomization.
+func ApplyKustomization(t utils.CleanupT, options *KubectlOptions, dir string) []*unstructured.Unstructured {
+	return Applier{}.ApplyKustomization(t, options, dir)
+}
+
+// ApplyKustomizationE builds the kustomization in dir and applies the result with the zero Applier.
+// See Applier.ApplyKustomizationE.
+func ApplyKustomizationE(t utils.CleanupT, options *KubectlOptions, dir string) ([]*unstructured.Unstructured, error) {
+	return Applier{}.ApplyKustomizationE(t, options, dir)
+}
+
+// ApplyManifests applies the manifests at paths, failing the test on error.
+//
+// Example usage:
+//
+//	k8s.Applier{WaitTimeout: 5 * time.Minute}.ApplyManifests(t, options, "testdata/podinfo.yaml")
+//
+// Parameters:
+//   - t: The testing context; objects created by the apply are deleted when it finishes.
+//   - options: The kubectl options for accessing the Kubernetes cluster.
+//   - paths: YAML or JSON files, or directories whose *.yaml, *.yml and *.json files are applied.
+//
+// Returns:
+//   - []*unstructured.Unstructured: The applied objects as returned by the API server.
+func (a Applier) ApplyManifests(t utils.CleanupT, options *KubectlOptions, paths ...string) []*unstructured.Unstructured {
+	objs, err := a.ApplyManifestsE(t, options, paths...)
+	require.NoError(t, err)
+	return objs
+}
+
+// ApplyManifestsE applies the manifests at paths. Each path is a YAML or JSON file, possibly with
+// several documents, or a directory whose *.yaml, *.yml and *.json files are applied in name order.
+// See Applier.ApplyE for how the objects are applied and cleaned up.
+func (a Applier) ApplyManifestsE(t utils.CleanupT, options *KubectlOptions, paths ...string) ([]*unstructured.Unstructured, error) {
+	var objs []*unstructured.Unstructured
+	for _, path := range paths {
+		files, err := manifestFiles(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			data, err := os.ReadFile(file)
+			if err != nil {
+				return nil, err
+			}
+			decoded, err := decodeManifests(data, file)
+			if err != nil {
+				return nil, err
+			}
+			objs = append(objs, decoded...)
+		}
+	}
+
+	return a.ApplyE(t, options, objs...)
+}
+
+// ApplyManifestsFS applies the manifests in fsys that match patterns, failing the test on error.
+//
+// Example usage:
+//
+//	//go:embed testdata
+//	var manifests embed.FS
+//
+//	k8s.ApplyManifestsFS(t, options, manifests, "testdata/*.yaml")
+//
+// Parameters:
+//   - t: The testing context; objects created by the apply are deleted when it finishes.
+//   - options: The kubectl options for accessing the Kubernetes cluster.
+//   - fsys: The file system holding the manifests, e.g. an embed.FS.
+//   - patterns: fs.Glob patterns of the files to apply; every *.yaml, *.yml and *.json file in the
+//     root of fsys when empty.
+//
+// Returns:
+//   - []*unstructured.Unstructured: The applied objects as returned by the API server.
+func (a Applier) ApplyManifestsFS(t utils.CleanupT, options *KubectlOptions, fsys fs.FS, patterns ...string) []*unstructured.Unstructured {
+	objs, err := a.ApplyManifestsFSE(t, options, fsys, patterns...)
+	require.NoError(t, err)
+	return objs
+}
+
+// ApplyManifestsFSE applies the manifests in fsys that match patterns, in pattern order and then name
+// order. See Applier.ApplyE for how the objects are applied and cleaned up.
+func (a Applier) ApplyManifestsFSE(t utils.CleanupT, options *KubectlOptions, fsys fs.FS, patterns ...string) ([]*unstructured.Unstructured, error) {
+	if len(patterns) == 0 {
+		patterns = []string{"*.yaml", "*.yml", "*.json"}
+	}
+
+	var objs []*unstructured.Unstructured
+	for _, pattern := range patterns {
+		files, err := fs.Glob(fsys, pattern)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			data, err := fs.ReadFile(fsys, file)
+			if err != nil {
+				return nil, err
+			}
+			decoded, err := decodeManifests(data, file)
+			if err != nil {
+				return nil, err
+			}
+			objs = append(objs, decoded...)
+		}
+	}
+
+	return a.ApplyE(t, options, objs...)
+}
+
+// ApplyKustomization builds the kustomization in dir and applies the result, failing the test on error.
+//
+// Example usage:
+//
+//	k8s.ApplyKustomization(t, options, "../deploy/overlays/test")
+//
+// Parameters:
+//   - t: The testing context; objects created by the apply are deleted when it finishes.
+//   - options: The kubectl options for accessing the Kubernetes cluster.
+//   - dir: The directory holding the kustomization.yaml.
+//
+// Returns:
+//   - []*unstructured.Unstructured: The applied objects as returned by the API server.
+func (a Applier) ApplyKustomization(t utils.CleanupT, options *KubectlOptions, dir string) []*unstructured.Unstructured {
+	objs, err := a.ApplyKustomizationE(t, options, dir)
+	require.NoError(t, err)
+	return objs
+}
+
+// ApplyKustomizationE builds the kustomization in dir with the kustomize API, as `kubectl kustomize`
+// does with its default options, and applies the result. See Applier.ApplyE for how the objects are
+// applied and cleaned up.
+func (a Applier) ApplyKustomizationE(t utils.CleanupT, options *KubectlOptions, dir string) ([]*unstructured.Unstructured, error) {
+	resources, err := krusty.MakeKustomizer(krusty.MakeDefaultOptions()).Run(filesys.MakeFsOnDisk(), dir)
+	if err != nil {
+		return nil, fmt.Errorf("building kustomization %s: %w", dir, err)
+	}
+	data, err := resources.AsYaml()
+	if err != nil {
+		return nil, err
+	}
+	objs, err := decodeManifests(data, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.ApplyE(t, options, objs...)
+}
+
+// ApplyHelmChart renders the Helm chart in chartDir as releaseName and applies the result with the
+// zero Applier, failing the test on error. See Applier.ApplyHelmChart.
+func ApplyHelmChart(t utils.CleanupT, options *KubectlOptions, helmOptions *helm.Options, chartDir, releaseName string) []*unstructured.Unstructured {
+	return Applier{}.ApplyHelmChart(t, options, helmOptions, chartDir, releaseName)
+}
+
+// ApplyHelmChartE renders the Helm chart in chartDir as releaseName and applies the result with the
+// zero Applier. See Applier.ApplyHelmChartE.
+func ApplyHelmChartE(t utils.CleanupT, options *KubectlOptions, helmOptions *helm.Options, chartDir, releaseName string) ([]*unstructured.Unstructured, error) {
+	return Applier{}.ApplyHelmChartE(t, options, helmOptions, chartDir, releaseName)
+}
+
+// ApplyHelmChart renders the Helm chart in chartDir as releaseName and applies the result, failing
+// the test on error.
+//
+// Example usage:
+//
+//	k8s.ApplyHelmChart(t, options, &helm.Options{SetValues: map[string]string{"replicaCount": "1"}}, "../charts/podinfo", "podinfo")
+//
+// Parameters:
+//   - t: The testing context; objects created by the apply are deleted when it finishes.
+//   - options: The kubectl options for accessing the Kubernetes cluster.
+//   - helmOptions: The values and flags the chart is rendered with; may be nil.
+//   - chartDir: The directory holding the Chart.yaml.
+//   - releaseName: The release name the chart is rendered as.
+//
+// Returns:
+//   - []*unstructured.Unstructured: The applied objects as returned by the API server.
+func (a Applier) ApplyHelmChart(t utils.CleanupT, options *KubectlOptions, helmOptions *helm.Options, chartDir, releaseName string) []*unstructured.Unstructured {
+	objs, err := a.ApplyHelmChartE(t, options, helmOptions, chartDir, releaseName)
+	require.NoError(t, err)
+	return objs
+}
+
+// ApplyHelmChartE renders the Helm chart in chartDir with `helm template`, through Terratest's helm
+// module, and applies the result; the helm binary must be on the PATH. The chart is rendered for
+// options.Namespace unless helmOptions sets its own KubectlOptions. Test hooks are skipped and other
+// hooks are applied as ordinary objects. No Helm release is recorded, so the chart is not listed by
+// `helm list` and is cleaned up like any other manifest. See Applier.ApplyE for how the objects are
+// applied and cleaned up.
+func (a Applier) ApplyHelmChartE(t utils.CleanupT, options *KubectlOptions, helmOptions *helm.Options, chartDir, releaseName string) ([]*unstructured.Unstructured, error) {
+	var renderOptions helm.Options
+	if helmOptions != nil {
+		renderOptions = *helmOptions
+	}
+	if renderOptions.KubectlOptions == nil {
+		renderOptions.KubectlOptions = options
+	}
+	rendered, err := helm.RenderTemplateE(t, &renderOptions, chartDir, releaseName, nil, "--skip-tests")
+	if err != nil {
+		return nil, fmt.Errorf("rendering Helm chart %s: %w", chartDir, err)
+	}
+	objs, err := decodeManifests([]byte(rendered), chartDir)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.ApplyE(t, options, objs...)
+}
+
+// Apply applies objs, failing the test on error. See Applier.ApplyE.
+func (a Applier) Apply(t utils.CleanupT, options *KubectlOptions, objs ...*unstructured.Unstructured) []*unstructured.Unstructured {
+	applied, err := a.ApplyE(t, options, objs...)
+	require.NoError(t, err)
+	return applied
+}
+
+// ApplyE applies objs with server-side apply. Namespaces are applied first and CustomResourceDefinitions
+// next, waiting for the CRDs to be established, then the rest of the objects in the order given.
+// Namespaced objects without a namespace are applied to options.Namespace, or "default".
+//
+// Objects that did not exist before the apply are deleted in reverse order when the test finishes,
+// including when the apply fails part way; objects that already existed are left in place. When
+// WaitTimeout is set, ApplyE then waits for every applied object to be ready and returns the
+// wait.Report's error if some are not.
+func (a Applier) ApplyE(t utils.CleanupT, options *KubectlOptions, objs ...*unstructured.Unstructured) ([]*unstructured.Unstructured, error) {
+	client, err := NewDynamicClient(t, options)
+	if err != nil {
+		return nil, err
+	}
+	mapper, err := NewRESTMapper(t, options)
+	if err != nil {
+		return nil, err
+	}
+
+	fieldManager := a.FieldManager
+	if fieldManager == "" {
+		fieldManager = DefaultFieldManager
+	}
+	namespace := options.Namespace
+	if namespace == "" {
+		namespace = "default"
+	}
+
+	var created []*unstructured.Unstructured
+	t.Cleanup(func() {
+		for i := len(created) - 1; i >= 0; i-- {
+			if err := deleteApplied(t, options, created[i]); err != nil {
+				t.Errorf("deleting applied %s: %v", objectRef(created[i]), err)
+			}
+		}
+	})
+
+	ordered := applyOrder(objs)
+	applied := make([]*unstructured.Unstructured, 0, len(ordered))
+	var crds []*unstructured.Unstructured
+	for i, obj := range ordered {
+		if len(crds) > 0 && !isCRD(obj) {
+			if err := a.waitForCRDs(t, options, crds); err != nil {
+				return applied, err
+			}
+			crds = nil
+		}
+
+		obj = obj.DeepCopy()
+		mapping, err := restMapping(mapper, obj.GroupVersionKind())
+		if err != nil {
+			return applied, fmt.Errorf("applying object %d of %d: %w", i+1, len(ordered), err)
+		}
+		resource := client.Resource(mapping.Resource)
+		var ns string
+		if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+			if obj.GetNamespace() == "" {
+				obj.SetNamespace(namespace)
+			}
+			ns = obj.GetNamespace()
+		} else {
+			obj.SetNamespace("")
+		}
+
+		_, err = resource.Namespace(ns).Get(context.Background(), obj.GetName(), metav1.GetOptions{})
+		exists := err == nil
+		if err != nil && !apierrors.IsNotFound(err) {
+			return applied, fmt.Errorf("applying %s: %w", objectRef(obj), err)
+		}
+
+		result, err := resource.Namespace(ns).Apply(context.Background(), obj.GetName(), obj, metav1.ApplyOptions{FieldManager: fieldManager, Force: a.Force})
+		if err != nil {
+			return applied, fmt.Errorf("applying %s: %w", objectRef(obj), err)
+		}
+		logger.Default.Logf(t, "Applied %s", objectRef(obj))
+		if result.GetObjectKind().GroupVersionKind().Empty() {
+			result.SetGroupVersionKind(obj.GroupVersionKind())
+		}
+		if !exists {
+			created = append(created, result)
+		}
+		applied = append(applied, result)
+		if isCRD(result) {
+			crds = append(crds, result)
+		}
+	}
+
+	if a.WaitTimeout == 0 {
+		return applied, nil
+	}
+	targets := make([]wait.Target, len(applied))
+	for i, obj := range applied {
+		targets[i] = wait.For(obj.GetKind(), obj.GetName(), obj.GetNamespace(), readyWaitFuncFor(obj.GroupVersionKind()))
+	}
+	report, err := wait.Group{Concurrency: a.Concurrency, Options: a.WaitOptions}.WaitForAllE(t, options, a.WaitTimeout, targets...)
+	if err != nil {
+		return applied, err
+	}
+	return applied, report.Err()
+}
+
+// waitForCRDs waits for crds to be established so that objects of their kinds can be applied.
+func (a Applier) waitForCRDs(t testing.TestingT, options *KubectlOptions, crds []*unstructured.Unstructured) error {
+	timeout := a.WaitTimeout
+	if timeout == 0 {
+		timeout = DefaultCRDEstablishTimeout
+	}
+	var errs []error
+	for _, crd := range crds {
+		errs = append(errs, WaitForResourceReadyE(t, options, crd.GroupVersionKind(), crd.GetName(), "", timeout, a.WaitOptions...))
+	}
+	return errors.Join(errs...)
+}
+
+// deleteApplied deletes obj, tolerating it already being gone.
+func deleteApplied(t testing.TestingT, options *KubectlOptions, obj *unstructured.Unstructured) error {
+	resource, err := resourceInterface(t, options, obj.GroupVersionKind(), obj.GetNamespace())
+	if err != nil {
+		return err
+	}
+	propagation := metav1.DeletePropagationBackground
+	err = resource.Delete(context.Background(), obj.GetName(), metav1.DeleteOptions{PropagationPolicy: &propagation})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err == nil {
+		logger.Default.Logf(t, "Deleted %s", objectRef(obj))
+	}
+	return err
+}
+
+// applyOrder returns objs with Namespaces first and CustomResourceDefinitions second, otherwise
+// keeping the order given.
+func applyOrder(objs []*unstructured.Unstructured) []*unstructured.Unstructured {
+	rank := func(obj *unstructured.Unstructured) int {
+		switch {
+		case obj.GroupVersionKind().GroupKind() == (schema.GroupKind{Kind: "Namespace"}):
+			return 0
+		case isCRD(obj):
+			return 1
+		default:
+			return 2
+		}
+	}
+	ordered := append([]*unstructured.Unstructured(nil), objs...)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return rank(ordered[i]) < rank(ordered[j])
+	})
+	return ordered
+}
+
+// isCRD reports whether obj is a CustomResourceDefinition.
+func isCRD(obj *unstructured.Unstructured) bool {
+	return obj.GroupVersionKind().GroupKind() == schema.GroupKind{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition"}
+}
+
+// objectRef formats obj as "Kind namespace/name", or "Kind name" when it is cluster-scoped.
+func objectRef(obj *unstructured.Unstructured) string {
+	if obj.GetNamespace() == "" {
+		return obj.GetKind() + " " + obj.GetName()
+	}
+	return obj.GetKind() + " " + obj.GetNamespace() + "/" + obj.GetName()
+}
+
+// manifestFiles returns path when it is a file, or the YAML and JSON files directly in it, sorted by
+// name, when it is a directory.
+func manifestFiles(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, entry := range entries {
+		switch filepath.Ext(entry.Name()) {
+		case ".yaml", ".yml", ".json":
+			if !entry.IsDir() {
+				files = append(files, filepath.Join(path, entry.Name()))
+			}
+		}
+	}
+	return files, nil
+}
+
+// decodeManifests decodes every YAML or JSON document in data, skipping empty documents and expanding
+// List kinds into their items. source names data in errors.
+func decodeManifests(data []byte, source string) ([]*unstructured.Unstructured, error) {
+	decoder := yaml.NewYAMLOrJSONDecoder(bytes.NewReader(data), 4096)
+	var objs []*unstructured.Unstructured
+	for {
+		obj := &unstructured.Unstructured{}
+		if err := decoder.Decode(&obj.Object); err != nil {
+			if errors.Is(err, io.EOF) {
+				return objs, nil
+			}
+			return nil, fmt.Errorf("decoding %s: %w", source, err)
+		}
+		if len(obj.Object) == 0 {
+			continue
+		}
+		if obj.GetKind() == "" || obj.GetAPIVersion() == "" {
+			return nil, fmt.Errorf("decoding %s: object %q has no apiVersion or kind", source, obj.GetName())
+		}
+
+		if !obj.IsList() {
+			objs = append(objs, obj)
+			continue
+		}
+		err := obj.EachListItem(func(item runtime.Object) error {
+			objs = append(objs, item.(*unstructured.Unstructured))
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("decoding %s: %w", source, err)
+		}
+	}
+}
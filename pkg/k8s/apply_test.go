@@ -0,0 +1,209 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/davidcollom/terratest-utils/pkg/wait"
+	"github.com/davidcollom/terratest-utils/pkg/waittest"
+	"github.com/gruntwork-io/terratest/modules/helm"
+	"github.com/gruntwork-io/terratest/modules/k8s"
+	terratesting "github.com/gruntwork-io/terratest/modules/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+var (
+	configMapGVR = schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}
+	namespaceGVR = schema.GroupVersionResource{Version: "v1", Resource: "namespaces"}
+)
+
+// newApplyTestClient returns a fake dynamic client seeded with objs whose RESTMapper knows Namespaces
+// and ConfigMaps.
+func newApplyTestClient(t *testing.T, objs ...*corev1.ConfigMap) dynamic.Interface {
+	seed := make([]runtime.Object, len(objs))
+	for i, obj := range objs {
+		seed[i] = obj
+	}
+	client := NewTestDynamicClient(t, seed...)
+
+	mapper := meta.NewDefaultRESTMapper(nil)
+	mapper.Add(corev1.SchemeGroupVersion.WithKind("Namespace"), meta.RESTScopeRoot)
+	mapper.Add(corev1.SchemeGroupVersion.WithKind("ConfigMap"), meta.RESTScopeNamespace)
+	NewRESTMapper = func(t terratesting.TestingT, options *k8s.KubectlOptions) (meta.RESTMapper, error) {
+		return mapper, nil
+	}
+	return client
+}
+
+func TestDecodeManifests(t *testing.T) {
+	objs, err := decodeManifests([]byte(`
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: app
+---
+---
+apiVersion: v1
+kind: List
+items:
+- apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: one
+- apiVersion: v1
+  kind: ConfigMap
+  metadata:
+    name: two
+`), "test.yaml")
+	require.NoError(t, err)
+	require.Len(t, objs, 3)
+	assert.Equal(t, []string{"app", "one", "two"}, []string{objs[0].GetName(), objs[1].GetName(), objs[2].GetName()})
+
+	_, err = decodeManifests([]byte(`{"metadata": {"name": "untyped"}}`), "test.json")
+	assert.ErrorContains(t, err, `object "untyped" has no apiVersion or kind`)
+}
+
+func TestApplyManifestsFS(t *testing.T) {
+	manifests := fstest.MapFS{
+		"config.yaml": {Data: []byte(`
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+  namespace: app
+data:
+  level: debug
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: existing
+data:
+  owner: test
+`)},
+		"namespace.yaml": {Data: []byte(`
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: app
+`)},
+	}
+	existing := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "existing", Namespace: "default"}}
+
+	var client dynamic.Interface
+	t.Run("apply", func(t *testing.T) {
+		client = newApplyTestClient(t, existing)
+
+		objs := ApplyManifestsFS(t, &KubectlOptions{}, manifests)
+		require.Len(t, objs, 3)
+		assert.Equal(t, "Namespace app", objectRef(objs[0]), "namespaces are applied first")
+		assert.Equal(t, "ConfigMap app/settings", objectRef(objs[1]))
+		assert.Equal(t, "ConfigMap default/existing", objectRef(objs[2]), "namespaced objects default to the options namespace")
+
+		settings, err := client.Resource(configMapGVR).Namespace("app").Get(context.Background(), "settings", metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]any{"level": "debug"}, settings.Object["data"])
+	})
+
+	_, err := client.Resource(configMapGVR).Namespace("app").Get(context.Background(), "settings", metav1.GetOptions{})
+	assert.True(t, apierrors.IsNotFound(err), "created objects are deleted when the test finishes")
+	_, err = client.Resource(namespaceGVR).Get(context.Background(), "app", metav1.GetOptions{})
+	assert.True(t, apierrors.IsNotFound(err), "created objects are deleted when the test finishes")
+	_, err = client.Resource(configMapGVR).Namespace("default").Get(context.Background(), "existing", metav1.GetOptions{})
+	assert.NoError(t, err, "objects that existed before the apply are kept")
+}
+
+func TestApplierWaitsForReadiness(t *testing.T) {
+	newApplyTestClient(t)
+	configMap := schema.GroupKind{Kind: "ConfigMap"}
+	t.Cleanup(func() { RegisterReadyWaitFunc(configMap, nil) })
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "config.yaml"), []byte(`
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: settings
+`), 0o600))
+	applier := Applier{WaitTimeout: time.Minute, WaitOptions: []wait.WaitOption{wait.WithClock(waittest.NewClock())}}
+
+	_, err := applier.ApplyManifestsE(t, &KubectlOptions{Namespace: "app"}, dir)
+	require.NoError(t, err, "kstatus treats ConfigMaps as ready once they exist")
+
+	RegisterReadyWaitFunc(configMap, func(t terratesting.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) error {
+		return errors.New(namespace + "/" + name + " is not ready")
+	})
+	_, err = applier.ApplyManifestsE(t, &KubectlOptions{Namespace: "app"}, dir)
+	assert.ErrorContains(t, err, "app/settings is not ready")
+}
+
+func TestApplyKustomization(t *testing.T) {
+	client := newApplyTestClient(t)
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "kustomization.yaml"), []byte(`
+namespace: app
+configMapGenerator:
+- name: settings
+  options:
+    disableNameSuffixHash: true
+  literals:
+  - level=debug
+`), 0o600))
+
+	objs := ApplyKustomization(t, &KubectlOptions{}, dir)
+	require.Len(t, objs, 1)
+	assert.Equal(t, "ConfigMap app/settings", objectRef(objs[0]))
+
+	_, err := client.Resource(configMapGVR).Namespace("app").Get(context.Background(), "settings", metav1.GetOptions{})
+	assert.NoError(t, err)
+}
+
+func TestApplyHelmChart(t *testing.T) {
+	client := newApplyTestClient(t)
+
+	// A stand-in for helm that records its arguments and renders a single ConfigMap.
+	bin := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(bin, "helm"), []byte(`#!/bin/sh
+echo "$@" > "$(dirname "$0")/args"
+cat <<'YAML'
+---
+# Source: podinfo/templates/configmap.yaml
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: podinfo
+  namespace: app
+data:
+  level: debug
+YAML
+`), 0o700))
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	chart := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(chart, "Chart.yaml"), []byte("apiVersion: v2\nname: podinfo\nversion: 0.1.0\n"), 0o600))
+
+	objs := ApplyHelmChart(t, &KubectlOptions{Namespace: "app"}, &helm.Options{SetValues: map[string]string{"level": "debug"}}, chart, "podinfo")
+	require.Len(t, objs, 1)
+	assert.Equal(t, "ConfigMap app/podinfo", objectRef(objs[0]))
+
+	args, err := os.ReadFile(filepath.Join(bin, "args"))
+	require.NoError(t, err)
+	assert.Equal(t, "template --namespace app --set level=debug --skip-tests podinfo "+chart+"\n", string(args))
+
+	_, err = client.Resource(configMapGVR).Namespace("app").Get(context.Background(), "podinfo", metav1.GetOptions{})
+	assert.NoError(t, err)
+}
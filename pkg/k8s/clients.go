@@ -1,20 +1,34 @@
 package k8s
 
 import (
-	"github.com/davidcollom/terratest-utils/pkg/utils"
-	terrak8s "github.com/gruntwork-io/terratest/modules/k8s"
+	"github.com/davidcollom/terratest-utils/pkg/clients"
 	"github.com/gruntwork-io/terratest/modules/testing"
 
 	apixclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
 )
 
-// NewClient gets a new standard kubernetes clientset
-var NewClient = terrak8s.GetKubernetesClientFromOptionsE
+// NewClient gets the standard kubernetes clientset for the cluster described by options.
+// The clientset is cached per cluster by pkg/clients.
+var NewClient = newClient
+
+func newClient(t testing.TestingT, options *KubectlOptions) (kubernetes.Interface, error) {
+	return clients.Get(t, options, "kubernetes", func(cfg *rest.Config) (kubernetes.Interface, error) {
+		return kubernetes.NewForConfig(cfg)
+	})
+}
 
 // NewAPIXClient creates a new API Extensions (apix) clientset using the provided
 // terrak8s.KubectlOptions. It returns an apixclientset.Interface for interacting
 // with Kubernetes API extensions resources, or an error if the client could not
 // be created. The testing.T object is used for test context and error reporting.
+// The clientset is cached per cluster by pkg/clients.
 //
 // Parameters:
 //   - t: The testing context, used for helper annotation and error reporting.
@@ -33,14 +47,33 @@ var NewClient = terrak8s.GetKubernetesClientFromOptionsE
 var NewAPIXClient = newAPIXClient
 
 func newAPIXClient(t testing.TestingT, options *KubectlOptions) (apixclientset.Interface, error) {
-	restConfig, err := utils.GetRestConfigE(t, options)
-	if err != nil {
-		return nil, err
-	}
-
-	client, err := apixclientset.NewForConfig(restConfig)
-	if err != nil {
-		return nil, err
-	}
-	return client, nil
+	return clients.Get(t, options, "apiextensions", func(cfg *rest.Config) (apixclientset.Interface, error) {
+		return apixclientset.NewForConfig(cfg)
+	})
+}
+
+// NewDynamicClient gets a dynamic client for the cluster described by options, for working with
+// resources of any kind as unstructured objects. The client is cached per cluster by pkg/clients.
+var NewDynamicClient = newDynamicClient
+
+func newDynamicClient(t testing.TestingT, options *KubectlOptions) (dynamic.Interface, error) {
+	return clients.Get(t, options, "dynamic", func(cfg *rest.Config) (dynamic.Interface, error) {
+		return dynamic.NewForConfig(cfg)
+	})
+}
+
+// NewRESTMapper gets a RESTMapper that resolves kinds to resources through the discovery API of the
+// cluster described by options. Discovery results are cached until the mapper is Reset, which the
+// helpers in this package do when a kind is not found so that CRDs installed during a test are
+// picked up. The mapper is cached per cluster by pkg/clients.
+var NewRESTMapper = newRESTMapper
+
+func newRESTMapper(t testing.TestingT, options *KubectlOptions) (meta.RESTMapper, error) {
+	return clients.Get(t, options, "restmapper", func(cfg *rest.Config) (meta.RESTMapper, error) {
+		client, err := discovery.NewDiscoveryClientForConfig(cfg)
+		if err != nil {
+			return nil, err
+		}
+		return restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(client)), nil
+	})
 }
@@ -0,0 +1,286 @@
+package k8s
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/testing"
+
+	"github.com/davidcollom/terratest-utils/pkg/wait"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// DefaultClusterDNSName is the name AssertClusterHealthy resolves from a probe Pod to check cluster DNS.
+const DefaultClusterDNSName = "kubernetes.default.svc.cluster.local"
+
+// nodePressureConditions are the node conditions that must be False on a healthy node.
+var nodePressureConditions = []corev1.NodeConditionType{
+	corev1.NodeMemoryPressure,
+	corev1.NodeDiskPressure,
+	corev1.NodePIDPressure,
+}
+
+// getReadyz fetches /readyz?verbose from the API server. It is a variable so that tests, whose fake
+// clientsets cannot make raw requests, can replace it.
+var getReadyz = func(ctx context.Context, client kubernetes.Interface) ([]byte, error) {
+	rc := client.Discovery().RESTClient()
+	if rc == nil {
+		return nil, errors.New("client cannot make raw requests")
+	}
+	return rc.Get().AbsPath("/readyz").Param("verbose", "").DoRaw(ctx)
+}
+
+// WaitForNodesReady waits until at least minNodes nodes are Ready or the timeout is reached, failing the test otherwise.
+//
+// Example usage:
+//
+//	k8s.WaitForNodesReady(t, options, 3, 10*time.Minute)
+//	k8s.AssertClusterHealthy(t, options)
+//
+// Parameters:
+//   - t: The testing context.
+//   - options: The kubectl options for accessing the Kubernetes cluster.
+//   - minNodes: The number of nodes that must be Ready.
+//   - timeout: The maximum duration to wait.
+func WaitForNodesReady(t testing.TestingT, options *KubectlOptions, minNodes int, timeout time.Duration, opts ...wait.WaitOption) {
+	err := WaitForNodesReadyE(t, options, minNodes, timeout, opts...)
+	require.NoError(t, err, "Fewer than %d nodes were Ready in time", minNodes)
+}
+
+// WaitForNodesReadyE waits until at least minNodes nodes have a Ready=True condition, e.g. while a
+// freshly provisioned cluster or node pool joins. On timeout the error describes the nodes that are
+// not Ready.
+func WaitForNodesReadyE(t testing.TestingT, options *KubectlOptions, minNodes int, timeout time.Duration, opts ...wait.WaitOption) error {
+	client, err := NewClient(t, options)
+	if err != nil {
+		return err
+	}
+
+	nodes, err := wait.Waiter[[]corev1.Node]{
+		Kind:    "Nodes",
+		Name:    fmt.Sprintf("(%d Ready)", minNodes),
+		Timeout: timeout,
+		Get: func(ctx context.Context) ([]corev1.Node, error) {
+			list, err := client.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+			if err != nil {
+				return nil, err
+			}
+			return list.Items, nil
+		},
+		Ready: func(nodes []corev1.Node) bool {
+			ready := 0
+			for i := range nodes {
+				if IsNodeReady(&nodes[i]) {
+					ready++
+				}
+			}
+			return ready >= minNodes
+		},
+	}.Wait(t, opts...)
+	if !wait.IsTimeout(err) {
+		return err
+	}
+
+	var problems []string
+	for i := range nodes {
+		if problem := nodeProblem(&nodes[i]); problem != nil {
+			problems = append(problems, problem.Error())
+		}
+	}
+	if len(problems) > 0 {
+		err = fmt.Errorf("%w\n  nodes not ready:\n    %s", err, strings.Join(problems, "\n    "))
+	}
+	return err
+}
+
+// IsNodeReady reports whether the node has a Ready=True condition.
+//
+// Parameters:
+//   - node: A pointer to the corev1.Node object to check.
+//
+// Returns:
+//   - bool: True if the node is Ready, false otherwise.
+func IsNodeReady(node *corev1.Node) bool {
+	for _, cond := range node.Status.Conditions {
+		if cond.Type == corev1.NodeReady {
+			return cond.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// AssertClusterHealthy checks the health of the cluster, failing the test once for every check that
+// fails, so that a single run reports everything that is wrong. See CheckClusterHealthE.
+//
+// Example usage:
+//
+//	if !k8s.AssertClusterHealthy(t, options) {
+//	    t.FailNow()
+//	}
+//
+// Parameters:
+//   - t: The testing context.
+//   - options: The kubectl options for accessing the Kubernetes cluster.
+//
+// Returns:
+//   - bool: True if every check passed.
+func AssertClusterHealthy(t testing.TestingT, options *KubectlOptions, opts ...wait.WaitOption) bool {
+	problems := CheckClusterHealthE(t, options, opts...)
+	for _, problem := range problems {
+		assert.Fail(t, "Cluster is not healthy", problem.Error())
+	}
+	return len(problems) == 0
+}
+
+// CheckClusterHealthE runs a set of cluster health checks, using the clientset from NewClient, and
+// returns one error per failed check, or nil when the cluster is healthy:
+//
+//   - every node is Ready and reports no MemoryPressure, DiskPressure or PIDPressure;
+//   - every Deployment, DaemonSet and StatefulSet in kube-system has all of its replicas available;
+//   - every check listed by the API server's /readyz?verbose endpoint passes;
+//   - DefaultClusterDNSName resolves from a probe Pod run with RunEphemeralProbePodE in the
+//     namespace of options, or "default", which exercises CoreDNS and the network path to it.
+//
+// opts are passed to the probe Pod's wait.
+func CheckClusterHealthE(t testing.TestingT, options *KubectlOptions, opts ...wait.WaitOption) []error {
+	client, err := NewClient(t, options)
+	if err != nil {
+		return []error{err}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	var problems []error
+	problems = append(problems, checkNodes(ctx, client)...)
+	problems = append(problems, checkSystemWorkloads(ctx, client)...)
+	problems = append(problems, checkReadyz(ctx, client)...)
+	if err := checkClusterDNS(t, options, opts...); err != nil {
+		problems = append(problems, err)
+	}
+	return problems
+}
+
+// checkNodes reports the nodes that are not Ready or are under resource pressure.
+func checkNodes(ctx context.Context, client kubernetes.Interface) []error {
+	nodes, err := client.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return []error{fmt.Errorf("listing nodes: %w", err)}
+	}
+	if len(nodes.Items) == 0 {
+		return []error{errors.New("cluster has no nodes")}
+	}
+	var problems []error
+	for i := range nodes.Items {
+		if problem := nodeProblem(&nodes.Items[i]); problem != nil {
+			problems = append(problems, problem)
+		}
+	}
+	return problems
+}
+
+// nodeProblem describes why node is not healthy, or returns nil.
+func nodeProblem(node *corev1.Node) error {
+	var issues []string
+	for _, cond := range node.Status.Conditions {
+		unhealthy := cond.Type == corev1.NodeReady && cond.Status != corev1.ConditionTrue
+		for _, pressure := range nodePressureConditions {
+			unhealthy = unhealthy || (cond.Type == pressure && cond.Status == corev1.ConditionTrue)
+		}
+		if unhealthy {
+			issues = append(issues, wait.Condition{Type: string(cond.Type), Status: string(cond.Status), Reason: cond.Reason, Message: cond.Message}.String())
+		}
+	}
+	if !IsNodeReady(node) && len(issues) == 0 {
+		issues = append(issues, "no Ready condition")
+	}
+	if len(issues) == 0 {
+		return nil
+	}
+	return fmt.Errorf("node %s: %s", node.Name, strings.Join(issues, "; "))
+}
+
+// checkSystemWorkloads reports the workloads in kube-system that do not have all of their replicas
+// available.
+func checkSystemWorkloads(ctx context.Context, client kubernetes.Interface) []error {
+	const namespace = metav1.NamespaceSystem
+	var problems []error
+
+	deployments, err := client.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		problems = append(problems, fmt.Errorf("listing Deployments in %s: %w", namespace, err))
+	} else {
+		for _, d := range deployments.Items {
+			if desired := replicas(d.Spec.Replicas); d.Status.AvailableReplicas < desired {
+				problems = append(problems, fmt.Errorf("Deployment %s/%s: %d of %d replicas available", namespace, d.Name, d.Status.AvailableReplicas, desired))
+			}
+		}
+	}
+
+	daemonSets, err := client.AppsV1().DaemonSets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		problems = append(problems, fmt.Errorf("listing DaemonSets in %s: %w", namespace, err))
+	} else {
+		for _, ds := range daemonSets.Items {
+			if ds.Status.NumberAvailable < ds.Status.DesiredNumberScheduled {
+				problems = append(problems, fmt.Errorf("DaemonSet %s/%s: %d of %d pods available", namespace, ds.Name, ds.Status.NumberAvailable, ds.Status.DesiredNumberScheduled))
+			}
+		}
+	}
+
+	statefulSets, err := client.AppsV1().StatefulSets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		problems = append(problems, fmt.Errorf("listing StatefulSets in %s: %w", namespace, err))
+	} else {
+		for _, sts := range statefulSets.Items {
+			if desired := replicas(sts.Spec.Replicas); sts.Status.AvailableReplicas < desired {
+				problems = append(problems, fmt.Errorf("StatefulSet %s/%s: %d of %d replicas available", namespace, sts.Name, sts.Status.AvailableReplicas, desired))
+			}
+		}
+	}
+
+	return problems
+}
+
+// checkReadyz reports every check the API server's /readyz?verbose endpoint lists as failed, e.g.
+// "[-]etcd failed: reason withheld". The endpoint answers 500 when a check fails, so its body is
+// read whether or not the request succeeded.
+func checkReadyz(ctx context.Context, client kubernetes.Interface) []error {
+	body, err := getReadyz(ctx, client)
+
+	var problems []error
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	for scanner.Scan() {
+		if check, ok := strings.CutPrefix(strings.TrimSpace(scanner.Text()), "[-]"); ok {
+			problems = append(problems, fmt.Errorf("API server readyz check %s", check))
+		}
+	}
+	if err != nil && len(problems) == 0 {
+		problems = append(problems, fmt.Errorf("API server readyz: %w", err))
+	}
+	return problems
+}
+
+// checkClusterDNS resolves DefaultClusterDNSName from a probe Pod.
+func checkClusterDNS(t testing.TestingT, options *KubectlOptions, opts ...wait.WaitOption) error {
+	result, err := RunEphemeralProbePodE(t, options, ProbePod{
+		Image:   DefaultNetworkProbeImage,
+		Command: []string{"nslookup", DefaultClusterDNSName},
+	}, DefaultNetworkProbeTimeout, opts...)
+	if err != nil {
+		return fmt.Errorf("cluster DNS: running probe: %w", err)
+	}
+	if result.ExitCode != 0 {
+		output := strings.TrimSpace(result.Stdout + "\n" + result.Stderr)
+		return fmt.Errorf("cluster DNS: resolving %s failed with exit code %d: %s", DefaultClusterDNSName, result.ExitCode, output)
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/davidcollom/terratest-utils/pkg/wait"
+	"github.com/davidcollom/terratest-utils/pkg/waittest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/utils/ptr"
+)
+
+func node(name string, ready corev1.ConditionStatus, pressure ...corev1.NodeConditionType) *corev1.Node {
+	n := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Status: corev1.NodeStatus{Conditions: []corev1.NodeCondition{
+			{Type: corev1.NodeReady, Status: ready, Reason: "KubeletReady"},
+		}},
+	}
+	for _, condType := range pressure {
+		n.Status.Conditions = append(n.Status.Conditions, corev1.NodeCondition{
+			Type: condType, Status: corev1.ConditionTrue, Reason: "Kubelet" + string(condType), Message: "kubelet has " + string(condType),
+		})
+	}
+	return n
+}
+
+// useReadyz makes getReadyz return body and err.
+func useReadyz(t *testing.T, body string, err error) {
+	previous := getReadyz
+	getReadyz = func(ctx context.Context, client kubernetes.Interface) ([]byte, error) {
+		return []byte(body), err
+	}
+	t.Cleanup(func() { getReadyz = previous })
+}
+
+func TestWaitForNodesReady(t *testing.T) {
+	t.Run("ready", func(t *testing.T) {
+		clock := waittest.NewClock()
+		client := NewTestClient(t, node("node-a", corev1.ConditionTrue), node("node-b", corev1.ConditionFalse)).(*fake.Clientset)
+		// The second node joins a minute into the wait.
+		client.PrependReactor("list", "nodes", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			if clock.Elapsed() >= time.Minute {
+				_ = client.Tracker().Update(corev1.SchemeGroupVersion.WithResource("nodes"), node("node-b", corev1.ConditionTrue), "")
+			}
+			return false, nil, nil
+		})
+
+		WaitForNodesReady(t, &KubectlOptions{}, 2, time.Hour, wait.WithClock(clock))
+		assert.GreaterOrEqual(t, clock.Elapsed(), time.Minute)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		NewTestClient(t, node("node-a", corev1.ConditionTrue), node("node-b", corev1.ConditionFalse))
+
+		err := WaitForNodesReadyE(t, &KubectlOptions{}, 2, 5*time.Minute, wait.WithClock(waittest.NewClock()))
+		require.True(t, wait.IsTimeout(err), "expected a timeout, got %v", err)
+		assert.Contains(t, err.Error(), "nodes not ready:\n    node node-b: Ready=False (KubeletReady)")
+	})
+}
+
+func TestCheckClusterHealth(t *testing.T) {
+	workloads := func(available int32) []runtime.Object {
+		return []runtime.Object{
+			&appsv1.Deployment{
+				ObjectMeta: metav1.ObjectMeta{Name: "coredns", Namespace: "kube-system"},
+				Spec:       appsv1.DeploymentSpec{Replicas: ptr.To[int32](2)},
+				Status:     appsv1.DeploymentStatus{AvailableReplicas: available},
+			},
+			&appsv1.DaemonSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "kube-proxy", Namespace: "kube-system"},
+				Status:     appsv1.DaemonSetStatus{DesiredNumberScheduled: 2, NumberAvailable: 2},
+			},
+		}
+	}
+	cluster := func(t *testing.T, executor fakeExecutor, objs ...runtime.Object) *[]*corev1.PodExecOptions {
+		client := NewTestClient(t, objs...).(*fake.Clientset)
+		client.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			pod := action.(k8stesting.CreateAction).GetObject().(*corev1.Pod)
+			pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+			return false, nil, nil
+		})
+		return useFakeExecutor(t, executor)
+	}
+
+	t.Run("healthy", func(t *testing.T) {
+		useReadyz(t, "[+]ping ok\n[+]etcd ok\nreadyz check passed\n", nil)
+		calls := cluster(t, fakeExecutor{stdout: "Address: 10.96.0.1"}, append(workloads(2), node("node-a", corev1.ConditionTrue))...)
+
+		assert.True(t, AssertClusterHealthy(t, &KubectlOptions{}, wait.WithClock(waittest.NewClock())))
+		require.Len(t, *calls, 1)
+		assert.Equal(t, []string{"nslookup", DefaultClusterDNSName}, (*calls)[0].Command)
+	})
+
+	t.Run("unhealthy", func(t *testing.T) {
+		useReadyz(t, "[+]ping ok\n[-]etcd failed: reason withheld\n[-]informer-sync failed: reason withheld\nreadyz check failed\n", errors.New("the server is currently unable to handle the request"))
+		cluster(t, fakeExecutor{stderr: "nslookup: can't resolve 'kubernetes.default.svc.cluster.local'", code: 1},
+			append(workloads(1),
+				node("node-a", corev1.ConditionTrue, corev1.NodeDiskPressure),
+				node("node-b", corev1.ConditionFalse, corev1.NodeMemoryPressure),
+			)...)
+
+		var msgs []string
+		for _, err := range CheckClusterHealthE(t, &KubectlOptions{}, wait.WithClock(waittest.NewClock())) {
+			msgs = append(msgs, err.Error())
+		}
+		assert.Equal(t, []string{
+			"node node-a: DiskPressure=True (KubeletDiskPressure): kubelet has DiskPressure",
+			"node node-b: Ready=False (KubeletReady); MemoryPressure=True (KubeletMemoryPressure): kubelet has MemoryPressure",
+			"Deployment kube-system/coredns: 1 of 2 replicas available",
+			"API server readyz check etcd failed: reason withheld",
+			"API server readyz check informer-sync failed: reason withheld",
+			"cluster DNS: resolving kubernetes.default.svc.cluster.local failed with exit code 1: nslookup: can't resolve 'kubernetes.default.svc.cluster.local'",
+		}, msgs)
+	})
+
+	t.Run("readyz unavailable", func(t *testing.T) {
+		useReadyz(t, "", errors.New("forbidden"))
+		problems := checkReadyz(context.Background(), NewTestClient(t))
+		require.Len(t, problems, 1)
+		assert.EqualError(t, problems[0], "API server readyz: forbidden")
+	})
+}
@@ -2,15 +2,28 @@ package k8s
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/gruntwork-io/terratest/modules/testing"
 
+	"github.com/davidcollom/terratest-utils/pkg/wait"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	apix "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
 	apixv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	structuralschema "k8s.io/apiextensions-apiserver/pkg/apiserver/schema"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/schema/pruning"
+	apixvalidation "k8s.io/apiextensions-apiserver/pkg/apiserver/validation"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/watch"
 )
 
 // GetCustomResourceDefinition retrieves a Kubernetes CustomResourceDefinition (CRD) by name using the provided KubectlOptions.
@@ -78,7 +91,7 @@ func ListCustomResourceDefinitionsE(t testing.TestingT, options *KubectlOptions,
 }
 
 // WaitForCustomResourceDefinitionIsReady waits until the specified CustomResourceDefinition (CRD) is ready in the Kubernetes cluster.
-// It polls the CRD status at regular intervals until it is ready or the timeout is reached.
+// It watches the CRD status until it is ready or the timeout is reached.
 // If the CRD does not become ready within the given timeout, the test fails.
 //
 // Parameters:
@@ -88,28 +101,46 @@ func ListCustomResourceDefinitionsE(t testing.TestingT, options *KubectlOptions,
 //   - timeout: The maximum duration to wait for the CRD to become ready.
 //
 // WaitForCustomResourceDefinitionIsReady waits for the resource condition to be satisfied.
-func WaitForCustomResourceDefinitionIsReady(t testing.TestingT, options *KubectlOptions, crdName string, timeout time.Duration) {
-	err := WaitForCustomResourceDefinitionIsReadyE(t, options, crdName, timeout)
+func WaitForCustomResourceDefinitionIsReady(t testing.TestingT, options *KubectlOptions, crdName string, timeout time.Duration, opts ...wait.WaitOption) {
+	err := WaitForCustomResourceDefinitionIsReadyE(t, options, crdName, timeout, opts...)
 	require.NoError(t, err, "CustomResourceDefinition %s was not Ready in time", crdName)
 }
 
 // WaitForCustomResourceDefinitionIsReadyE waits for the resource condition to be satisfied.
-func WaitForCustomResourceDefinitionIsReadyE(t testing.TestingT, options *KubectlOptions, crdName string, timeout time.Duration) error {
+// It returns a *wait.TerminalStateError as soon as the API server rejects the CRD's names.
+func WaitForCustomResourceDefinitionIsReadyE(t testing.TestingT, options *KubectlOptions, crdName string, timeout time.Duration, opts ...wait.WaitOption) error {
 	client, err := NewAPIXClient(t, options)
 	if err != nil {
 		return err
 	}
 
-	return wait.PollUntilContextTimeout(context.Background(), 2*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
-		crd, err := client.ApiextensionsV1().CustomResourceDefinitions().Get(ctx, crdName, metav1.GetOptions{})
-		if err != nil {
-			return false, nil // retry
-		}
-		if IsCustomResourceDefinitionReady(crd) {
-			return true, nil
+	_, err = wait.Waiter[*apixv1.CustomResourceDefinition]{
+		Kind:    "CustomResourceDefinition",
+		Name:    crdName,
+		Timeout: timeout,
+		Get: func(ctx context.Context) (*apixv1.CustomResourceDefinition, error) {
+			return client.ApiextensionsV1().CustomResourceDefinitions().Get(ctx, crdName, metav1.GetOptions{})
+		},
+		Watch: func(ctx context.Context) (watch.Interface, error) {
+			return client.ApiextensionsV1().CustomResourceDefinitions().Watch(ctx, metav1.SingleObject(metav1.ObjectMeta{Name: crdName}))
+		},
+		Ready:  IsCustomResourceDefinitionReady,
+		Failed: customResourceDefinitionNamesRejected,
+		Events: wait.KubeEvents(t, options),
+	}.Wait(t, opts...)
+	return err
+}
+
+// customResourceDefinitionNamesRejected reports NamesAccepted=False as a terminal state. The API
+// server rejects names that conflict with another CRD and will not establish the CRD until the
+// conflict is resolved.
+func customResourceDefinitionNamesRejected(crd *apixv1.CustomResourceDefinition) error {
+	for _, cond := range crd.Status.Conditions {
+		if cond.Type == apixv1.NamesAccepted && cond.Status == apixv1.ConditionFalse {
+			return &wait.TerminalStateError{Phase: "NamesAccepted=False", Reason: cond.Reason, Message: cond.Message}
 		}
-		return false, nil
-	})
+	}
+	return nil
 }
 
 // IsCustomResourceDefinitionReady checks whether the given CustomResourceDefinition (CRD)
@@ -141,3 +172,223 @@ func IsCustomResourceDefinitionReady(crd *apixv1.CustomResourceDefinition) bool
 	}
 	return established && accepted
 }
+
+// CustomResourceDefinitionVersions describes the versions of a CustomResourceDefinition, as compared
+// by AssertCustomResourceDefinitionVersions. Empty fields are not compared.
+type CustomResourceDefinitionVersions struct {
+	// Served are the versions served by the API server, in the order of spec.versions.
+	Served []string
+	// Storage is the version objects are persisted in.
+	Storage string
+	// Stored are the versions objects may still be persisted in, from status.storedVersions. A
+	// version stays listed until it is removed after a storage version migration.
+	Stored []string
+}
+
+// CustomResourceDefinitionVersionsOf returns the served, storage and stored versions of crd.
+func CustomResourceDefinitionVersionsOf(crd *apixv1.CustomResourceDefinition) CustomResourceDefinitionVersions {
+	var versions CustomResourceDefinitionVersions
+	for _, v := range crd.Spec.Versions {
+		if v.Served {
+			versions.Served = append(versions.Served, v.Name)
+		}
+		if v.Storage {
+			versions.Storage = v.Name
+		}
+	}
+	versions.Stored = crd.Status.StoredVersions
+	return versions
+}
+
+// AssertCustomResourceDefinitionVersions checks that a CustomResourceDefinition serves and stores the
+// expected versions, failing the test otherwise. Served and Stored are compared regardless of
+// order and must match exactly, so a deprecated version that is still served, or still listed in
+// status.storedVersions because it was never migrated away from, fails the assertion.
+//
+// Example usage:
+//
+//	k8s.AssertCustomResourceDefinitionVersions(t, options, "certificates.cert-manager.io", k8s.CustomResourceDefinitionVersions{
+//	    Served:  []string{"v1"},
+//	    Storage: "v1",
+//	    Stored:  []string{"v1"},
+//	})
+//
+// Parameters:
+//   - t: The testing context.
+//   - options: The kubectl options to use for connecting to the cluster.
+//   - crdName: The name of the CRD, e.g. "certificates.cert-manager.io".
+//   - expected: The expected versions; empty fields are not compared.
+//
+// Returns:
+//   - bool: True if the CRD's versions match expected.
+func AssertCustomResourceDefinitionVersions(t testing.TestingT, options *KubectlOptions, crdName string, expected CustomResourceDefinitionVersions) bool {
+	crd, err := GetCustomResourceDefinitionE(t, options, crdName, metav1.GetOptions{})
+	if !assert.NoError(t, err) {
+		return false
+	}
+	actual := CustomResourceDefinitionVersionsOf(crd)
+
+	ok := true
+	if expected.Served != nil {
+		ok = assert.ElementsMatch(t, expected.Served, actual.Served, "served versions of CustomResourceDefinition %s", crdName) && ok
+	}
+	if expected.Storage != "" {
+		ok = assert.Equal(t, expected.Storage, actual.Storage, "storage version of CustomResourceDefinition %s", crdName) && ok
+	}
+	if expected.Stored != nil {
+		ok = assert.ElementsMatch(t, expected.Stored, actual.Stored, "status.storedVersions of CustomResourceDefinition %s", crdName) && ok
+	}
+	return ok
+}
+
+// AssertCustomResourceDefinitionConversion checks that the conversion webhook of a
+// CustomResourceDefinition can be reached and that its objects can be read at every served version,
+// failing the test otherwise. See CheckCustomResourceDefinitionConversionE.
+//
+// Example usage:
+//
+//	k8s.ApplyManifests(t, options, "testdata/widget-v1alpha1.yaml")
+//	k8s.AssertCustomResourceDefinitionConversion(t, options, "widgets.example.com")
+//
+// Parameters:
+//   - t: The testing context.
+//   - options: The kubectl options to use for connecting to the cluster.
+//   - crdName: The name of the CRD.
+//
+// Returns:
+//   - bool: True if the conversion webhook is reachable and every served version could be read.
+func AssertCustomResourceDefinitionConversion(t testing.TestingT, options *KubectlOptions, crdName string) bool {
+	return assert.NoError(t, CheckCustomResourceDefinitionConversionE(t, options, crdName))
+}
+
+// CheckCustomResourceDefinitionConversionE checks the conversion webhook of a CustomResourceDefinition.
+// It returns an error for a CRD whose conversion strategy is not Webhook, as its versions are not
+// converted, and for a webhook without a client config. When the webhook is served by a Service, the
+// Service must have ready endpoints and the client config a caBundle to verify it with. It then lists
+// the objects of the CRD at each of its served versions, which makes the API server convert any
+// existing objects from the version they are stored in through the webhook, and returns the API
+// server's error when a version cannot be read, e.g. because the webhook's certificate does not match
+// its caBundle.
+func CheckCustomResourceDefinitionConversionE(t testing.TestingT, options *KubectlOptions, crdName string) error {
+	crd, err := GetCustomResourceDefinitionE(t, options, crdName, metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if err := checkConversionWebhook(t, options, crd); err != nil {
+		return err
+	}
+	client, err := NewDynamicClient(t, options)
+	if err != nil {
+		return err
+	}
+
+	for _, version := range CustomResourceDefinitionVersionsOf(crd).Served {
+		gvr := schema.GroupVersionResource{Group: crd.Spec.Group, Version: version, Resource: crd.Spec.Names.Plural}
+		if _, err := client.Resource(gvr).List(context.Background(), metav1.ListOptions{}); err != nil {
+			return fmt.Errorf("reading %s at version %s: %w", crdName, version, err)
+		}
+	}
+	return nil
+}
+
+// checkConversionWebhook checks that crd converts its versions with a webhook the API server can
+// reach: one given by URL, or served by a Service with ready endpoints and verified with a caBundle.
+func checkConversionWebhook(t testing.TestingT, options *KubectlOptions, crd *apixv1.CustomResourceDefinition) error {
+	conversion := crd.Spec.Conversion
+	if conversion == nil || conversion.Strategy != apixv1.WebhookConverter {
+		strategy := apixv1.NoneConverter
+		if conversion != nil && conversion.Strategy != "" {
+			strategy = conversion.Strategy
+		}
+		return fmt.Errorf("%s has conversion strategy %s, not Webhook, so its versions are not converted", crd.Name, strategy)
+	}
+	if conversion.Webhook == nil || conversion.Webhook.ClientConfig == nil {
+		return fmt.Errorf("%s has a Webhook conversion strategy but no webhook client config", crd.Name)
+	}
+
+	config := conversion.Webhook.ClientConfig
+	if config.Service == nil {
+		if config.URL == nil || *config.URL == "" {
+			return fmt.Errorf("conversion webhook of %s has neither a Service nor a URL", crd.Name)
+		}
+		return nil
+	}
+	service := config.Service
+	if len(config.CABundle) == 0 {
+		return fmt.Errorf("conversion webhook of %s has no caBundle to verify Service %s/%s with", crd.Name, service.Namespace, service.Name)
+	}
+
+	client, err := NewClient(t, options)
+	if err != nil {
+		return err
+	}
+	selector := labels.SelectorFromSet(labels.Set{discoveryv1.LabelServiceName: service.Name}).String()
+	slices, err := client.DiscoveryV1().EndpointSlices(service.Namespace).List(context.Background(), metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return fmt.Errorf("listing endpoints of conversion webhook Service %s/%s: %w", service.Namespace, service.Name, err)
+	}
+	if len(ReadyEndpointAddresses(slices.Items)) == 0 {
+		return fmt.Errorf("conversion webhook Service %s/%s of %s has no ready endpoints", service.Namespace, service.Name, crd.Name)
+	}
+	return nil
+}
+
+// ValidateCustomResource validates obj against the OpenAPI v3 schema crd defines for obj's version,
+// failing the test if it is invalid. See ValidateCustomResourceE.
+//
+// Example usage:
+//
+//	crd := k8s.GetCustomResourceDefinition(t, options, "widgets.example.com", metav1.GetOptions{})
+//	k8s.ValidateCustomResource(t, crd, sample)
+//
+// Parameters:
+//   - t: The testing context.
+//   - crd: The CustomResourceDefinition, e.g. from GetCustomResourceDefinition or decoded from its manifest.
+//   - obj: The custom resource to validate.
+func ValidateCustomResource(t testing.TestingT, crd *apixv1.CustomResourceDefinition, obj *unstructured.Unstructured) {
+	require.NoError(t, ValidateCustomResourceE(crd, obj))
+}
+
+// ValidateCustomResourceE validates obj against the OpenAPI v3 schema crd defines for obj's version
+// offline, with the validation the API server applies on create. Fields the schema does not
+// define are reported as errors, as kubectl's default strict field validation does, rather than
+// pruned. CEL validation rules (x-kubernetes-validations) and metadata are not checked. The
+// returned error lists every problem found.
+func ValidateCustomResourceE(crd *apixv1.CustomResourceDefinition, obj *unstructured.Unstructured) error {
+	gvk := obj.GroupVersionKind()
+	if gvk.Group != crd.Spec.Group || gvk.Kind != crd.Spec.Names.Kind {
+		return fmt.Errorf("%s is not defined by CustomResourceDefinition %s", gvk, crd.Name)
+	}
+	var version *apixv1.CustomResourceDefinitionVersion
+	for i := range crd.Spec.Versions {
+		if crd.Spec.Versions[i].Name == gvk.Version {
+			version = &crd.Spec.Versions[i]
+		}
+	}
+	if version == nil {
+		return fmt.Errorf("CustomResourceDefinition %s has no version %s", crd.Name, gvk.Version)
+	}
+	if version.Schema == nil || version.Schema.OpenAPIV3Schema == nil {
+		return fmt.Errorf("CustomResourceDefinition %s has no schema for version %s", crd.Name, gvk.Version)
+	}
+
+	var props apix.JSONSchemaProps
+	if err := apixv1.Convert_v1_JSONSchemaProps_To_apiextensions_JSONSchemaProps(version.Schema.OpenAPIV3Schema, &props, nil); err != nil {
+		return fmt.Errorf("converting schema of %s %s: %w", crd.Name, gvk.Version, err)
+	}
+	structural, err := structuralschema.NewStructural(&props)
+	if err != nil {
+		return fmt.Errorf("schema of %s %s is not structural: %w", crd.Name, gvk.Version, err)
+	}
+	validator, _, err := apixvalidation.NewSchemaValidator(&props)
+	if err != nil {
+		return fmt.Errorf("building validator for %s %s: %w", crd.Name, gvk.Version, err)
+	}
+
+	errs := apixvalidation.ValidateCustomResource(nil, obj.UnstructuredContent(), validator)
+	unknown := pruning.PruneWithOptions(runtime.DeepCopyJSON(obj.UnstructuredContent()), structural, true, structuralschema.UnknownFieldPathOptions{TrackUnknownFieldPaths: true})
+	for _, path := range unknown {
+		errs = append(errs, field.Forbidden(field.NewPath(path), "unknown field, not declared in the schema"))
+	}
+	return errs.ToAggregate()
+}
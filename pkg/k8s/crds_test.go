@@ -1,14 +1,21 @@
 package k8s
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/gruntwork-io/terratest/modules/k8s"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	apixv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/utils/ptr"
 )
 
 func TestGetCustomResourceDefinitionE(t *testing.T) {
@@ -93,5 +100,187 @@ func TestGetCustomResourceDefinitionE(t *testing.T) {
 	}
 }
 
+// widgetCRD defines widgets.example.com with a deprecated v1alpha1 and a v1 storage version.
+func widgetCRD() *apixv1.CustomResourceDefinition {
+	schema := &apixv1.CustomResourceValidation{OpenAPIV3Schema: &apixv1.JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]apixv1.JSONSchemaProps{
+			"spec": {
+				Type:     "object",
+				Required: []string{"size"},
+				Properties: map[string]apixv1.JSONSchemaProps{
+					"size":  {Type: "integer", Minimum: ptr.To[float64](1)},
+					"color": {Type: "string", Enum: []apixv1.JSON{{Raw: []byte(`"red"`)}, {Raw: []byte(`"blue"`)}}},
+				},
+			},
+		},
+	}}
+	return &apixv1.CustomResourceDefinition{
+		ObjectMeta: metav1.ObjectMeta{Name: "widgets.example.com"},
+		Spec: apixv1.CustomResourceDefinitionSpec{
+			Group: "example.com",
+			Names: apixv1.CustomResourceDefinitionNames{Plural: "widgets", Kind: "Widget"},
+			Scope: apixv1.NamespaceScoped,
+			Versions: []apixv1.CustomResourceDefinitionVersion{
+				{Name: "v1alpha1", Served: true, Deprecated: true, Schema: schema},
+				{Name: "v1", Served: true, Storage: true, Schema: schema},
+			},
+			Conversion: &apixv1.CustomResourceConversion{
+				Strategy: apixv1.WebhookConverter,
+				Webhook: &apixv1.WebhookConversion{
+					ClientConfig: &apixv1.WebhookClientConfig{
+						Service:  &apixv1.ServiceReference{Name: "widget-webhook", Namespace: "default", Path: ptr.To("/convert")},
+						CABundle: []byte("-----BEGIN CERTIFICATE-----"),
+					},
+					ConversionReviewVersions: []string{"v1"},
+				},
+			},
+		},
+		Status: apixv1.CustomResourceDefinitionStatus{StoredVersions: []string{"v1alpha1", "v1"}},
+	}
+}
+
+func TestAssertCustomResourceDefinitionVersions(t *testing.T) {
+	NewAPIXTestClient(t, []runtime.Object{widgetCRD()})
+
+	assert.Equal(t, CustomResourceDefinitionVersions{
+		Served:  []string{"v1alpha1", "v1"},
+		Storage: "v1",
+		Stored:  []string{"v1alpha1", "v1"},
+	}, CustomResourceDefinitionVersionsOf(widgetCRD()))
+
+	assert.True(t, AssertCustomResourceDefinitionVersions(t, k8soptions, "widgets.example.com", CustomResourceDefinitionVersions{
+		Served:  []string{"v1", "v1alpha1"},
+		Storage: "v1",
+	}))
+
+	mock := &testing.T{}
+	assert.False(t, AssertCustomResourceDefinitionVersions(mock, k8soptions, "widgets.example.com", CustomResourceDefinitionVersions{
+		Stored: []string{"v1"},
+	}), "v1alpha1 is still in status.storedVersions")
+}
+
+// widgetWebhookEndpoints returns the EndpointSlice of the widget-webhook Service with one endpoint.
+func widgetWebhookEndpoints(ready bool) *discoveryv1.EndpointSlice {
+	return &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "widget-webhook-x7k2p",
+			Namespace: "default",
+			Labels:    map[string]string{discoveryv1.LabelServiceName: "widget-webhook"},
+		},
+		Endpoints: []discoveryv1.Endpoint{{Addresses: []string{"10.0.0.7"}, Conditions: discoveryv1.EndpointConditions{Ready: ptr.To(ready)}}},
+	}
+}
+
+func TestCheckCustomResourceDefinitionConversionE(t *testing.T) {
+	NewAPIXTestClient(t, []runtime.Object{widgetCRD()})
+	NewTestClient(t, widgetWebhookEndpoints(true))
+
+	t.Run("no objects", func(t *testing.T) {
+		client := NewTestDynamicClient(t, sampleWidget("v1alpha1", nil), sampleWidget("v1", nil))
+		for _, version := range []string{"v1alpha1", "v1"} {
+			gvr := schema.GroupVersionResource{Group: "example.com", Version: version, Resource: "widgets"}
+			require.NoError(t, client.Resource(gvr).Namespace("default").Delete(t.Context(), "sample-"+version, metav1.DeleteOptions{}))
+		}
+		assert.NoError(t, CheckCustomResourceDefinitionConversionE(t, k8soptions, "widgets.example.com"))
+	})
+
+	t.Run("converted", func(t *testing.T) {
+		NewTestDynamicClient(t, sampleWidget("v1alpha1", nil), sampleWidget("v1", nil))
+		assert.True(t, AssertCustomResourceDefinitionConversion(t, k8soptions, "widgets.example.com"))
+	})
+
+	t.Run("webhook unreachable", func(t *testing.T) {
+		client := NewTestDynamicClient(t, sampleWidget("v1alpha1", nil), sampleWidget("v1", nil)).(*dynamicfake.FakeDynamicClient)
+		client.PrependReactor("list", "widgets", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			if action.GetResource().Version != "v1alpha1" {
+				return false, nil, nil
+			}
+			return true, nil, errors.New(`conversion webhook for example.com/v1, Kind=Widget failed: Post "https://widget-webhook.default.svc:443/convert": x509: certificate signed by unknown authority`)
+		})
+		err := CheckCustomResourceDefinitionConversionE(t, k8soptions, "widgets.example.com")
+		assert.ErrorContains(t, err, "at version v1alpha1")
+		assert.ErrorContains(t, err, "certificate signed by unknown authority")
+	})
+
+	t.Run("webhook Service has no ready endpoints", func(t *testing.T) {
+		NewTestClient(t, widgetWebhookEndpoints(false))
+		NewTestDynamicClient(t, sampleWidget("v1", nil))
+		err := CheckCustomResourceDefinitionConversionE(t, k8soptions, "widgets.example.com")
+		assert.EqualError(t, err, "conversion webhook Service default/widget-webhook of widgets.example.com has no ready endpoints")
+	})
+
+	t.Run("no caBundle", func(t *testing.T) {
+		crd := widgetCRD()
+		crd.Spec.Conversion.Webhook.ClientConfig.CABundle = nil
+		NewAPIXTestClient(t, []runtime.Object{crd})
+		err := CheckCustomResourceDefinitionConversionE(t, k8soptions, "widgets.example.com")
+		assert.EqualError(t, err, "conversion webhook of widgets.example.com has no caBundle to verify Service default/widget-webhook with")
+	})
+
+	t.Run("webhook given by URL", func(t *testing.T) {
+		crd := widgetCRD()
+		crd.Spec.Conversion.Webhook.ClientConfig = &apixv1.WebhookClientConfig{URL: ptr.To("https://widgets.example.com/convert")}
+		NewAPIXTestClient(t, []runtime.Object{crd})
+		NewTestClient(t)
+		NewTestDynamicClient(t, sampleWidget("v1", nil))
+		assert.NoError(t, CheckCustomResourceDefinitionConversionE(t, k8soptions, "widgets.example.com"))
+	})
+
+	t.Run("no conversion webhook", func(t *testing.T) {
+		crd := widgetCRD()
+		crd.Spec.Conversion = &apixv1.CustomResourceConversion{Strategy: apixv1.NoneConverter}
+		NewAPIXTestClient(t, []runtime.Object{crd})
+		err := CheckCustomResourceDefinitionConversionE(t, k8soptions, "widgets.example.com")
+		assert.EqualError(t, err, "widgets.example.com has conversion strategy None, not Webhook, so its versions are not converted")
+
+		crd.Spec.Conversion.Strategy = apixv1.WebhookConverter
+		NewAPIXTestClient(t, []runtime.Object{crd})
+		err = CheckCustomResourceDefinitionConversionE(t, k8soptions, "widgets.example.com")
+		assert.EqualError(t, err, "widgets.example.com has a Webhook conversion strategy but no webhook client config")
+	})
+}
+
+func sampleWidget(version string, spec map[string]any) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]any{
+		"metadata": map[string]any{"name": "sample-" + version, "namespace": "default"},
+	}}
+	if spec != nil {
+		obj.Object["spec"] = spec
+	}
+	obj.SetGroupVersionKind(schema.GroupVersionKind{Group: "example.com", Version: version, Kind: "Widget"})
+	return obj
+}
+
+func TestValidateCustomResourceE(t *testing.T) {
+	tests := []struct {
+		name    string
+		obj     *unstructured.Unstructured
+		wantErr []string
+	}{
+		{name: "valid", obj: sampleWidget("v1", map[string]any{"size": int64(3), "color": "red"})},
+		{name: "missing required", obj: sampleWidget("v1", map[string]any{"color": "red"}), wantErr: []string{"spec.size: Required value"}},
+		{
+			name:    "invalid values",
+			obj:     sampleWidget("v1", map[string]any{"size": int64(0), "color": "green"}),
+			wantErr: []string{"spec.size: Invalid value", "spec.color: Unsupported value"},
+		},
+		{name: "unknown field", obj: sampleWidget("v1", map[string]any{"size": int64(1), "shape": "round"}), wantErr: []string{"spec.shape: Forbidden: unknown field"}},
+		{name: "unknown version", obj: sampleWidget("v2", map[string]any{"size": int64(1)}), wantErr: []string{"has no version v2"}},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateCustomResourceE(widgetCRD(), tc.obj)
+			if tc.wantErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			for _, want := range tc.wantErr {
+				assert.ErrorContains(t, err, want)
+			}
+		})
+	}
+}
+
 // k8soptions a global k8s.KubectlOptions instance to be used within many tests..
 var k8soptions = &k8s.KubectlOptions{}
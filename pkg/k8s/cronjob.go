@@ -0,0 +1,143 @@
+package k8s
+
+import (
+	"context"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/testing"
+
+	"github.com/davidcollom/terratest-utils/pkg/wait"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// GetCronJob retrieves the specified CronJob from the given namespace, failing the test if it cannot be retrieved.
+//
+// Parameters:
+//   - t: The testing context.
+//   - options: The kubectl options to use when interacting with the cluster.
+//   - name: The name of the CronJob to retrieve.
+//   - namespace: The namespace where the CronJob is located.
+//   - opts: Additional options for the get operation.
+//
+// Returns:
+//   - A pointer to the retrieved batchv1.CronJob object.
+func GetCronJob(t testing.TestingT, options *KubectlOptions, name, namespace string, opts metav1.GetOptions) *batchv1.CronJob {
+	cronJob, err := GetCronJobE(t, options, name, namespace, opts)
+	require.NoError(t, err)
+	return cronJob
+}
+
+// GetCronJobE retrieves the specified CronJob from the given namespace.
+//
+// Parameters:
+//   - t: The testing context.
+//   - options: The KubectlOptions to configure the Kubernetes client.
+//   - name: The name of the CronJob to retrieve.
+//   - namespace: The namespace where the CronJob resides.
+//   - opts: Additional options for the Get request.
+//
+// Returns:
+//   - *batchv1.CronJob: The retrieved CronJob object.
+//   - error: An error if the CronJob could not be retrieved.
+func GetCronJobE(t testing.TestingT, options *KubectlOptions, name, namespace string, opts metav1.GetOptions) (*batchv1.CronJob, error) {
+	client, err := NewClient(t, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.BatchV1().CronJobs(namespace).Get(context.Background(), name, opts)
+}
+
+// ListCronJobs retrieves the CronJobs in the namespace of options, failing the test if an error occurs.
+//
+// Parameters:
+//   - t: The testing context.
+//   - options: The kubectl options specifying the context and namespace.
+//   - opts: The options to filter the list of CronJobs.
+//
+// Returns:
+//   - A slice of batchv1.CronJob objects representing the CronJobs found.
+func ListCronJobs(t testing.TestingT, options *KubectlOptions, opts metav1.ListOptions) []batchv1.CronJob {
+	cronJobs, err := ListCronJobsE(t, options, opts)
+	require.NoError(t, err)
+	return cronJobs
+}
+
+// ListCronJobsE retrieves the CronJobs in the namespace of options.
+//
+// Parameters:
+//   - t: The testing context.
+//   - options: The kubectl options specifying the Kubernetes context and namespace.
+//   - opts: The list options to filter the CronJobs.
+//
+// Returns:
+//   - A slice of CronJob objects found in the specified namespace.
+//   - An error if the CronJobs could not be listed.
+func ListCronJobsE(t testing.TestingT, options *KubectlOptions, opts metav1.ListOptions) ([]batchv1.CronJob, error) {
+	client, err := NewClient(t, options)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := client.BatchV1().CronJobs(options.Namespace).List(context.Background(), opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return list.Items, nil
+}
+
+// WaitForCronJobSucceeded waits until the specified CronJob has run and its most recently scheduled Job has succeeded or the timeout is reached, failing the test otherwise.
+//
+// Parameters:
+//   - t: The testing context.
+//   - options: The kubectl options for accessing the Kubernetes cluster.
+//   - name: The name of the CronJob to check.
+//   - namespace: The namespace where the CronJob is located.
+//   - timeout: The maximum duration to wait.
+func WaitForCronJobSucceeded(t testing.TestingT, options *KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) {
+	err := WaitForCronJobSucceededE(t, options, name, namespace, timeout, opts...)
+	require.NoError(t, err, "CronJob %s/%s did not run successfully in time", namespace, name)
+}
+
+// WaitForCronJobSucceededE waits until the specified CronJob has run and its most recently scheduled Job has succeeded, using IsCronJobSucceeded.
+func WaitForCronJobSucceededE(t testing.TestingT, options *KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) error {
+	client, err := NewClient(t, options)
+	if err != nil {
+		return err
+	}
+
+	_, err = wait.Waiter[*batchv1.CronJob]{
+		Kind:      "CronJob",
+		Name:      name,
+		Namespace: namespace,
+		Timeout:   timeout,
+		Get: func(ctx context.Context) (*batchv1.CronJob, error) {
+			return client.BatchV1().CronJobs(namespace).Get(ctx, name, metav1.GetOptions{})
+		},
+		Watch: func(ctx context.Context) (watch.Interface, error) {
+			return client.BatchV1().CronJobs(namespace).Watch(ctx, metav1.SingleObject(metav1.ObjectMeta{Name: name}))
+		},
+		Ready:  IsCronJobSucceeded,
+		Events: wait.KubeEvents(t, options),
+	}.Wait(t, opts...)
+	return err
+}
+
+// IsCronJobSucceeded reports whether the CronJob has been scheduled at least once and the most
+// recently scheduled Job has succeeded, i.e. lastSuccessfulTime is not before lastScheduleTime.
+//
+// Parameters:
+//   - cronJob: A pointer to the batchv1.CronJob object to check.
+//
+// Returns:
+//   - bool: True if the latest scheduled run succeeded, false otherwise.
+func IsCronJobSucceeded(cronJob *batchv1.CronJob) bool {
+	status := cronJob.Status
+	return status.LastScheduleTime != nil && status.LastSuccessfulTime != nil &&
+		!status.LastSuccessfulTime.Before(status.LastScheduleTime)
+}
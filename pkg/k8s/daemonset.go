@@ -0,0 +1,145 @@
+package k8s
+
+import (
+	"context"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/testing"
+
+	"github.com/davidcollom/terratest-utils/pkg/wait"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// GetDaemonSet retrieves the specified DaemonSet from the given namespace, failing the test if it cannot be retrieved.
+//
+// Parameters:
+//   - t: The testing context.
+//   - options: The kubectl options to use when interacting with the cluster.
+//   - name: The name of the DaemonSet to retrieve.
+//   - namespace: The namespace where the DaemonSet is located.
+//   - opts: Additional options for the get operation.
+//
+// Returns:
+//   - A pointer to the retrieved appsv1.DaemonSet object.
+func GetDaemonSet(t testing.TestingT, options *KubectlOptions, name, namespace string, opts metav1.GetOptions) *appsv1.DaemonSet {
+	daemonSet, err := GetDaemonSetE(t, options, name, namespace, opts)
+	require.NoError(t, err)
+	return daemonSet
+}
+
+// GetDaemonSetE retrieves the specified DaemonSet from the given namespace.
+//
+// Parameters:
+//   - t: The testing context.
+//   - options: The KubectlOptions to configure the Kubernetes client.
+//   - name: The name of the DaemonSet to retrieve.
+//   - namespace: The namespace where the DaemonSet resides.
+//   - opts: Additional options for the Get request.
+//
+// Returns:
+//   - *appsv1.DaemonSet: The retrieved DaemonSet object.
+//   - error: An error if the DaemonSet could not be retrieved.
+func GetDaemonSetE(t testing.TestingT, options *KubectlOptions, name, namespace string, opts metav1.GetOptions) (*appsv1.DaemonSet, error) {
+	client, err := NewClient(t, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.AppsV1().DaemonSets(namespace).Get(context.Background(), name, opts)
+}
+
+// ListDaemonSets retrieves the DaemonSets in the namespace of options, failing the test if an error occurs.
+//
+// Parameters:
+//   - t: The testing context.
+//   - options: The kubectl options specifying the context and namespace.
+//   - opts: The options to filter the list of DaemonSets.
+//
+// Returns:
+//   - A slice of appsv1.DaemonSet objects representing the DaemonSets found.
+func ListDaemonSets(t testing.TestingT, options *KubectlOptions, opts metav1.ListOptions) []appsv1.DaemonSet {
+	daemonSets, err := ListDaemonSetsE(t, options, opts)
+	require.NoError(t, err)
+	return daemonSets
+}
+
+// ListDaemonSetsE retrieves the DaemonSets in the namespace of options.
+//
+// Parameters:
+//   - t: The testing context.
+//   - options: The kubectl options specifying the Kubernetes context and namespace.
+//   - opts: The list options to filter the DaemonSets.
+//
+// Returns:
+//   - A slice of DaemonSet objects found in the specified namespace.
+//   - An error if the DaemonSets could not be listed.
+func ListDaemonSetsE(t testing.TestingT, options *KubectlOptions, opts metav1.ListOptions) ([]appsv1.DaemonSet, error) {
+	client, err := NewClient(t, options)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := client.AppsV1().DaemonSets(options.Namespace).List(context.Background(), opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return list.Items, nil
+}
+
+// WaitForDaemonSetReady waits until the specified DaemonSet has finished rolling out to every scheduled node or the timeout is reached, failing the test otherwise.
+//
+// Parameters:
+//   - t: The testing context.
+//   - options: The kubectl options for accessing the Kubernetes cluster.
+//   - name: The name of the DaemonSet to check.
+//   - namespace: The namespace where the DaemonSet is located.
+//   - timeout: The maximum duration to wait.
+func WaitForDaemonSetReady(t testing.TestingT, options *KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) {
+	err := WaitForDaemonSetReadyE(t, options, name, namespace, timeout, opts...)
+	require.NoError(t, err, "DaemonSet %s/%s did not finish rolling out in time", namespace, name)
+}
+
+// WaitForDaemonSetReadyE waits until the specified DaemonSet has finished rolling out to every scheduled node, using IsDaemonSetReady.
+func WaitForDaemonSetReadyE(t testing.TestingT, options *KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) error {
+	client, err := NewClient(t, options)
+	if err != nil {
+		return err
+	}
+
+	_, err = wait.Waiter[*appsv1.DaemonSet]{
+		Kind:      "DaemonSet",
+		Name:      name,
+		Namespace: namespace,
+		Timeout:   timeout,
+		Get: func(ctx context.Context) (*appsv1.DaemonSet, error) {
+			return client.AppsV1().DaemonSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		},
+		Watch: func(ctx context.Context) (watch.Interface, error) {
+			return client.AppsV1().DaemonSets(namespace).Watch(ctx, metav1.SingleObject(metav1.ObjectMeta{Name: name}))
+		},
+		Ready:  IsDaemonSetReady,
+		Events: wait.KubeEvents(t, options),
+	}.Wait(t, opts...)
+	return err
+}
+
+// IsDaemonSetReady reports whether the DaemonSet's latest rollout is complete: the controller has
+// observed the current generation and every node that should run the daemon pod runs an updated,
+// available one.
+//
+// Parameters:
+//   - daemonSet: A pointer to the appsv1.DaemonSet object to check.
+//
+// Returns:
+//   - bool: True if the rollout is complete, false otherwise.
+func IsDaemonSetReady(daemonSet *appsv1.DaemonSet) bool {
+	status := daemonSet.Status
+	return status.ObservedGeneration >= daemonSet.Generation &&
+		status.UpdatedNumberScheduled == status.DesiredNumberScheduled &&
+		status.NumberAvailable == status.DesiredNumberScheduled
+}
@@ -0,0 +1,172 @@
+package k8s
+
+import (
+	"context"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/testing"
+
+	"github.com/davidcollom/terratest-utils/pkg/wait"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// GetDeployment retrieves the specified Deployment from the given namespace, failing the test if it cannot be retrieved.
+//
+// Parameters:
+//   - t: The testing context.
+//   - options: The kubectl options to use when interacting with the cluster.
+//   - name: The name of the Deployment to retrieve.
+//   - namespace: The namespace where the Deployment is located.
+//   - opts: Additional options for the get operation.
+//
+// Returns:
+//   - A pointer to the retrieved appsv1.Deployment object.
+func GetDeployment(t testing.TestingT, options *KubectlOptions, name, namespace string, opts metav1.GetOptions) *appsv1.Deployment {
+	deployment, err := GetDeploymentE(t, options, name, namespace, opts)
+	require.NoError(t, err)
+	return deployment
+}
+
+// GetDeploymentE retrieves the specified Deployment from the given namespace.
+//
+// Parameters:
+//   - t: The testing context.
+//   - options: The KubectlOptions to configure the Kubernetes client.
+//   - name: The name of the Deployment to retrieve.
+//   - namespace: The namespace where the Deployment resides.
+//   - opts: Additional options for the Get request.
+//
+// Returns:
+//   - *appsv1.Deployment: The retrieved Deployment object.
+//   - error: An error if the Deployment could not be retrieved.
+func GetDeploymentE(t testing.TestingT, options *KubectlOptions, name, namespace string, opts metav1.GetOptions) (*appsv1.Deployment, error) {
+	client, err := NewClient(t, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.AppsV1().Deployments(namespace).Get(context.Background(), name, opts)
+}
+
+// ListDeployments retrieves the Deployments in the namespace of options, failing the test if an error occurs.
+//
+// Parameters:
+//   - t: The testing context.
+//   - options: The kubectl options specifying the context and namespace.
+//   - opts: The options to filter the list of Deployments.
+//
+// Returns:
+//   - A slice of appsv1.Deployment objects representing the Deployments found.
+func ListDeployments(t testing.TestingT, options *KubectlOptions, opts metav1.ListOptions) []appsv1.Deployment {
+	deployments, err := ListDeploymentsE(t, options, opts)
+	require.NoError(t, err)
+	return deployments
+}
+
+// ListDeploymentsE retrieves the Deployments in the namespace of options.
+//
+// Parameters:
+//   - t: The testing context.
+//   - options: The kubectl options specifying the Kubernetes context and namespace.
+//   - opts: The list options to filter the Deployments.
+//
+// Returns:
+//   - A slice of Deployment objects found in the specified namespace.
+//   - An error if the Deployments could not be listed.
+func ListDeploymentsE(t testing.TestingT, options *KubectlOptions, opts metav1.ListOptions) ([]appsv1.Deployment, error) {
+	client, err := NewClient(t, options)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := client.AppsV1().Deployments(options.Namespace).List(context.Background(), opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return list.Items, nil
+}
+
+// WaitForDeploymentReady waits until the specified Deployment has finished rolling out or the timeout is reached, failing the test otherwise.
+//
+// Parameters:
+//   - t: The testing context.
+//   - options: The kubectl options for accessing the Kubernetes cluster.
+//   - name: The name of the Deployment to check.
+//   - namespace: The namespace where the Deployment is located.
+//   - timeout: The maximum duration to wait.
+func WaitForDeploymentReady(t testing.TestingT, options *KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) {
+	err := WaitForDeploymentReadyE(t, options, name, namespace, timeout, opts...)
+	require.NoError(t, err, "Deployment %s/%s did not finish rolling out in time", namespace, name)
+}
+
+// WaitForDeploymentReadyE waits until the specified Deployment has finished rolling out, using IsDeploymentReady. It returns a *wait.TerminalStateError as soon as the rollout exceeds its progress deadline.
+func WaitForDeploymentReadyE(t testing.TestingT, options *KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) error {
+	client, err := NewClient(t, options)
+	if err != nil {
+		return err
+	}
+
+	_, err = wait.Waiter[*appsv1.Deployment]{
+		Kind:      "Deployment",
+		Name:      name,
+		Namespace: namespace,
+		Timeout:   timeout,
+		Get: func(ctx context.Context) (*appsv1.Deployment, error) {
+			return client.AppsV1().Deployments(namespace).Get(ctx, name, metav1.GetOptions{})
+		},
+		Watch: func(ctx context.Context) (watch.Interface, error) {
+			return client.AppsV1().Deployments(namespace).Watch(ctx, metav1.SingleObject(metav1.ObjectMeta{Name: name}))
+		},
+		Ready:  IsDeploymentReady,
+		Failed: deploymentFailed,
+		Events: wait.KubeEvents(t, options),
+	}.Wait(t, opts...)
+	return err
+}
+
+// IsDeploymentReady reports whether the Deployment's latest rollout is complete, following the same
+// rules as `kubectl rollout status`: the controller has observed the current generation, every
+// desired replica runs the latest template and is available, and no replicas of older revisions
+// remain.
+//
+// Parameters:
+//   - deployment: A pointer to the appsv1.Deployment object to check.
+//
+// Returns:
+//   - bool: True if the rollout is complete, false otherwise.
+func IsDeploymentReady(deployment *appsv1.Deployment) bool {
+	desired := replicas(deployment.Spec.Replicas)
+	status := deployment.Status
+	return status.ObservedGeneration >= deployment.Generation &&
+		status.UpdatedReplicas == desired &&
+		status.Replicas == status.UpdatedReplicas &&
+		status.AvailableReplicas == desired
+}
+
+// deploymentFailed reports a Deployment whose rollout has exceeded its progressDeadlineSeconds as a
+// terminal state; the controller stops making progress on it until the Deployment is changed.
+func deploymentFailed(deployment *appsv1.Deployment) error {
+	if deployment.Status.ObservedGeneration < deployment.Generation {
+		return nil
+	}
+	for _, cond := range deployment.Status.Conditions {
+		if cond.Type == appsv1.DeploymentProgressing && cond.Status == corev1.ConditionFalse && cond.Reason == "ProgressDeadlineExceeded" {
+			return &wait.TerminalStateError{Phase: "Progressing=False", Reason: cond.Reason, Message: cond.Message}
+		}
+	}
+	return nil
+}
+
+// replicas returns the desired replica count of a workload, which defaults to 1 when unset.
+func replicas(desired *int32) int32 {
+	if desired == nil {
+		return 1
+	}
+	return *desired
+}
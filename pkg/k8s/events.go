@@ -0,0 +1,432 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/logger"
+	"github.com/gruntwork-io/terratest/modules/testing"
+
+	"github.com/davidcollom/terratest-utils/pkg/utils"
+	"github.com/davidcollom/terratest-utils/pkg/wait"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ListEventsFor retrieves the Events recorded for obj, oldest first, failing the test if they cannot
+// be retrieved. See ListEventsForE.
+//
+// Parameters:
+//   - t: The testing context.
+//   - options: The kubectl options to use when interacting with the cluster.
+//   - obj: The object the Events are about.
+//
+// Returns:
+//   - []corev1.Event: The Events recorded for obj.
+func ListEventsFor(t testing.TestingT, options *KubectlOptions, obj runtime.Object) []corev1.Event {
+	events, err := ListEventsForE(t, options, obj)
+	require.NoError(t, err)
+	return events
+}
+
+// ListEventsForE retrieves the Events recorded for obj, oldest first. obj is any object with its
+// kind set in TypeMeta, a typed built-in object, or a *corev1.ObjectReference. When obj has a UID,
+// only Events about that incarnation of the object are returned. Events for cluster-scoped objects
+// are looked up in the "default" namespace, where Kubernetes records them.
+//
+// Parameters:
+//   - t: The testing context.
+//   - options: The kubectl options to use when interacting with the cluster.
+//   - obj: The object the Events are about.
+//
+// Returns:
+//   - []corev1.Event: The Events recorded for obj.
+//   - error: An error if obj's kind is unknown or the Events could not be retrieved.
+func ListEventsForE(t testing.TestingT, options *KubectlOptions, obj runtime.Object) ([]corev1.Event, error) {
+	client, err := NewClient(t, options)
+	if err != nil {
+		return nil, err
+	}
+	ref, err := involvedObjectRef(obj)
+	if err != nil {
+		return nil, err
+	}
+
+	return eventsFor(context.Background(), client, ref)
+}
+
+// WaitForEvent waits until an Event with reason is recorded for involvedObject, failing the test
+// otherwise. See WaitForEventE.
+//
+// Example usage:
+//
+//	cert := &corev1.ObjectReference{Kind: "Certificate", Namespace: "default", Name: "web-tls"}
+//	k8s.WaitForEvent(t, options, cert, "Issuing", 2*time.Minute)
+//
+// Parameters:
+//   - t: The testing context.
+//   - options: The kubectl options for accessing the Kubernetes cluster.
+//   - involvedObject: The object the Event is about.
+//   - reason: The reason of the Event, e.g. "BackupFailed".
+//   - timeout: The maximum duration to wait.
+//
+// Returns:
+//   - corev1.Event: The most recent Event with reason.
+func WaitForEvent(t testing.TestingT, options *KubectlOptions, involvedObject runtime.Object, reason string, timeout time.Duration, opts ...wait.WaitOption) corev1.Event {
+	event, err := WaitForEventE(t, options, involvedObject, reason, timeout, opts...)
+	require.NoError(t, err, "no %s Event was recorded in time", reason)
+	return event
+}
+
+// WaitForEventE waits until an Event with reason is recorded for involvedObject and returns the most
+// recent such Event. involvedObject is accepted in the same forms as by ListEventsForE. On timeout
+// the *wait.TimeoutError lists the Events that were recorded instead.
+func WaitForEventE(t testing.TestingT, options *KubectlOptions, involvedObject runtime.Object, reason string, timeout time.Duration, opts ...wait.WaitOption) (corev1.Event, error) {
+	client, err := NewClient(t, options)
+	if err != nil {
+		return corev1.Event{}, err
+	}
+	ref, err := involvedObjectRef(involvedObject)
+	if err != nil {
+		return corev1.Event{}, err
+	}
+
+	events, err := wait.Waiter[[]corev1.Event]{
+		Kind:      ref.Kind,
+		Name:      ref.Name,
+		Namespace: ref.Namespace,
+		Timeout:   timeout,
+		Get: func(ctx context.Context) ([]corev1.Event, error) {
+			return eventsFor(ctx, client, ref)
+		},
+		Ready: func(events []corev1.Event) bool {
+			_, ok := lastEventWithReason(events, reason)
+			return ok
+		},
+		Events: wait.KubeEvents(t, options),
+	}.Wait(t, opts...)
+	if err != nil {
+		return corev1.Event{}, err
+	}
+	event, _ := lastEventWithReason(events, reason)
+	return event, nil
+}
+
+// AssertNoWarningEvents checks that no Warning Events were recorded in namespace since the given
+// time, failing the test and listing them otherwise. An empty namespace checks every namespace.
+//
+// Example usage:
+//
+//	start := time.Now()
+//	flux.WaitForHelmReleaseReady(t, options, "podinfo", "flux-system", 5*time.Minute)
+//	k8s.AssertNoWarningEvents(t, options, "flux-system", start)
+//
+// Parameters:
+//   - t: The testing context.
+//   - options: The kubectl options for accessing the Kubernetes cluster.
+//   - namespace: The namespace to check.
+//   - since: Only Events last observed at or after this time are considered.
+//
+// Returns:
+//   - bool: True if no Warning Events were found.
+func AssertNoWarningEvents(t testing.TestingT, options *KubectlOptions, namespace string, since time.Time) bool {
+	warnings, err := ListWarningEventsE(t, options, namespace, since)
+	if !assert.NoError(t, err) {
+		return false
+	}
+	if len(warnings) == 0 {
+		return true
+	}
+	lines := make([]string, len(warnings))
+	for i, event := range warnings {
+		lines[i] = fmt.Sprintf("%s %s: %s", event.InvolvedObject.Kind, eventObjectRef(event), wait.FormatEvent(event))
+	}
+	return assert.Fail(t, fmt.Sprintf("%d Warning Events recorded since %s", len(warnings), since.Format(time.RFC3339)), strings.Join(lines, "\n"))
+}
+
+// ListWarningEventsE retrieves the Warning Events recorded in namespace since the given time, oldest
+// first. An empty namespace lists every namespace.
+func ListWarningEventsE(t testing.TestingT, options *KubectlOptions, namespace string, since time.Time) ([]corev1.Event, error) {
+	client, err := NewClient(t, options)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := client.CoreV1().Events(namespace).List(context.Background(), metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("type", corev1.EventTypeWarning).String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	var events []corev1.Event
+	for _, event := range list.Items {
+		// Filter again client side; not every API server (or fake) honours the field selector.
+		if event.Type == corev1.EventTypeWarning && !wait.EventTime(event).Before(since) {
+			events = append(events, event)
+		}
+	}
+	sortEvents(events)
+	return events, nil
+}
+
+// EventRecorder captures the Events recorded in a namespace while a test runs. Create one with
+// RecordEvents.
+type EventRecorder struct {
+	mu     sync.Mutex
+	events []corev1.Event
+	index  map[string]int
+}
+
+// RecordEvents starts capturing every Event recorded, or updated, in namespace from now until the test
+// finishes, failing the test if the Events cannot be watched. An empty namespace captures every
+// namespace. When the test fails, the captured Events are printed to the test output.
+//
+// Example usage:
+//
+//	events := k8s.RecordEvents(t, options, "velero")
+//	velero.WaitForBackupSucceeded(t, options, "nightly", "velero", 10*time.Minute)
+//	assert.Empty(t, events.Warnings())
+//
+// Parameters:
+//   - t: The testing context.
+//   - options: The kubectl options for accessing the Kubernetes cluster.
+//   - namespace: The namespace to capture Events from.
+//
+// Returns:
+//   - *EventRecorder: The recorder holding the captured Events.
+func RecordEvents(t utils.FailureT, options *KubectlOptions, namespace string) *EventRecorder {
+	recorder, err := RecordEventsE(t, options, namespace)
+	require.NoError(t, err)
+	return recorder
+}
+
+// RecordEventsE starts capturing every Event recorded, or updated, in namespace from now until the
+// test finishes. The watch is established before RecordEventsE returns and is re-established if the
+// API server closes it, so no Event is missed.
+func RecordEventsE(t utils.FailureT, options *KubectlOptions, namespace string) (*EventRecorder, error) {
+	client, err := NewClient(t, options)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	list, err := client.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{Limit: 1})
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	w, err := client.CoreV1().Events(namespace).Watch(ctx, metav1.ListOptions{ResourceVersion: list.ResourceVersion})
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	r := &EventRecorder{index: map[string]int{}}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		r.record(ctx, t, client, namespace, w, list.ResourceVersion)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+		if t.Failed() {
+			logger.Default.Logf(t, "Events recorded in %q during the test:\n%s", namespace, r)
+		}
+	})
+	return r, nil
+}
+
+// record adds the Events delivered by w, re-opening the watch from the last seen resource version
+// whenever it closes, until ctx is cancelled.
+func (r *EventRecorder) record(ctx context.Context, t testing.TestingT, client kubernetes.Interface, namespace string, w watch.Interface, resourceVersion string) {
+	for {
+		stop := context.AfterFunc(ctx, w.Stop)
+		for ev := range w.ResultChan() {
+			if ev.Type == watch.Error {
+				if apierrors.IsResourceExpired(apierrors.FromObject(ev.Object)) || apierrors.IsGone(apierrors.FromObject(ev.Object)) {
+					resourceVersion = ""
+				}
+				continue
+			}
+			event, ok := ev.Object.(*corev1.Event)
+			if !ok {
+				continue
+			}
+			resourceVersion = event.ResourceVersion
+			if ev.Type == watch.Added || ev.Type == watch.Modified {
+				r.add(*event)
+			}
+		}
+		stop()
+		w.Stop()
+
+		var err error
+		for {
+			if ctx.Err() != nil {
+				return
+			}
+			if resourceVersion == "" {
+				// The watch expired; resume from the current state, losing only the Events in between.
+				var list *corev1.EventList
+				if list, err = client.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{Limit: 1}); err == nil {
+					resourceVersion = list.ResourceVersion
+				}
+			}
+			if err == nil {
+				w, err = client.CoreV1().Events(namespace).Watch(ctx, metav1.ListOptions{ResourceVersion: resourceVersion})
+			}
+			if err == nil {
+				break
+			}
+			if !errors.Is(err, context.Canceled) {
+				logger.Default.Logf(t, "Re-establishing the Event watch in %q: %v", namespace, err)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(time.Second):
+			}
+		}
+	}
+}
+
+// add records event, replacing an earlier copy of the same Event, e.g. one with a lower count.
+func (r *EventRecorder) add(event corev1.Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := string(event.UID)
+	if key == "" {
+		key = event.Namespace + "/" + event.Name
+	}
+	if i, ok := r.index[key]; ok {
+		r.events[i] = event
+		return
+	}
+	r.index[key] = len(r.events)
+	r.events = append(r.events, event)
+}
+
+// Events returns the Events captured so far, oldest first.
+func (r *EventRecorder) Events() []corev1.Event {
+	r.mu.Lock()
+	events := append([]corev1.Event(nil), r.events...)
+	r.mu.Unlock()
+	sortEvents(events)
+	return events
+}
+
+// Warnings returns the Warning Events captured so far, oldest first.
+func (r *EventRecorder) Warnings() []corev1.Event {
+	var warnings []corev1.Event
+	for _, event := range r.Events() {
+		if event.Type == corev1.EventTypeWarning {
+			warnings = append(warnings, event)
+		}
+	}
+	return warnings
+}
+
+// String formats the captured Events one per line as "Kind namespace/name: Type Reason: Message".
+func (r *EventRecorder) String() string {
+	var b strings.Builder
+	for _, event := range r.Events() {
+		fmt.Fprintf(&b, "%s %s: %s\n", event.InvolvedObject.Kind, eventObjectRef(event), wait.FormatEvent(event))
+	}
+	return b.String()
+}
+
+// involvedObjectRef returns the reference Events use for obj.
+func involvedObjectRef(obj runtime.Object) (corev1.ObjectReference, error) {
+	if ref, ok := obj.(*corev1.ObjectReference); ok {
+		return *ref, nil
+	}
+
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return corev1.ObjectReference{}, err
+	}
+	gvk := obj.GetObjectKind().GroupVersionKind()
+	if gvk.Kind == "" {
+		gvks, _, err := scheme.Scheme.ObjectKinds(obj)
+		if err != nil {
+			return corev1.ObjectReference{}, fmt.Errorf("kind of %T is unknown, set its TypeMeta: %w", obj, err)
+		}
+		gvk = gvks[0]
+	}
+	return corev1.ObjectReference{
+		Kind:       gvk.Kind,
+		APIVersion: gvk.GroupVersion().String(),
+		Namespace:  accessor.GetNamespace(),
+		Name:       accessor.GetName(),
+		UID:        accessor.GetUID(),
+	}, nil
+}
+
+// eventsFor lists the Events about ref, oldest first.
+func eventsFor(ctx context.Context, client kubernetes.Interface, ref corev1.ObjectReference) ([]corev1.Event, error) {
+	namespace := ref.Namespace
+	if namespace == "" {
+		namespace = metav1.NamespaceDefault
+	}
+	selector := fields.Set{"involvedObject.kind": ref.Kind, "involvedObject.name": ref.Name}
+	if ref.UID != "" {
+		selector["involvedObject.uid"] = string(ref.UID)
+	}
+	list, err := client.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{FieldSelector: selector.String()})
+	if err != nil {
+		return nil, err
+	}
+
+	var events []corev1.Event
+	for _, event := range list.Items {
+		// Filter again client side; not every API server (or fake) honours the field selector.
+		involved := event.InvolvedObject
+		if involved.Kind == ref.Kind && involved.Name == ref.Name && (ref.UID == "" || involved.UID == ref.UID) {
+			events = append(events, event)
+		}
+	}
+	sortEvents(events)
+	return events, nil
+}
+
+// lastEventWithReason returns the most recent of events with reason.
+func lastEventWithReason(events []corev1.Event, reason string) (corev1.Event, bool) {
+	for i := len(events) - 1; i >= 0; i-- {
+		if events[i].Reason == reason {
+			return events[i], true
+		}
+	}
+	return corev1.Event{}, false
+}
+
+// sortEvents sorts events oldest first by the time they were last observed.
+func sortEvents(events []corev1.Event) {
+	sort.SliceStable(events, func(i, j int) bool {
+		return wait.EventTime(events[i]).Before(wait.EventTime(events[j]))
+	})
+}
+
+// eventObjectRef formats the object an Event is about as "namespace/name", or "name" when it is
+// cluster-scoped.
+func eventObjectRef(event corev1.Event) string {
+	if event.InvolvedObject.Namespace == "" {
+		return event.InvolvedObject.Name
+	}
+	return event.InvolvedObject.Namespace + "/" + event.InvolvedObject.Name
+}
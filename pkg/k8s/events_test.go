@@ -0,0 +1,134 @@
+package k8s
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/davidcollom/terratest-utils/pkg/wait"
+	"github.com/davidcollom/terratest-utils/pkg/waittest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+var eventsStart = time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func event(name, eventType, reason string, involved corev1.ObjectReference, at time.Duration) *corev1.Event {
+	namespace := involved.Namespace
+	if namespace == "" {
+		namespace = metav1.NamespaceDefault
+	}
+	return &corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Name: name, Namespace: namespace, UID: types.UID(name)},
+		InvolvedObject: involved,
+		Type:           eventType,
+		Reason:         reason,
+		Message:        reason + " happened",
+		LastTimestamp:  metav1.NewTime(eventsStart.Add(at)),
+	}
+}
+
+var (
+	backup     = corev1.ObjectReference{Kind: "Backup", Namespace: "velero", Name: "nightly", UID: "backup-2"}
+	oldBackup  = corev1.ObjectReference{Kind: "Backup", Namespace: "velero", Name: "nightly", UID: "backup-1"}
+	deployment = corev1.ObjectReference{Kind: "Deployment", Namespace: "velero", Name: "velero"}
+)
+
+func TestListEventsFor(t *testing.T) {
+	NewTestClient(t,
+		event("b", corev1.EventTypeWarning, "BackupFailed", backup, 2*time.Minute),
+		event("a", corev1.EventTypeNormal, "BackupStarted", backup, time.Minute),
+		event("old", corev1.EventTypeNormal, "BackupStarted", oldBackup, 0),
+		event("deploy", corev1.EventTypeNormal, "ScalingReplicaSet", deployment, 0),
+	)
+
+	reasons := func(events []corev1.Event) []string {
+		var r []string
+		for _, e := range events {
+			r = append(r, e.Reason)
+		}
+		return r
+	}
+	assert.Equal(t, []string{"BackupStarted", "BackupFailed"}, reasons(ListEventsFor(t, &KubectlOptions{}, &backup)), "oldest first, current incarnation only")
+
+	typed := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "velero", Namespace: "velero"}}
+	assert.Equal(t, []string{"ScalingReplicaSet"}, reasons(ListEventsFor(t, &KubectlOptions{}, typed)), "the kind of typed built-in objects is looked up")
+
+	_, err := ListEventsForE(t, &KubectlOptions{}, &corev1.EventList{})
+	assert.Error(t, err)
+}
+
+func TestWaitForEvent(t *testing.T) {
+	t.Run("recorded", func(t *testing.T) {
+		clock := waittest.NewClock()
+		client := NewTestClient(t, event("a", corev1.EventTypeNormal, "BackupStarted", backup, 0)).(*fake.Clientset)
+		// The backup fails a minute into the wait.
+		failed := event("b", corev1.EventTypeWarning, "BackupFailed", backup, time.Minute)
+		client.PrependReactor("list", "events", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			if clock.Elapsed() >= time.Minute {
+				_ = client.Tracker().Add(failed)
+			}
+			return false, nil, nil
+		})
+
+		got := WaitForEvent(t, &KubectlOptions{}, &backup, "BackupFailed", time.Hour, wait.WithClock(clock))
+		assert.Equal(t, "BackupFailed happened", got.Message)
+		assert.GreaterOrEqual(t, clock.Elapsed(), time.Minute)
+	})
+
+	t.Run("timeout lists the recorded events", func(t *testing.T) {
+		NewTestClient(t, event("a", corev1.EventTypeNormal, "BackupStarted", backup, 0))
+
+		_, err := WaitForEventE(t, &KubectlOptions{}, &backup, "BackupCompleted", time.Minute, wait.WithClock(waittest.NewClock()))
+		require.Error(t, err)
+		assert.True(t, wait.IsTimeout(err))
+		assert.Contains(t, err.Error(), "Normal BackupStarted: BackupStarted happened")
+	})
+}
+
+func TestListWarningEvents(t *testing.T) {
+	NewTestClient(t,
+		event("early", corev1.EventTypeWarning, "BackOff", deployment, 0),
+		event("normal", corev1.EventTypeNormal, "BackupStarted", backup, 2*time.Minute),
+		event("warning", corev1.EventTypeWarning, "BackupFailed", backup, 3*time.Minute),
+	)
+
+	warnings, err := ListWarningEventsE(t, &KubectlOptions{}, "velero", eventsStart.Add(time.Minute))
+	require.NoError(t, err)
+	require.Len(t, warnings, 1)
+	assert.Equal(t, "BackupFailed", warnings[0].Reason)
+
+	assert.True(t, AssertNoWarningEvents(t, &KubectlOptions{}, "velero", eventsStart.Add(5*time.Minute)))
+}
+
+func TestRecordEvents(t *testing.T) {
+	client := NewTestClient(t, event("before", corev1.EventTypeWarning, "BackOff", deployment, 0))
+
+	var recorder *EventRecorder
+	t.Run("test", func(t *testing.T) {
+		recorder = RecordEvents(t, &KubectlOptions{}, "velero")
+
+		events := client.CoreV1().Events("velero")
+		started, err := events.Create(context.Background(), event("a", corev1.EventTypeNormal, "BackupStarted", backup, time.Minute), metav1.CreateOptions{})
+		require.NoError(t, err)
+		_, err = events.Create(context.Background(), event("b", corev1.EventTypeWarning, "BackupFailed", backup, 2*time.Minute), metav1.CreateOptions{})
+		require.NoError(t, err)
+		started.Count = 2
+		started.LastTimestamp = metav1.NewTime(eventsStart.Add(3 * time.Minute))
+		_, err = events.Update(context.Background(), started, metav1.UpdateOptions{})
+		require.NoError(t, err)
+
+		require.Eventually(t, func() bool { return len(recorder.Events()) == 2 && recorder.Events()[1].Count == 2 }, 5*time.Second, 10*time.Millisecond)
+	})
+
+	assert.Equal(t, "Backup velero/nightly: Warning BackupFailed: BackupFailed happened\nBackup velero/nightly: Normal BackupStarted (x2): BackupStarted happened\n", recorder.String(),
+		"Events from before the recorder started are not captured and updates replace earlier copies")
+	require.Len(t, recorder.Warnings(), 1)
+}
@@ -0,0 +1,273 @@
+package k8s
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/logger"
+	"github.com/gruntwork-io/terratest/modules/testing"
+
+	"github.com/davidcollom/terratest-utils/pkg/utils"
+	"github.com/davidcollom/terratest-utils/pkg/wait"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/remotecommand"
+	utilexec "k8s.io/client-go/util/exec"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+)
+
+// ProbeContainerName is the name of the container of the Pods started by RunEphemeralProbePod.
+const ProbeContainerName = "probe"
+
+// ExecResult is the outcome of a command run by ExecInPod or RunEphemeralProbePod.
+type ExecResult struct {
+	// ExitCode is the exit code of the command.
+	ExitCode int
+	// Stdout is what the command wrote to its standard output.
+	Stdout string
+	// Stderr is what the command wrote to its standard error.
+	Stderr string
+}
+
+// NewExecutor creates the executor that runs a command in a Pod through the exec subresource. It
+// uses the REST config from utils.GetRestConfigE and, like kubectl, prefers the WebSocket protocol,
+// falling back to SPDY when the API server or a proxy in between does not support it.
+var NewExecutor = newExecutor
+
+func newExecutor(t testing.TestingT, options *KubectlOptions, name, namespace string, opts *corev1.PodExecOptions) (remotecommand.Executor, error) {
+	cfg, err := utils.GetRestConfigE(t, options)
+	if err != nil {
+		return nil, err
+	}
+	client, err := NewClient(t, options)
+	if err != nil {
+		return nil, err
+	}
+
+	url := client.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(namespace).
+		Name(name).
+		SubResource("exec").
+		VersionedParams(opts, scheme.ParameterCodec).
+		URL()
+	websocket, err := remotecommand.NewWebSocketExecutor(cfg, "GET", url.String())
+	if err != nil {
+		return nil, err
+	}
+	spdy, err := remotecommand.NewSPDYExecutor(cfg, "POST", url)
+	if err != nil {
+		return nil, err
+	}
+	return remotecommand.NewFallbackExecutor(websocket, spdy, func(err error) bool {
+		return httpstream.IsUpgradeFailure(err) || httpstream.IsHTTPSProxyError(err)
+	})
+}
+
+// ExecInPod runs command in a container of a Pod, failing the test if the command cannot be run.
+// A non-zero exit code does not fail the test; check ExecResult.ExitCode.
+//
+// Example usage:
+//
+//	result := k8s.ExecInPod(t, options, "app-0", "default", "app", []string{"cat", "/etc/secret/token"})
+//	require.Equal(t, 0, result.ExitCode, result.Stderr)
+//
+// Parameters:
+//   - t: The testing context.
+//   - options: The kubectl options to use when interacting with the cluster.
+//   - name: The name of the Pod.
+//   - namespace: The namespace where the Pod is located.
+//   - container: The container to run the command in; may be empty for Pods with a single container.
+//   - command: The command and its arguments. It is not run through a shell.
+//
+// Returns:
+//   - ExecResult: The exit code and output of the command.
+func ExecInPod(t testing.TestingT, options *KubectlOptions, name, namespace, container string, command []string) ExecResult {
+	result, err := ExecInPodE(t, options, name, namespace, container, command)
+	require.NoError(t, err)
+	return result
+}
+
+// ExecInPodE runs command in a container of a Pod and returns its exit code and output. It returns an
+// error only when the command could not be run or its exit code is unknown; a command that exits
+// with a non-zero code is reported through ExecResult.ExitCode.
+func ExecInPodE(t testing.TestingT, options *KubectlOptions, name, namespace, container string, command []string) (ExecResult, error) {
+	return execInPod(context.Background(), t, options, name, namespace, container, command)
+}
+
+func execInPod(ctx context.Context, t testing.TestingT, options *KubectlOptions, name, namespace, container string, command []string) (ExecResult, error) {
+	executor, err := NewExecutor(t, options, name, namespace, &corev1.PodExecOptions{
+		Container: container,
+		Command:   command,
+		Stdout:    true,
+		Stderr:    true,
+	})
+	if err != nil {
+		return ExecResult{}, err
+	}
+
+	var stdout, stderr bytes.Buffer
+	err = executor.StreamWithContext(ctx, remotecommand.StreamOptions{Stdout: &stdout, Stderr: &stderr})
+	result := ExecResult{Stdout: stdout.String(), Stderr: stderr.String()}
+	var exitErr utilexec.ExitError
+	if errors.As(err, &exitErr) && exitErr.Exited() {
+		result.ExitCode = exitErr.ExitStatus()
+		return result, nil
+	}
+	if err != nil {
+		return result, fmt.Errorf("running %q in Pod %s/%s: %w", command, namespace, name, err)
+	}
+	return result, nil
+}
+
+// ProbePod describes the throwaway Pod RunEphemeralProbePod runs a command from.
+type ProbePod struct {
+	// Image of the probe container, e.g. "curlimages/curl:8.10.1". It must provide a sleep command,
+	// which keeps the container running while Command is executed in it.
+	Image string
+	// Command is run in the probe container once the Pod is ready. It is not run through a shell.
+	Command []string
+	// Namespace the Pod is created in; the namespace of options, or "default", when empty.
+	Namespace string
+	// Labels and Annotations of the Pod, e.g. to have a service mesh inject its sidecar.
+	Labels      map[string]string
+	Annotations map[string]string
+	// ServiceAccountName of the Pod; the namespace's default service account when empty.
+	ServiceAccountName string
+}
+
+// RunEphemeralProbePod starts a throwaway Pod, runs a command in it and deletes it, failing the test
+// if the command cannot be run. A non-zero exit code does not fail the test; check
+// ExecResult.ExitCode.
+//
+// Example usage:
+//
+//	result := k8s.RunEphemeralProbePod(t, options, k8s.ProbePod{
+//	    Image:   "curlimages/curl:8.10.1",
+//	    Command: []string{"curl", "-sf", "http://podinfo.default:9898/healthz"},
+//	    Labels:  map[string]string{"sidecar.istio.io/inject": "true"},
+//	}, 2*time.Minute)
+//	require.Equal(t, 0, result.ExitCode, result.Stderr)
+//
+// Parameters:
+//   - t: The testing context.
+//   - options: The kubectl options for accessing the Kubernetes cluster.
+//   - probe: The image and command of the probe and how its Pod is created.
+//   - timeout: The maximum duration for the Pod to become ready and the command to finish.
+//
+// Returns:
+//   - ExecResult: The exit code and output of the command.
+func RunEphemeralProbePod(t testing.TestingT, options *KubectlOptions, probe ProbePod, timeout time.Duration, opts ...wait.WaitOption) ExecResult {
+	result, err := RunEphemeralProbePodE(t, options, probe, timeout, opts...)
+	require.NoError(t, err)
+	return result
+}
+
+// RunEphemeralProbePodE creates a Pod that runs probe.Image, waits for it to be ready, including any
+// injected sidecars, executes probe.Command in the probe container and deletes the Pod again. Running
+// the command through exec rather than as the container's entrypoint keeps its stdout and stderr
+// apart and reports its exit code even when the mesh sidecar outlives it. The wait fails fast when
+// the image pull backs off or the Pod stops.
+func RunEphemeralProbePodE(t testing.TestingT, options *KubectlOptions, probe ProbePod, timeout time.Duration, opts ...wait.WaitOption) (ExecResult, error) {
+	client, err := NewClient(t, options)
+	if err != nil {
+		return ExecResult{}, err
+	}
+
+	namespace := probe.Namespace
+	if namespace == "" {
+		namespace = options.Namespace
+	}
+	if namespace == "" {
+		namespace = "default"
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "probe-" + utilrand.String(5),
+			Namespace:   namespace,
+			Labels:      probe.Labels,
+			Annotations: probe.Annotations,
+		},
+		Spec: corev1.PodSpec{
+			RestartPolicy:                 corev1.RestartPolicyNever,
+			ServiceAccountName:            probe.ServiceAccountName,
+			TerminationGracePeriodSeconds: ptr.To[int64](0),
+			Containers: []corev1.Container{{
+				Name:    ProbeContainerName,
+				Image:   probe.Image,
+				Command: []string{"sleep", fmt.Sprint(int(timeout.Seconds()) + 60)},
+			}},
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	pod, err = client.CoreV1().Pods(namespace).Create(ctx, pod, metav1.CreateOptions{})
+	if err != nil {
+		return ExecResult{}, fmt.Errorf("creating probe Pod: %w", err)
+	}
+	logger.Default.Logf(t, "Created probe Pod %s/%s running %s", namespace, pod.Name, probe.Image)
+	defer func() {
+		err := client.CoreV1().Pods(namespace).Delete(context.Background(), pod.Name, metav1.DeleteOptions{GracePeriodSeconds: ptr.To[int64](0)})
+		if err != nil {
+			logger.Default.Logf(t, "Deleting probe Pod %s/%s: %v", namespace, pod.Name, err)
+		}
+	}()
+
+	name := pod.Name
+	_, err = wait.Waiter[*corev1.Pod]{
+		Kind:      "Pod",
+		Name:      name,
+		Namespace: namespace,
+		Timeout:   timeout,
+		Get: func(ctx context.Context) (*corev1.Pod, error) {
+			return client.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+		},
+		Watch: func(ctx context.Context) (watch.Interface, error) {
+			return client.CoreV1().Pods(namespace).Watch(ctx, metav1.SingleObject(metav1.ObjectMeta{Name: name}))
+		},
+		Ready:  isPodReady,
+		Failed: podFailed,
+		Events: wait.KubeEvents(t, options),
+	}.Wait(t, append([]wait.WaitOption{wait.WithContext(ctx)}, opts...)...)
+	if err != nil {
+		return ExecResult{}, err
+	}
+
+	return execInPod(ctx, t, options, name, namespace, ProbeContainerName, probe.Command)
+}
+
+// isPodReady reports whether the Pod's Ready condition is True, i.e. all of its containers are ready.
+func isPodReady(pod *corev1.Pod) bool {
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == corev1.PodReady {
+			return cond.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// podFailed reports a Pod that has stopped, or whose image cannot be pulled, as a terminal state.
+func podFailed(pod *corev1.Pod) error {
+	if pod.Status.Phase == corev1.PodFailed || pod.Status.Phase == corev1.PodSucceeded {
+		return &wait.TerminalStateError{Phase: string(pod.Status.Phase), Message: pod.Status.Message}
+	}
+	for _, status := range pod.Status.ContainerStatuses {
+		if waiting := status.State.Waiting; waiting != nil {
+			switch waiting.Reason {
+			case "ImagePullBackOff", "InvalidImageName":
+				return &wait.TerminalStateError{Phase: waiting.Reason, Message: status.Name + ": " + waiting.Message}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/davidcollom/terratest-utils/pkg/wait"
+	"github.com/davidcollom/terratest-utils/pkg/waittest"
+	"github.com/gruntwork-io/terratest/modules/k8s"
+	terratesting "github.com/gruntwork-io/terratest/modules/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/remotecommand"
+	utilexec "k8s.io/client-go/util/exec"
+)
+
+// fakeExecutor writes fixed output and exits with code.
+type fakeExecutor struct {
+	stdout, stderr string
+	code           int
+}
+
+func (e fakeExecutor) Stream(options remotecommand.StreamOptions) error {
+	return e.StreamWithContext(context.Background(), options)
+}
+
+func (e fakeExecutor) StreamWithContext(ctx context.Context, options remotecommand.StreamOptions) error {
+	_, _ = io.WriteString(options.Stdout, e.stdout)
+	_, _ = io.WriteString(options.Stderr, e.stderr)
+	if e.code != 0 {
+		return utilexec.CodeExitError{Err: errors.New("command terminated with non-zero exit code"), Code: e.code}
+	}
+	return nil
+}
+
+// useFakeExecutor makes NewExecutor return executor and records the exec options it was given.
+func useFakeExecutor(t *testing.T, executor remotecommand.Executor) *[]*corev1.PodExecOptions {
+	var calls []*corev1.PodExecOptions
+	NewExecutor = func(t terratesting.TestingT, options *k8s.KubectlOptions, name, namespace string, opts *corev1.PodExecOptions) (remotecommand.Executor, error) {
+		calls = append(calls, opts)
+		return executor, nil
+	}
+	t.Cleanup(func() { NewExecutor = newExecutor })
+	return &calls
+}
+
+func TestExecInPod(t *testing.T) {
+	calls := useFakeExecutor(t, fakeExecutor{stdout: "token", stderr: "warning", code: 3})
+
+	result := ExecInPod(t, &KubectlOptions{}, "app-0", "default", "app", []string{"cat", "/etc/secret/token"})
+	assert.Equal(t, ExecResult{ExitCode: 3, Stdout: "token", Stderr: "warning"}, result)
+	require.Len(t, *calls, 1)
+	assert.Equal(t, "app", (*calls)[0].Container)
+	assert.Equal(t, []string{"cat", "/etc/secret/token"}, (*calls)[0].Command)
+}
+
+func TestRunEphemeralProbePod(t *testing.T) {
+	// readyOnCreate makes created Pods ready, standing in for the kubelet.
+	readyOnCreate := func(client *fake.Clientset) {
+		client.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			pod := action.(k8stesting.CreateAction).GetObject().(*corev1.Pod)
+			pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+			return false, nil, nil
+		})
+	}
+	probe := ProbePod{Image: "busybox:1.36", Command: []string{"nslookup", "kubernetes.default"}, Labels: map[string]string{"sidecar.istio.io/inject": "true"}}
+
+	t.Run("runs and deletes the pod", func(t *testing.T) {
+		client := NewTestClient(t).(*fake.Clientset)
+		readyOnCreate(client)
+		calls := useFakeExecutor(t, fakeExecutor{stdout: "Address: 10.96.0.1"})
+
+		result := RunEphemeralProbePod(t, &KubectlOptions{Namespace: "app"}, probe, time.Minute, wait.WithClock(waittest.NewClock()))
+		assert.Equal(t, ExecResult{Stdout: "Address: 10.96.0.1"}, result)
+		require.Len(t, *calls, 1)
+		assert.Equal(t, ProbeContainerName, (*calls)[0].Container)
+		assert.Equal(t, probe.Command, (*calls)[0].Command)
+
+		var created *corev1.Pod
+		for _, action := range client.Actions() {
+			if action.Matches("create", "pods") {
+				created = action.(k8stesting.CreateAction).GetObject().(*corev1.Pod)
+			}
+		}
+		require.NotNil(t, created)
+		assert.Equal(t, "app", created.Namespace)
+		assert.Equal(t, probe.Labels, created.Labels)
+		assert.Equal(t, "busybox:1.36", created.Spec.Containers[0].Image)
+
+		pods, err := client.CoreV1().Pods("app").List(context.Background(), metav1.ListOptions{})
+		require.NoError(t, err)
+		assert.Empty(t, pods.Items, "the probe pod is deleted")
+	})
+
+	t.Run("image cannot be pulled", func(t *testing.T) {
+		client := NewTestClient(t).(*fake.Clientset)
+		client.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			pod := action.(k8stesting.CreateAction).GetObject().(*corev1.Pod)
+			pod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+				Name:  ProbeContainerName,
+				State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ImagePullBackOff", Message: "Back-off pulling image"}},
+			}}
+			return false, nil, nil
+		})
+		useFakeExecutor(t, fakeExecutor{})
+
+		_, err := RunEphemeralProbePodE(t, &KubectlOptions{}, probe, time.Minute, wait.WithClock(waittest.NewClock()))
+		require.Error(t, err)
+		assert.True(t, wait.IsTerminalState(err))
+		assert.ErrorContains(t, err, "ImagePullBackOff")
+	})
+}
@@ -0,0 +1,152 @@
+package k8s
+
+import (
+	"github.com/davidcollom/terratest-utils/pkg/utils"
+	"github.com/davidcollom/terratest-utils/pkg/wait"
+	"github.com/gruntwork-io/terratest/modules/testing"
+
+	apixcm "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	apixfake "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/fake"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// NewTestClient creates a fake Kubernetes clientset seeded with objs and makes NewClient return it for
+// the rest of the test. The fake also backs wait.NewEventsClient, so Events seeded here appear in the
+// diagnostics of timed out waits in every package. Both are restored when the test finishes.
+func NewTestClient(t utils.CleanupT, objs ...runtime.Object) kubernetes.Interface {
+	client := fake.NewClientset(objs...)
+
+	newFake := func(t testing.TestingT, options *KubectlOptions) (kubernetes.Interface, error) {
+		return client, nil
+	}
+	previousEventsClient := wait.NewEventsClient
+	NewClient, wait.NewEventsClient = newFake, newFake
+	t.Cleanup(func() {
+		NewClient, wait.NewEventsClient = newClient, previousEventsClient
+	})
+
+	return client
+}
+
+// NewAPIXTestClient creates a fake API Extensions clientset seeded with objs and makes NewAPIXClient
+// return it for the rest of the test. NewAPIXClient is restored when the test finishes.
+func NewAPIXTestClient(t utils.CleanupT, objs []runtime.Object) apixcm.Interface {
+	client := apixfake.NewSimpleClientset(objs...)
+
+	NewAPIXClient = func(t testing.TestingT, options *KubectlOptions) (apixcm.Interface, error) {
+		return client, nil
+	}
+	t.Cleanup(func() {
+		NewAPIXClient = newAPIXClient
+	})
+
+	return client
+}
+
+// NewTestDynamicClient creates a fake dynamic client seeded with objs and makes NewDynamicClient return
+// it for the rest of the test. NewRESTMapper is replaced with a static mapper that knows the kinds of
+// objs, guessing their resource names and treating a kind as namespaced when its object has a
+// namespace. objs may be unstructured or typed built-in objects. Server-side apply creates or
+// merges into objects as on a real API server, without tracking field ownership. Both
+// are restored when the test finishes.
+func NewTestDynamicClient(t utils.CleanupT, objs ...runtime.Object) dynamic.Interface {
+	mapper := meta.NewDefaultRESTMapper(nil)
+	listKinds := map[schema.GroupVersionResource]string{}
+	for _, obj := range objs {
+		gvk := obj.GetObjectKind().GroupVersionKind()
+		if gvk.Empty() {
+			if gvks, _, err := scheme.Scheme.ObjectKinds(obj); err == nil {
+				gvk = gvks[0]
+			}
+		}
+		scope := meta.RESTScopeRoot
+		if accessor, err := meta.Accessor(obj); err == nil && accessor.GetNamespace() != "" {
+			scope = meta.RESTScopeNamespace
+		}
+		mapper.Add(gvk, scope)
+		gvr, _ := meta.UnsafeGuessKindToResource(gvk)
+		listKinds[gvr] = gvk.Kind + "List"
+	}
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(scheme.Scheme, listKinds, objs...)
+	client.PrependReactor("patch", "*", applyReactor(client.Tracker()))
+
+	NewDynamicClient = func(t testing.TestingT, options *KubectlOptions) (dynamic.Interface, error) {
+		return client, nil
+	}
+	NewRESTMapper = func(t testing.TestingT, options *KubectlOptions) (meta.RESTMapper, error) {
+		return mapper, nil
+	}
+	t.Cleanup(func() {
+		NewDynamicClient, NewRESTMapper = newDynamicClient, newRESTMapper
+	})
+
+	return client
+}
+
+// applyReactor handles server-side apply on the fake dynamic client, whose default reaction cannot
+// apply to unstructured objects. It creates the object when it does not exist yet and otherwise
+// merges the applied configuration into it, replacing lists; field ownership is not tracked.
+func applyReactor(tracker k8stesting.ObjectTracker) k8stesting.ReactionFunc {
+	return func(action k8stesting.Action) (bool, runtime.Object, error) {
+		patch, ok := action.(k8stesting.PatchAction)
+		if !ok || patch.GetPatchType() != types.ApplyPatchType {
+			return false, nil, nil
+		}
+		gvr, namespace, name := patch.GetResource(), patch.GetNamespace(), patch.GetName()
+
+		applied := &unstructured.Unstructured{}
+		if err := applied.UnmarshalJSON(patch.GetPatch()); err != nil {
+			return true, nil, err
+		}
+		applied.SetName(name)
+		applied.SetNamespace(namespace)
+
+		existing, err := tracker.Get(gvr, namespace, name)
+		if apierrors.IsNotFound(err) {
+			if err := tracker.Create(gvr, applied, namespace); err != nil {
+				return true, nil, err
+			}
+			obj, err := tracker.Get(gvr, namespace, name)
+			return true, obj, err
+		}
+		if err != nil {
+			return true, nil, err
+		}
+
+		content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(existing)
+		if err != nil {
+			return true, nil, err
+		}
+		merged := &unstructured.Unstructured{Object: mergeApplied(content, applied.Object)}
+		if err := tracker.Update(gvr, merged, namespace); err != nil {
+			return true, nil, err
+		}
+		obj, err := tracker.Get(gvr, namespace, name)
+		return true, obj, err
+	}
+}
+
+// mergeApplied merges src into dst, recursing into maps and replacing every other value.
+func mergeApplied(dst, src map[string]any) map[string]any {
+	for key, value := range src {
+		if srcMap, ok := value.(map[string]any); ok {
+			if dstMap, ok := dst[key].(map[string]any); ok {
+				dst[key] = mergeApplied(dstMap, srcMap)
+				continue
+			}
+		}
+		dst[key] = value
+	}
+	return dst
+}
@@ -0,0 +1,72 @@
+package k8s
+
+import (
+	"sync"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/status"
+)
+
+// HealthFunc computes the kstatus status of an object: Current once it is ready, InProgress while it
+// is converging, Failed when it will not become ready on its own. Register one with
+// RegisterHealthFunc for kinds whose readiness the generic kstatus rules get wrong.
+type HealthFunc func(obj *unstructured.Unstructured) (*status.Result, error)
+
+var (
+	healthMu    sync.RWMutex
+	healthFuncs = map[schema.GroupVersionKind]HealthFunc{}
+)
+
+// RegisterHealthFunc makes ResourceHealth, and with it WaitForResourceReady, use fn for objects of
+// gvk. Leave gvk.Version empty to register fn for every version of the kind; a function registered
+// for an exact version takes precedence. Registering nil removes the function.
+//
+// Example usage:
+//
+//	k8s.RegisterHealthFunc(schema.GroupVersionKind{Group: "example.com", Kind: "Widget"},
+//	    func(obj *unstructured.Unstructured) (*status.Result, error) {
+//	        phase, _, _ := unstructured.NestedString(obj.Object, "status", "phase")
+//	        if phase == "Running" {
+//	            return &status.Result{Status: status.CurrentStatus, Message: "Widget is running"}, nil
+//	        }
+//	        return &status.Result{Status: status.InProgressStatus, Message: "Widget is " + phase}, nil
+//	    })
+func RegisterHealthFunc(gvk schema.GroupVersionKind, fn HealthFunc) {
+	healthMu.Lock()
+	defer healthMu.Unlock()
+	if fn == nil {
+		delete(healthFuncs, gvk)
+		return
+	}
+	healthFuncs[gvk] = fn
+}
+
+// healthFuncFor returns the HealthFunc registered for gvk, or nil.
+func healthFuncFor(gvk schema.GroupVersionKind) HealthFunc {
+	healthMu.RLock()
+	defer healthMu.RUnlock()
+	if fn, ok := healthFuncs[gvk]; ok {
+		return fn
+	}
+	gvk.Version = ""
+	return healthFuncs[gvk]
+}
+
+// ResourceHealth computes the status of obj with the HealthFunc registered for its kind or, when
+// there is none, the kstatus rules. kstatus understands the built-in workloads and any resource that
+// follows the Kubernetes API conventions: it compares status.observedGeneration with
+// metadata.generation and reads the Ready, Reconciling and Stalled conditions.
+//
+// Parameters:
+//   - obj: The object to assess.
+//
+// Returns:
+//   - *status.Result: The computed status and a human readable message.
+//   - error: An error if the status could not be computed.
+func ResourceHealth(obj *unstructured.Unstructured) (*status.Result, error) {
+	if fn := healthFuncFor(obj.GroupVersionKind()); fn != nil {
+		return fn(obj)
+	}
+	return status.Compute(obj)
+}
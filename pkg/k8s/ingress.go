@@ -0,0 +1,86 @@
+package k8s
+
+import (
+	"context"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/testing"
+
+	"github.com/davidcollom/terratest-utils/pkg/wait"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// WaitForIngressAddress waits until the specified Ingress has been given a load-balancer address or the timeout is reached, failing the test otherwise.
+//
+// Example usage:
+//
+//	addr := k8s.WaitForIngressAddress(t, options, "podinfo", "default", 5*time.Minute)
+//	http_helper.HttpGetWithRetry(t, "http://"+addr+"/healthz", nil, 200, "OK", 30, 10*time.Second)
+//
+// Parameters:
+//   - t: The testing context.
+//   - options: The kubectl options for accessing the Kubernetes cluster.
+//   - name: The name of the Ingress.
+//   - namespace: The namespace where the Ingress is located.
+//   - timeout: The maximum duration to wait.
+//
+// Returns:
+//   - string: The IP address or hostname of the Ingress's load balancer.
+func WaitForIngressAddress(t testing.TestingT, options *KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) string {
+	address, err := WaitForIngressAddressE(t, options, name, namespace, timeout, opts...)
+	require.NoError(t, err, "Ingress %s/%s was not given an address in time", namespace, name)
+	return address
+}
+
+// WaitForIngressAddressE waits until the ingress controller has published a load-balancer address in
+// the status of the specified Ingress and returns it, preferring an IP address over a hostname.
+func WaitForIngressAddressE(t testing.TestingT, options *KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) (string, error) {
+	client, err := NewClient(t, options)
+	if err != nil {
+		return "", err
+	}
+
+	ingress, err := wait.Waiter[*networkingv1.Ingress]{
+		Kind:      "Ingress",
+		Name:      name,
+		Namespace: namespace,
+		Timeout:   timeout,
+		Get: func(ctx context.Context) (*networkingv1.Ingress, error) {
+			return client.NetworkingV1().Ingresses(namespace).Get(ctx, name, metav1.GetOptions{})
+		},
+		Watch: func(ctx context.Context) (watch.Interface, error) {
+			return client.NetworkingV1().Ingresses(namespace).Watch(ctx, metav1.SingleObject(metav1.ObjectMeta{Name: name}))
+		},
+		Ready:  func(ingress *networkingv1.Ingress) bool { return IngressAddress(ingress) != "" },
+		Events: wait.KubeEvents(t, options),
+	}.Wait(t, opts...)
+	if err != nil {
+		return "", err
+	}
+	return IngressAddress(ingress), nil
+}
+
+// IngressAddress returns the first load-balancer address of the Ingress, preferring an IP address
+// over a hostname, or "" when the ingress controller has not published one yet.
+//
+// Parameters:
+//   - ingress: A pointer to the networkingv1.Ingress object to check.
+//
+// Returns:
+//   - string: The IP address or hostname, or "".
+func IngressAddress(ingress *networkingv1.Ingress) string {
+	var hostname string
+	for _, lb := range ingress.Status.LoadBalancer.Ingress {
+		if lb.IP != "" {
+			return lb.IP
+		}
+		if hostname == "" {
+			hostname = lb.Hostname
+		}
+	}
+	return hostname
+}
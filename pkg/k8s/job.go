@@ -0,0 +1,170 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/testing"
+
+	"github.com/davidcollom/terratest-utils/pkg/wait"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// GetJob retrieves the specified Job from the given namespace, failing the test if it cannot be retrieved.
+//
+// Parameters:
+//   - t: The testing context.
+//   - options: The kubectl options to use when interacting with the cluster.
+//   - name: The name of the Job to retrieve.
+//   - namespace: The namespace where the Job is located.
+//   - opts: Additional options for the get operation.
+//
+// Returns:
+//   - A pointer to the retrieved batchv1.Job object.
+func GetJob(t testing.TestingT, options *KubectlOptions, name, namespace string, opts metav1.GetOptions) *batchv1.Job {
+	job, err := GetJobE(t, options, name, namespace, opts)
+	require.NoError(t, err)
+	return job
+}
+
+// GetJobE retrieves the specified Job from the given namespace.
+//
+// Parameters:
+//   - t: The testing context.
+//   - options: The KubectlOptions to configure the Kubernetes client.
+//   - name: The name of the Job to retrieve.
+//   - namespace: The namespace where the Job resides.
+//   - opts: Additional options for the Get request.
+//
+// Returns:
+//   - *batchv1.Job: The retrieved Job object.
+//   - error: An error if the Job could not be retrieved.
+func GetJobE(t testing.TestingT, options *KubectlOptions, name, namespace string, opts metav1.GetOptions) (*batchv1.Job, error) {
+	client, err := NewClient(t, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.BatchV1().Jobs(namespace).Get(context.Background(), name, opts)
+}
+
+// ListJobs retrieves the Jobs in the namespace of options, failing the test if an error occurs.
+//
+// Parameters:
+//   - t: The testing context.
+//   - options: The kubectl options specifying the context and namespace.
+//   - opts: The options to filter the list of Jobs.
+//
+// Returns:
+//   - A slice of batchv1.Job objects representing the Jobs found.
+func ListJobs(t testing.TestingT, options *KubectlOptions, opts metav1.ListOptions) []batchv1.Job {
+	jobs, err := ListJobsE(t, options, opts)
+	require.NoError(t, err)
+	return jobs
+}
+
+// ListJobsE retrieves the Jobs in the namespace of options.
+//
+// Parameters:
+//   - t: The testing context.
+//   - options: The kubectl options specifying the Kubernetes context and namespace.
+//   - opts: The list options to filter the Jobs.
+//
+// Returns:
+//   - A slice of Job objects found in the specified namespace.
+//   - An error if the Jobs could not be listed.
+func ListJobsE(t testing.TestingT, options *KubectlOptions, opts metav1.ListOptions) ([]batchv1.Job, error) {
+	client, err := NewClient(t, options)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := client.BatchV1().Jobs(options.Namespace).List(context.Background(), opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return list.Items, nil
+}
+
+// WaitForJobComplete waits until the specified Job has completed successfully or the timeout is reached, failing the test otherwise.
+//
+// Parameters:
+//   - t: The testing context.
+//   - options: The kubectl options for accessing the Kubernetes cluster.
+//   - name: The name of the Job to check.
+//   - namespace: The namespace where the Job is located.
+//   - timeout: The maximum duration to wait.
+func WaitForJobComplete(t testing.TestingT, options *KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) {
+	err := WaitForJobCompleteE(t, options, name, namespace, timeout, opts...)
+	require.NoError(t, err, "Job %s/%s did not complete in time", namespace, name)
+}
+
+// WaitForJobCompleteE waits until the specified Job has completed successfully, using IsJobComplete. It returns a *wait.TerminalStateError as soon as the Job fails, e.g. because it exceeded its backoffLimit or activeDeadlineSeconds.
+func WaitForJobCompleteE(t testing.TestingT, options *KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) error {
+	client, err := NewClient(t, options)
+	if err != nil {
+		return err
+	}
+
+	_, err = wait.Waiter[*batchv1.Job]{
+		Kind:      "Job",
+		Name:      name,
+		Namespace: namespace,
+		Timeout:   timeout,
+		Get: func(ctx context.Context) (*batchv1.Job, error) {
+			return client.BatchV1().Jobs(namespace).Get(ctx, name, metav1.GetOptions{})
+		},
+		Watch: func(ctx context.Context) (watch.Interface, error) {
+			return client.BatchV1().Jobs(namespace).Watch(ctx, metav1.SingleObject(metav1.ObjectMeta{Name: name}))
+		},
+		Ready:  IsJobComplete,
+		Failed: jobFailed,
+		Events: wait.KubeEvents(t, options),
+	}.Wait(t, opts...)
+	return err
+}
+
+// IsJobComplete reports whether the Job has completed successfully, i.e. it has a Complete condition
+// with status True.
+//
+// Parameters:
+//   - job: A pointer to the batchv1.Job object to check.
+//
+// Returns:
+//   - bool: True if the Job has completed, false otherwise.
+func IsJobComplete(job *batchv1.Job) bool {
+	return jobCondition(job, batchv1.JobComplete) != nil
+}
+
+// jobFailed reports a failed Job as a terminal state. The Failed condition is authoritative; until
+// the controller sets it, a Job whose failed pods exceed its backoffLimit is reported as failed too.
+func jobFailed(job *batchv1.Job) error {
+	if cond := jobCondition(job, batchv1.JobFailed); cond != nil {
+		return &wait.TerminalStateError{Phase: "Failed=True", Reason: cond.Reason, Message: cond.Message}
+	}
+	if limit := job.Spec.BackoffLimit; limit != nil && job.Status.Failed > *limit {
+		return &wait.TerminalStateError{
+			Phase:   "Failed",
+			Reason:  "BackoffLimitExceeded",
+			Message: fmt.Sprintf("%d pods failed, exceeding the backoffLimit of %d", job.Status.Failed, *limit),
+		}
+	}
+	return nil
+}
+
+// jobCondition returns the Job's condition of type condType if its status is True, or nil.
+func jobCondition(job *batchv1.Job, condType batchv1.JobConditionType) *batchv1.JobCondition {
+	for i, cond := range job.Status.Conditions {
+		if cond.Type == condType && cond.Status == corev1.ConditionTrue {
+			return &job.Status.Conditions[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,338 @@
+package k8s
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/logger"
+	"github.com/gruntwork-io/terratest/modules/testing"
+
+	"github.com/davidcollom/terratest-utils/pkg/utils"
+	"github.com/davidcollom/terratest-utils/pkg/wait"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/utils/ptr"
+)
+
+// DefaultDumpTailLines is the number of lines DumpLogsOnFailure prints per container.
+const DefaultDumpTailLines = 200
+
+// LogSource selects the pods, and optionally the container, whose logs DumpLogsOnFailure prints.
+type LogSource struct {
+	// Namespace of the pods.
+	Namespace string
+	// Selector is a label selector matching the pods, e.g. "app=source-controller".
+	Selector string
+	// Container restricts the logs to one container; every container, including init containers,
+	// when empty.
+	Container string
+}
+
+// Log sources of the controllers whose packages this module provides helpers for, as installed by
+// their official Helm charts or install manifests into their default namespaces. Copy and adjust the
+// Namespace for installs elsewhere.
+var (
+	CertManagerLogs                 = LogSource{Namespace: "cert-manager", Selector: "app.kubernetes.io/instance=cert-manager"}
+	FluxSourceControllerLogs        = LogSource{Namespace: "flux-system", Selector: "app=source-controller"}
+	ArgoCDApplicationControllerLogs = LogSource{Namespace: "argocd", Selector: "app.kubernetes.io/name=argocd-application-controller"}
+	VeleroLogs                      = LogSource{Namespace: "velero", Selector: "component=velero"}
+)
+
+// GetPodLogs retrieves the logs of a Pod, failing the test if they cannot be retrieved.
+//
+// Parameters:
+//   - t: The testing context.
+//   - options: The kubectl options to use when interacting with the cluster.
+//   - name: The name of the Pod.
+//   - namespace: The namespace where the Pod is located.
+//   - opts: Options for the log request, e.g. Container, TailLines or Previous.
+//
+// Returns:
+//   - string: The logs of the Pod.
+func GetPodLogs(t testing.TestingT, options *KubectlOptions, name, namespace string, opts corev1.PodLogOptions) string {
+	logs, err := GetPodLogsE(t, options, name, namespace, opts)
+	require.NoError(t, err)
+	return logs
+}
+
+// GetPodLogsE retrieves the logs of a Pod. opts.Container may be left empty for Pods with a single
+// container.
+//
+// Parameters:
+//   - t: The testing context.
+//   - options: The kubectl options to use when interacting with the cluster.
+//   - name: The name of the Pod.
+//   - namespace: The namespace where the Pod is located.
+//   - opts: Options for the log request, e.g. Container, TailLines or Previous.
+//
+// Returns:
+//   - string: The logs of the Pod.
+//   - error: An error if the logs could not be retrieved.
+func GetPodLogsE(t testing.TestingT, options *KubectlOptions, name, namespace string, opts corev1.PodLogOptions) (string, error) {
+	client, err := NewClient(t, options)
+	if err != nil {
+		return "", err
+	}
+
+	logs, err := client.CoreV1().Pods(namespace).GetLogs(name, &opts).DoRaw(context.Background())
+	if err != nil {
+		return "", fmt.Errorf("getting logs of Pod %s/%s: %w", namespace, name, err)
+	}
+	return string(logs), nil
+}
+
+// StreamPodLogs follows the logs of a Pod into the test output until the test finishes, failing the
+// test if the stream cannot be opened. See StreamPodLogsE.
+func StreamPodLogs(t utils.CleanupT, options *KubectlOptions, name, namespace string, opts corev1.PodLogOptions) {
+	require.NoError(t, StreamPodLogsE(t, options, name, namespace, opts))
+}
+
+// StreamPodLogsE follows the logs of a Pod into the test output, prefixing every line with
+// "namespace/name[container]". Streaming runs in the background and stops when the Pod's container
+// exits or the test finishes; if the stream breaks before then, the error is logged.
+//
+// Example usage:
+//
+//	k8s.StreamPodLogsE(t, options, "podinfo-0", "default", corev1.PodLogOptions{Container: "podinfo"})
+//
+// Parameters:
+//   - t: The testing context; streaming stops when it finishes.
+//   - options: The kubectl options to use when interacting with the cluster.
+//   - name: The name of the Pod.
+//   - namespace: The namespace where the Pod is located.
+//   - opts: Options for the log request; Follow is always set.
+//
+// Returns:
+//   - error: An error if the log stream could not be opened.
+func StreamPodLogsE(t utils.CleanupT, options *KubectlOptions, name, namespace string, opts corev1.PodLogOptions) error {
+	client, err := NewClient(t, options)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	opts.Follow = true
+	stream, err := client.CoreV1().Pods(namespace).GetLogs(name, &opts).Stream(ctx)
+	if err != nil {
+		cancel()
+		return fmt.Errorf("streaming logs of Pod %s/%s: %w", namespace, name, err)
+	}
+
+	prefix := namespace + "/" + name
+	if opts.Container != "" {
+		prefix += "[" + opts.Container + "]"
+	}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer stream.Close()
+		err := forEachLogLine(stream, func(line string) {
+			logger.Default.Logf(t, "%s %s", prefix, line)
+		})
+		if err != nil && !errors.Is(err, context.Canceled) && ctx.Err() == nil {
+			logger.Default.Logf(t, "%s log stream ended: %v", prefix, err)
+		}
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+	return nil
+}
+
+// forEachLogLine calls fn with every line read from r, without the line ending, until r is
+// exhausted. Lines are not limited in length. It returns the error that ended the read, or nil at
+// the end of r.
+func forEachLogLine(r io.Reader, fn func(line string)) error {
+	reader := bufio.NewReader(r)
+	for {
+		line, err := reader.ReadString('\n')
+		if line != "" {
+			fn(strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r"))
+		}
+		if errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+	}
+}
+
+// WaitForLogLine waits until a line of the logs of a Pod matching selector in the namespace of options
+// matches pattern, failing the test otherwise.
+//
+// Example usage:
+//
+//	k8s.WaitForLogLine(t, options, "app=source-controller", "manager", regexp.MustCompile(`artifact up-to-date`), 2*time.Minute)
+//
+// Parameters:
+//   - t: The testing context.
+//   - options: The kubectl options for accessing the Kubernetes cluster.
+//   - selector: A label selector matching the Pods whose logs are searched.
+//   - container: The container whose logs are searched; the Pod's default container when empty.
+//   - pattern: The regular expression a line must match.
+//   - timeout: The maximum duration to wait.
+//
+// Returns:
+//   - string: The first matching line.
+func WaitForLogLine(t testing.TestingT, options *KubectlOptions, selector, container string, pattern *regexp.Regexp, timeout time.Duration, opts ...wait.WaitOption) string {
+	line, err := WaitForLogLineE(t, options, selector, container, pattern, timeout, opts...)
+	require.NoError(t, err, "no log line of Pods %q in %s matched %q in time", selector, options.Namespace, pattern)
+	return line
+}
+
+// WaitForLogLineE waits until a line of the logs of a Pod matching selector in the namespace of
+// options matches pattern, and returns that line. The logs of every matching Pod are fetched in full
+// on each poll, so the line is found even if it was written before the wait started. Pods whose
+// logs cannot be read yet are skipped on that poll.
+func WaitForLogLineE(t testing.TestingT, options *KubectlOptions, selector, container string, pattern *regexp.Regexp, timeout time.Duration, opts ...wait.WaitOption) (string, error) {
+	client, err := NewClient(t, options)
+	if err != nil {
+		return "", err
+	}
+
+	return wait.Waiter[string]{
+		Kind:      "Pod logs",
+		Name:      selector,
+		Namespace: options.Namespace,
+		Timeout:   timeout,
+		Get: func(ctx context.Context) (string, error) {
+			pods, err := client.CoreV1().Pods(options.Namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+			if err != nil {
+				return "", err
+			}
+			if len(pods.Items) == 0 {
+				return "", fmt.Errorf("no Pods match %q", selector)
+			}
+			return firstMatchingLine(pods.Items, pattern, func(pod corev1.Pod) ([]byte, error) {
+				return client.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &corev1.PodLogOptions{Container: container}).DoRaw(ctx)
+			})
+		},
+		Ready: func(line string) bool {
+			return line != ""
+		},
+	}.Wait(t, opts...)
+}
+
+// firstMatchingLine returns the first line of the logs of pods that matches pattern, or "". Pods
+// whose logs cannot be read, such as ones still being created or evicted, are skipped so they do
+// not hide a match in another replica; an error is only returned when no Pod's logs could be read.
+func firstMatchingLine(pods []corev1.Pod, pattern *regexp.Regexp, getLogs func(pod corev1.Pod) ([]byte, error)) (string, error) {
+	var errs []error
+	for _, pod := range pods {
+		logs, err := getLogs(pod)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("getting logs of Pod %s/%s: %w", pod.Namespace, pod.Name, err))
+			continue
+		}
+		if line := matchingLine(logs, pattern); line != "" {
+			return line, nil
+		}
+	}
+	if len(errs) == len(pods) {
+		return "", errors.Join(errs...)
+	}
+	return "", nil
+}
+
+// matchingLine returns the first line of logs that matches pattern, or "". Lines are not limited
+// in length.
+func matchingLine(logs []byte, pattern *regexp.Regexp) string {
+	for len(logs) > 0 {
+		line := logs
+		if i := bytes.IndexByte(logs, '\n'); i >= 0 {
+			line, logs = logs[:i], logs[i+1:]
+		} else {
+			logs = nil
+		}
+		line = bytes.TrimSuffix(line, []byte("\r"))
+		if pattern.Match(line) {
+			return string(line)
+		}
+	}
+	return ""
+}
+
+// DumpLogsOnFailure prints the last DefaultDumpTailLines lines of every container of the Pods selected
+// by sources to the test output when the test has failed by the time it finishes. Errors while
+// fetching logs are printed in their place rather than failing the test again.
+//
+// Example usage:
+//
+//	k8s.DumpLogsOnFailure(t, options, k8s.CertManagerLogs, k8s.FluxSourceControllerLogs)
+//
+// Parameters:
+//   - t: The testing context.
+//   - options: The kubectl options for accessing the Kubernetes cluster.
+//   - sources: The Pods and containers whose logs are printed.
+func DumpLogsOnFailure(t utils.FailureT, options *KubectlOptions, sources ...LogSource) {
+	t.Cleanup(func() {
+		if !t.Failed() {
+			return
+		}
+		var b bytes.Buffer
+		dumpLogs(t, options, &b, sources)
+		logger.Default.Logf(t, "Logs of %d log sources after test failure:\n%s", len(sources), b.String())
+	})
+}
+
+// dumpLogs writes the tail of the logs of every container selected by sources to w.
+func dumpLogs(t testing.TestingT, options *KubectlOptions, w io.Writer, sources []LogSource) {
+	client, err := NewClient(t, options)
+	if err != nil {
+		fmt.Fprintf(w, "creating client: %v\n", err)
+		return
+	}
+
+	ctx := context.Background()
+	for _, source := range sources {
+		pods, err := client.CoreV1().Pods(source.Namespace).List(ctx, metav1.ListOptions{LabelSelector: source.Selector})
+		if err != nil {
+			fmt.Fprintf(w, "==> %s %q: %v\n", source.Namespace, source.Selector, err)
+			continue
+		}
+		if len(pods.Items) == 0 {
+			fmt.Fprintf(w, "==> %s %q: no Pods found\n", source.Namespace, source.Selector)
+			continue
+		}
+		for _, pod := range pods.Items {
+			for _, container := range podContainers(pod, source.Container) {
+				fmt.Fprintf(w, "==> %s/%s[%s] <==\n", pod.Namespace, pod.Name, container)
+				opts := corev1.PodLogOptions{Container: container, TailLines: ptr.To[int64](DefaultDumpTailLines)}
+				logs, err := client.CoreV1().Pods(pod.Namespace).GetLogs(pod.Name, &opts).DoRaw(ctx)
+				if err != nil {
+					fmt.Fprintf(w, "%v\n", err)
+					continue
+				}
+				w.Write(logs)
+				if len(logs) > 0 && logs[len(logs)-1] != '\n' {
+					fmt.Fprintln(w)
+				}
+			}
+		}
+	}
+}
+
+// podContainers returns container when set, or the names of the init and regular containers of pod.
+func podContainers(pod corev1.Pod, container string) []string {
+	if container != "" {
+		return []string{container}
+	}
+	var names []string
+	for _, c := range pod.Spec.InitContainers {
+		names = append(names, c.Name)
+	}
+	for _, c := range pod.Spec.Containers {
+		names = append(names, c.Name)
+	}
+	return names
+}
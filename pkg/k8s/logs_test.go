@@ -0,0 +1,139 @@
+package k8s
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"regexp"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+
+	"github.com/davidcollom/terratest-utils/pkg/wait"
+	"github.com/davidcollom/terratest-utils/pkg/waittest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func controllerPod(name string, containers ...string) *corev1.Pod {
+	pod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "flux-system", Labels: map[string]string{"app": "source-controller"}}}
+	pod.Spec.InitContainers = []corev1.Container{{Name: "init"}}
+	for _, c := range containers {
+		pod.Spec.Containers = append(pod.Spec.Containers, corev1.Container{Name: c})
+	}
+	return pod
+}
+
+// The fake clientset answers every log request with "fake logs".
+
+func TestGetPodLogs(t *testing.T) {
+	NewTestClient(t, controllerPod("source-controller-0", "manager"))
+
+	assert.Equal(t, "fake logs", GetPodLogs(t, &KubectlOptions{}, "source-controller-0", "flux-system", corev1.PodLogOptions{Container: "manager"}))
+}
+
+func TestStreamPodLogs(t *testing.T) {
+	NewTestClient(t, controllerPod("source-controller-0", "manager"))
+
+	t.Run("stream", func(t *testing.T) {
+		StreamPodLogs(t, &KubectlOptions{}, "source-controller-0", "flux-system", corev1.PodLogOptions{Container: "manager"})
+	})
+}
+
+func TestForEachLogLine(t *testing.T) {
+	long := strings.Repeat("x", 1024*1024)
+
+	var lines []string
+	err := forEachLogLine(strings.NewReader("starting\r\n"+long+"\nartifact up-to-date"), func(line string) {
+		lines = append(lines, line)
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"starting", long, "artifact up-to-date"}, lines)
+
+	broken := io.MultiReader(strings.NewReader("starting\n"), iotest.ErrReader(errors.New("stream reset")))
+	err = forEachLogLine(broken, func(string) {})
+	assert.EqualError(t, err, "stream reset")
+}
+
+func TestWaitForLogLine(t *testing.T) {
+	options := &KubectlOptions{Namespace: "flux-system"}
+
+	t.Run("matches", func(t *testing.T) {
+		NewTestClient(t, controllerPod("source-controller-0", "manager"))
+
+		line := WaitForLogLine(t, options, "app=source-controller", "manager", regexp.MustCompile(`^fake`), time.Minute, wait.WithClock(waittest.NewClock()))
+		assert.Equal(t, "fake logs", line)
+	})
+
+	t.Run("no match", func(t *testing.T) {
+		NewTestClient(t, controllerPod("source-controller-0", "manager"))
+
+		_, err := WaitForLogLineE(t, options, "app=source-controller", "manager", regexp.MustCompile(`artifact up-to-date`), time.Minute, wait.WithClock(waittest.NewClock()))
+		require.Error(t, err)
+		assert.True(t, wait.IsTimeout(err))
+	})
+
+	t.Run("no pods", func(t *testing.T) {
+		NewTestClient(t)
+
+		_, err := WaitForLogLineE(t, options, "app=source-controller", "", regexp.MustCompile(`.`), time.Minute, wait.WithClock(waittest.NewClock()))
+		require.Error(t, err)
+		assert.ErrorContains(t, err, `no Pods match "app=source-controller"`)
+	})
+}
+
+func TestFirstMatchingLine(t *testing.T) {
+	pods := []corev1.Pod{*controllerPod("source-controller-0"), *controllerPod("source-controller-1")}
+	pattern := regexp.MustCompile(`artifact up-to-date`)
+
+	t.Run("skips Pods whose logs cannot be read", func(t *testing.T) {
+		line, err := firstMatchingLine(pods, pattern, func(pod corev1.Pod) ([]byte, error) {
+			if pod.Name == "source-controller-0" {
+				return nil, errors.New("container is waiting to start: ContainerCreating")
+			}
+			return []byte("starting\nartifact up-to-date\n"), nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "artifact up-to-date", line)
+	})
+
+	t.Run("no Pod logs readable", func(t *testing.T) {
+		_, err := firstMatchingLine(pods, pattern, func(pod corev1.Pod) ([]byte, error) {
+			return nil, errors.New("evicted")
+		})
+		require.Error(t, err)
+		assert.ErrorContains(t, err, "getting logs of Pod flux-system/source-controller-0: evicted")
+		assert.ErrorContains(t, err, "getting logs of Pod flux-system/source-controller-1: evicted")
+	})
+}
+
+func TestMatchingLine(t *testing.T) {
+	long := strings.Repeat("x", 2*1024*1024)
+	logs := []byte(long + "\r\nartifact up-to-date\r\n")
+
+	assert.Equal(t, "artifact up-to-date", matchingLine(logs, regexp.MustCompile(`^artifact`)))
+	assert.Equal(t, long, matchingLine(logs, regexp.MustCompile(`^x+$`)))
+	assert.Empty(t, matchingLine(logs, regexp.MustCompile(`missing`)))
+}
+
+func TestDumpLogs(t *testing.T) {
+	NewTestClient(t, controllerPod("source-controller-0", "manager"))
+
+	var b bytes.Buffer
+	dumpLogs(t, &KubectlOptions{}, &b, []LogSource{
+		FluxSourceControllerLogs,
+		{Namespace: "flux-system", Selector: "app=source-controller", Container: "manager"},
+		VeleroLogs,
+	})
+	assert.Equal(t, `==> flux-system/source-controller-0[init] <==
+fake logs
+==> flux-system/source-controller-0[manager] <==
+fake logs
+==> flux-system/source-controller-0[manager] <==
+fake logs
+==> velero "component=velero": no Pods found
+`, b.String())
+}
@@ -0,0 +1,201 @@
+package k8s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/logger"
+	"github.com/gruntwork-io/terratest/modules/testing"
+
+	"github.com/davidcollom/terratest-utils/pkg/utils"
+	"github.com/davidcollom/terratest-utils/pkg/wait"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	utilrand "k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/discovery"
+)
+
+// DefaultNamespaceDeleteTimeout is how long the cleanup registered by CreateTestNamespace waits for
+// the namespace to finish Terminating.
+const DefaultNamespaceDeleteTimeout = 5 * time.Minute
+
+// CreateTestNamespace creates a namespace with a unique name starting with prefix and the given
+// labels, failing the test on error, and deletes it when the test finishes. See CreateTestNamespaceE.
+//
+// Example usage:
+//
+//	options := k8s.CreateTestNamespace(t, k8s.NewKubectlOptions("", "", ""), "podinfo", map[string]string{
+//	    "istio-injection": "enabled",
+//	})
+//	k8s.ApplyManifests(t, options, "testdata/podinfo")
+//
+// Parameters:
+//   - t: The testing context, used to register the cleanup.
+//   - options: The kubectl options for accessing the Kubernetes cluster.
+//   - prefix: The start of the namespace's name; a random suffix is appended to it.
+//   - labels: The labels to set on the namespace, e.g. to enable sidecar injection. May be nil.
+//
+// Returns:
+//   - *KubectlOptions: A copy of options scoped to the new namespace.
+func CreateTestNamespace(t utils.CleanupT, options *KubectlOptions, prefix string, labels map[string]string) *KubectlOptions {
+	scoped, err := CreateTestNamespaceE(t, options, prefix, labels)
+	require.NoError(t, err, "Failed to create test namespace with prefix %s", prefix)
+	return scoped
+}
+
+// CreateTestNamespaceE creates a namespace named prefix followed by a random suffix, so that tests
+// running in parallel or against a shared cluster do not collide, and returns a copy of options
+// scoped to it. When the test finishes the namespace is deleted and the cleanup waits up to
+// DefaultNamespaceDeleteTimeout for it to be gone; a namespace that is still Terminating by then
+// fails the test with the finalizers and objects that are holding it up.
+func CreateTestNamespaceE(t utils.CleanupT, options *KubectlOptions, prefix string, labels map[string]string) (*KubectlOptions, error) {
+	client, err := NewClient(t, options)
+	if err != nil {
+		return nil, err
+	}
+
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: testNamespaceName(prefix), Labels: labels}}
+	ns, err = client.CoreV1().Namespaces().Create(context.Background(), ns, metav1.CreateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("creating namespace: %w", err)
+	}
+	logger.Default.Logf(t, "Created test namespace %s", ns.Name)
+
+	t.Cleanup(func() {
+		err := client.CoreV1().Namespaces().Delete(context.Background(), ns.Name, metav1.DeleteOptions{})
+		if apierrors.IsNotFound(err) {
+			return
+		}
+		if err == nil {
+			err = WaitForNamespaceDeletedE(t, options, ns.Name, DefaultNamespaceDeleteTimeout)
+		}
+		if err != nil {
+			t.Errorf("deleting test namespace %s: %v", ns.Name, err)
+		}
+	})
+
+	scoped := *options
+	scoped.Namespace = ns.Name
+	return &scoped, nil
+}
+
+// testNamespaceName returns prefix with a random suffix, shortened to fit a namespace name.
+func testNamespaceName(prefix string) string {
+	const suffixLen = 5
+	prefix = strings.Trim(strings.ToLower(prefix), "-")
+	if limit := 63 - suffixLen - 1; len(prefix) > limit {
+		prefix = strings.TrimRight(prefix[:limit], "-")
+	}
+	if prefix == "" {
+		prefix = "test"
+	}
+	return prefix + "-" + utilrand.String(suffixLen)
+}
+
+// WaitForNamespaceDeleted waits until the specified namespace no longer exists or the timeout is reached, failing the test otherwise.
+//
+// Parameters:
+//   - t: The testing context.
+//   - options: The kubectl options for accessing the Kubernetes cluster.
+//   - name: The name of the namespace.
+//   - timeout: The maximum duration to wait.
+func WaitForNamespaceDeleted(t testing.TestingT, options *KubectlOptions, name string, timeout time.Duration, opts ...wait.WaitOption) {
+	err := WaitForNamespaceDeletedE(t, options, name, timeout, opts...)
+	require.NoError(t, err, "Namespace %s was not deleted in time", name)
+}
+
+// WaitForNamespaceDeletedE waits until the specified namespace has finished Terminating and no
+// longer exists. It does not delete the namespace. On timeout the error lists the namespace's
+// remaining finalizers and conditions, such as NamespaceFinalizersRemaining, followed by the
+// objects left in the namespace that still have finalizers, e.g. a Velero Backup or an
+// ExternalSecret whose controller is no longer running.
+func WaitForNamespaceDeletedE(t testing.TestingT, options *KubectlOptions, name string, timeout time.Duration, opts ...wait.WaitOption) error {
+	client, err := NewClient(t, options)
+	if err != nil {
+		return err
+	}
+
+	_, err = wait.Waiter[*corev1.Namespace]{
+		Kind:         "Namespace",
+		Name:         name,
+		Timeout:      timeout,
+		UntilDeleted: true,
+		Get: func(ctx context.Context) (*corev1.Namespace, error) {
+			return client.CoreV1().Namespaces().Get(ctx, name, metav1.GetOptions{})
+		},
+		Watch: func(ctx context.Context) (watch.Interface, error) {
+			return client.CoreV1().Namespaces().Watch(ctx, metav1.SingleObject(metav1.ObjectMeta{Name: name}))
+		},
+		Events: wait.KubeEvents(t, options),
+	}.Wait(t, opts...)
+	if !wait.IsTimeout(err) {
+		return err
+	}
+
+	blocking, listErr := finalizingObjects(t, options, name)
+	if len(blocking) > 0 {
+		err = fmt.Errorf("%w\n  objects with finalizers:\n    %s", err, strings.Join(blocking, "\n    "))
+	}
+	if listErr != nil {
+		err = fmt.Errorf("%w\n  objects with finalizers unavailable: %v", err, listErr)
+	}
+	return err
+}
+
+// finalizingObjects lists the objects in namespace that have finalizers, formatted as
+// "Kind name: finalizer, ...". Resources that cannot be discovered or listed are skipped and
+// reported in the returned error.
+func finalizingObjects(t testing.TestingT, options *KubectlOptions, namespace string) ([]string, error) {
+	client, err := NewClient(t, options)
+	if err != nil {
+		return nil, err
+	}
+	dynamicClient, err := NewDynamicClient(t, options)
+	if err != nil {
+		return nil, err
+	}
+
+	lists, err := discovery.ServerPreferredNamespacedResources(client.Discovery())
+	var errs []error
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	var objects []string
+	for _, list := range lists {
+		gv, err := schema.ParseGroupVersion(list.GroupVersion)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		for _, resource := range list.APIResources {
+			if strings.Contains(resource.Name, "/") || !slices.Contains(resource.Verbs, "list") {
+				continue
+			}
+			items, err := dynamicClient.Resource(gv.WithResource(resource.Name)).Namespace(namespace).List(ctx, metav1.ListOptions{})
+			if err != nil {
+				errs = append(errs, fmt.Errorf("listing %s: %w", resource.Name, err))
+				continue
+			}
+			for _, item := range items.Items {
+				if finalizers := item.GetFinalizers(); len(finalizers) > 0 {
+					objects = append(objects, fmt.Sprintf("%s %s: %s", resource.Kind, item.GetName(), strings.Join(finalizers, ", ")))
+				}
+			}
+		}
+	}
+	sort.Strings(objects)
+	return objects, errors.Join(errs...)
+}
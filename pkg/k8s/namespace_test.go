@@ -0,0 +1,95 @@
+package k8s
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/davidcollom/terratest-utils/pkg/wait"
+	"github.com/davidcollom/terratest-utils/pkg/waittest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+)
+
+func TestCreateTestNamespace(t *testing.T) {
+	client := NewTestClient(t)
+	options := &KubectlOptions{ContextName: "kind-test"}
+
+	var name string
+	t.Run("test", func(t *testing.T) {
+		scoped := CreateTestNamespace(t, options, "Mesh-Test", map[string]string{"istio-injection": "enabled"})
+		name = scoped.Namespace
+		assert.True(t, strings.HasPrefix(name, "mesh-test-"), "unexpected namespace name %s", name)
+		assert.Equal(t, "kind-test", scoped.ContextName)
+		assert.Empty(t, options.Namespace)
+
+		ns, err := client.CoreV1().Namespaces().Get(context.Background(), name, metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, "enabled", ns.Labels["istio-injection"])
+	})
+
+	_, err := client.CoreV1().Namespaces().Get(context.Background(), name, metav1.GetOptions{})
+	assert.True(t, apierrors.IsNotFound(err), "expected the namespace to be deleted, got %v", err)
+}
+
+func TestTestNamespaceName(t *testing.T) {
+	assert.Regexp(t, `^test-[a-z0-9]{5}$`, testNamespaceName(""))
+	assert.Len(t, testNamespaceName(strings.Repeat("a", 100)), 63)
+}
+
+func TestWaitForNamespaceDeleted(t *testing.T) {
+	terminating := func() *corev1.Namespace {
+		return &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: "backups-x7k2p", DeletionTimestamp: &metav1.Time{Time: time.Now()}},
+			Spec:       corev1.NamespaceSpec{Finalizers: []corev1.FinalizerName{corev1.FinalizerKubernetes}},
+			Status: corev1.NamespaceStatus{
+				Phase: corev1.NamespaceTerminating,
+				Conditions: []corev1.NamespaceCondition{{
+					Type:    corev1.NamespaceFinalizersRemaining,
+					Status:  corev1.ConditionTrue,
+					Reason:  "SomeFinalizersRemain",
+					Message: "Some content in the namespace has finalizers remaining: velero.io/backup-finalizer in 1 resource instances",
+				}},
+			},
+		}
+	}
+
+	t.Run("deleted", func(t *testing.T) {
+		clock := waittest.NewClock()
+		waittest.Prepend(t, NewTestClient(t), "namespaces", waittest.NewScript(clock, "", "backups-x7k2p").
+			Return(terminating()).
+			AtError(40*time.Second, apierrors.NewNotFound(corev1.Resource("namespaces"), "backups-x7k2p")))
+
+		WaitForNamespaceDeleted(t, &KubectlOptions{}, "backups-x7k2p", time.Hour, wait.WithClock(clock))
+		assert.Equal(t, 40*time.Second, clock.Elapsed())
+	})
+
+	t.Run("blocked by finalizers", func(t *testing.T) {
+		client := NewTestClient(t, terminating())
+		client.Discovery().(*fakediscovery.FakeDiscovery).Resources = []*metav1.APIResourceList{{
+			GroupVersion: "velero.io/v1",
+			APIResources: []metav1.APIResource{{Name: "backups", Kind: "Backup", Namespaced: true, Verbs: metav1.Verbs{"get", "list"}}},
+		}}
+		backup := &unstructured.Unstructured{}
+		backup.SetAPIVersion("velero.io/v1")
+		backup.SetKind("Backup")
+		backup.SetName("nightly")
+		backup.SetNamespace("backups-x7k2p")
+		backup.SetFinalizers([]string{"velero.io/backup-finalizer"})
+		NewTestDynamicClient(t, backup)
+
+		err := WaitForNamespaceDeletedE(t, &KubectlOptions{}, "backups-x7k2p", 5*time.Minute, wait.WithClock(waittest.NewClock()))
+		require.True(t, wait.IsTimeout(err), "expected a timeout, got %v", err)
+		msg := err.Error()
+		assert.Contains(t, msg, "waiting for Namespace backups-x7k2p to be deleted")
+		assert.Contains(t, msg, "deletion blocked by finalizers: kubernetes")
+		assert.Contains(t, msg, "NamespaceFinalizersRemaining=True (SomeFinalizersRemain)")
+		assert.Contains(t, msg, "objects with finalizers:\n    Backup nightly: velero.io/backup-finalizer")
+	})
+}
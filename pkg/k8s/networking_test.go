@@ -0,0 +1,141 @@
+package k8s
+
+import (
+	"testing"
+	"time"
+
+	"github.com/davidcollom/terratest-utils/pkg/wait"
+	"github.com/davidcollom/terratest-utils/pkg/waittest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/utils/ptr"
+)
+
+func endpointSlice(name string, ready *bool, addresses ...string) *discoveryv1.EndpointSlice {
+	return &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Labels:    map[string]string{discoveryv1.LabelServiceName: "podinfo"},
+		},
+		Endpoints: []discoveryv1.Endpoint{{Addresses: addresses, Conditions: discoveryv1.EndpointConditions{Ready: ready}}},
+	}
+}
+
+func TestReadyEndpointAddresses(t *testing.T) {
+	slices := []discoveryv1.EndpointSlice{
+		*endpointSlice("a", ptr.To(true), "10.0.0.1"),
+		*endpointSlice("b", ptr.To(false), "10.0.0.2"),
+		*endpointSlice("c", nil, "10.0.0.3"),
+	}
+	assert.Equal(t, []string{"10.0.0.1", "10.0.0.3"}, ReadyEndpointAddresses(slices))
+}
+
+func TestWaitForServiceEndpointsReady(t *testing.T) {
+	service := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "podinfo", Namespace: "default"}}
+
+	t.Run("ready", func(t *testing.T) {
+		clock := waittest.NewClock()
+		other := endpointSlice("other", ptr.To(true), "10.0.1.1")
+		other.Labels[discoveryv1.LabelServiceName] = "other"
+		client := NewTestClient(t, service, other, endpointSlice("podinfo-abc", ptr.To(false), "10.0.0.1")).(*fake.Clientset)
+		// The backend becomes ready a minute into the wait.
+		client.PrependReactor("list", "endpointslices", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			if clock.Elapsed() >= time.Minute {
+				_ = client.Tracker().Update(discoveryv1.SchemeGroupVersion.WithResource("endpointslices"), endpointSlice("podinfo-abc", ptr.To(true), "10.0.0.1"), "default")
+			}
+			return false, nil, nil
+		})
+
+		WaitForServiceEndpointsReady(t, &KubectlOptions{}, "podinfo", "default", time.Hour, wait.WithClock(clock))
+		assert.GreaterOrEqual(t, clock.Elapsed(), time.Minute)
+	})
+
+	t.Run("ExternalName", func(t *testing.T) {
+		external := service.DeepCopy()
+		external.Spec.Type = corev1.ServiceTypeExternalName
+		NewTestClient(t, external)
+
+		err := WaitForServiceEndpointsReadyE(t, &KubectlOptions{}, "podinfo", "default", time.Hour, wait.WithClock(waittest.NewClock()))
+		assert.True(t, wait.IsTerminalState(err), "expected a terminal state error, got %v", err)
+	})
+}
+
+func TestWaitForIngressAddress(t *testing.T) {
+	ingress := func(lb ...networkingv1.IngressLoadBalancerIngress) *networkingv1.Ingress {
+		return &networkingv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Name: "podinfo", Namespace: "default"},
+			Status:     networkingv1.IngressStatus{LoadBalancer: networkingv1.IngressLoadBalancerStatus{Ingress: lb}},
+		}
+	}
+
+	assert.Empty(t, IngressAddress(ingress()))
+	assert.Equal(t, "203.0.113.10", IngressAddress(ingress(networkingv1.IngressLoadBalancerIngress{Hostname: "lb.example.com"}, networkingv1.IngressLoadBalancerIngress{IP: "203.0.113.10"})))
+
+	clock := waittest.NewClock()
+	waittest.Prepend(t, NewTestClient(t), "ingresses", waittest.NewScript(clock, "default", "podinfo").
+		Return(ingress()).
+		At(2*time.Minute, ingress(networkingv1.IngressLoadBalancerIngress{Hostname: "lb.example.com"})))
+
+	assert.Equal(t, "lb.example.com", WaitForIngressAddress(t, &KubectlOptions{}, "podinfo", "default", time.Hour, wait.WithClock(clock)))
+	assert.Equal(t, 2*time.Minute, clock.Elapsed())
+}
+
+func TestNetworkPolicyAssertions(t *testing.T) {
+	frontend := ProbePod{Namespace: "shop", Labels: map[string]string{"app": "frontend"}}
+	services := []runtime.Object{
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "cart", Namespace: "shop"}, Spec: corev1.ServiceSpec{ClusterIP: "10.96.0.10"}},
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "postgres", Namespace: "db"}, Spec: corev1.ServiceSpec{ClusterIP: "10.96.0.20"}},
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "postgres-headless", Namespace: "db"}, Spec: corev1.ServiceSpec{ClusterIP: corev1.ClusterIPNone}},
+	}
+	probe := func(t *testing.T, executor fakeExecutor) *[]*corev1.PodExecOptions {
+		client := NewTestClient(t, services...).(*fake.Clientset)
+		client.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			pod := action.(k8stesting.CreateAction).GetObject().(*corev1.Pod)
+			pod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+			return false, nil, nil
+		})
+		return useFakeExecutor(t, executor)
+	}
+
+	t.Run("allowed", func(t *testing.T) {
+		calls := probe(t, fakeExecutor{})
+		assert.True(t, AssertNetworkPolicyAllows(t, &KubectlOptions{}, frontend, "cart", 8080, wait.WithClock(waittest.NewClock())))
+		require.Len(t, *calls, 1)
+		assert.Equal(t, []string{"nc", "-z", "-w", "5", "10.96.0.10", "8080"}, (*calls)[0].Command)
+	})
+
+	t.Run("denied", func(t *testing.T) {
+		calls := probe(t, fakeExecutor{code: 1})
+		assert.True(t, AssertNetworkPolicyDenies(t, &KubectlOptions{}, frontend, "postgres.db", 5432, wait.WithClock(waittest.NewClock())))
+		require.Len(t, *calls, 1)
+		assert.Equal(t, []string{"nc", "-z", "-w", "5", "10.96.0.20", "5432"}, (*calls)[0].Command)
+	})
+
+	t.Run("probe without nc", func(t *testing.T) {
+		probe(t, fakeExecutor{stderr: `exec: "nc": executable file not found in $PATH`, code: 127})
+		_, err := CanConnectE(t, &KubectlOptions{}, frontend, "postgres.db", 5432, wait.WithClock(waittest.NewClock()))
+		assert.ErrorContains(t, err, "nc exited with code 127")
+		assert.ErrorContains(t, err, "a Pod labelled app=frontend in namespace shop")
+	})
+
+	t.Run("missing service", func(t *testing.T) {
+		calls := probe(t, fakeExecutor{code: 1})
+		_, err := CanConnectE(t, &KubectlOptions{}, frontend, "postgress.db", 5432, wait.WithClock(waittest.NewClock()))
+		assert.ErrorContains(t, err, "looking up Service postgress.db")
+		assert.Empty(t, *calls)
+	})
+
+	t.Run("headless service", func(t *testing.T) {
+		probe(t, fakeExecutor{code: 1})
+		_, err := CanConnectE(t, &KubectlOptions{}, frontend, "postgres-headless.db.svc.cluster.local", 5432, wait.WithClock(waittest.NewClock()))
+		assert.ErrorContains(t, err, "no cluster IP to probe for Service db/postgres-headless")
+	})
+}
@@ -0,0 +1,153 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/testing"
+
+	"github.com/davidcollom/terratest-utils/pkg/wait"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	// DefaultNetworkProbeImage is the image of the probe Pod CanConnectE starts when from.Image is
+	// empty. Its nc supports the -z and -w flags the probe relies on.
+	DefaultNetworkProbeImage = "busybox:1.36"
+	// DefaultNetworkProbeTimeout bounds how long CanConnectE waits for its probe Pod to start and
+	// finish.
+	DefaultNetworkProbeTimeout = 2 * time.Minute
+	// networkProbeConnectTimeout is how long the probe waits for a TCP connection before treating
+	// the destination as unreachable. NetworkPolicies usually drop, rather than reject, traffic.
+	networkProbeConnectTimeout = 5 * time.Second
+)
+
+// AssertNetworkPolicyAllows checks that a Pod described by from can open a TCP connection to port of
+// toService, failing the test otherwise. See CanConnectE.
+//
+// Example usage:
+//
+//	frontend := k8s.ProbePod{Namespace: "shop", Labels: map[string]string{"app": "frontend"}}
+//	k8s.AssertNetworkPolicyAllows(t, options, frontend, "cart", 8080)
+//	k8s.AssertNetworkPolicyDenies(t, options, frontend, "postgres.db", 5432)
+//
+// Parameters:
+//   - t: The testing context.
+//   - options: The kubectl options for accessing the Kubernetes cluster.
+//   - from: The namespace and labels the policies under test select the source Pod by.
+//   - toService: The Service, as "name" in from's namespace or "name.namespace".
+//   - port: The Service port.
+//
+// Returns:
+//   - bool: True if the connection succeeded.
+func AssertNetworkPolicyAllows(t testing.TestingT, options *KubectlOptions, from ProbePod, toService string, port int, opts ...wait.WaitOption) bool {
+	connected, err := CanConnectE(t, options, from, toService, port, opts...)
+	if !assert.NoError(t, err) {
+		return false
+	}
+	return assert.True(t, connected, "%s cannot connect to %s:%d", describeProbe(options, from), toService, port)
+}
+
+// AssertNetworkPolicyDenies checks that a Pod described by from cannot open a TCP connection to port
+// of toService, failing the test otherwise. See CanConnectE.
+//
+// Returns:
+//   - bool: True if the connection failed.
+func AssertNetworkPolicyDenies(t testing.TestingT, options *KubectlOptions, from ProbePod, toService string, port int, opts ...wait.WaitOption) bool {
+	connected, err := CanConnectE(t, options, from, toService, port, opts...)
+	if !assert.NoError(t, err) {
+		return false
+	}
+	return assert.False(t, connected, "%s can connect to %s:%d", describeProbe(options, from), toService, port)
+}
+
+// CanConnectE reports whether a Pod with from's namespace, labels and ServiceAccount can open a TCP
+// connection to port of toService. It looks toService up through the API and runs `nc -z` against
+// its cluster IP from a throwaway probe Pod with RunEphemeralProbePodE, so the NetworkPolicies that
+// select from's labels apply to it as they would to the workload it stands in for; Command is
+// ignored and Image defaults to DefaultNetworkProbeImage.
+//
+// Only nc's exit code 1, a refused or timed out connection, counts as denied. It returns an error
+// when toService does not exist or has no cluster IP, when the probe cannot be run, and for any
+// other exit code, e.g. 127 from an image without nc, so that a broken probe does not pass as a
+// denial.
+//
+// Only reachability at the TCP level is checked. Policies enforced at the application layer, such
+// as Istio AuthorizationPolicies for HTTP, let the connection through; check those with an HTTP
+// request from RunEphemeralProbePod.
+func CanConnectE(t testing.TestingT, options *KubectlOptions, from ProbePod, toService string, port int, opts ...wait.WaitOption) (bool, error) {
+	clusterIP, err := serviceClusterIP(t, options, probeNamespace(options, from), toService)
+	if err != nil {
+		return false, err
+	}
+
+	if from.Image == "" {
+		from.Image = DefaultNetworkProbeImage
+	}
+	from.Command = []string{"nc", "-z", "-w", strconv.Itoa(int(networkProbeConnectTimeout.Seconds())), clusterIP, strconv.Itoa(port)}
+
+	result, err := RunEphemeralProbePodE(t, options, from, DefaultNetworkProbeTimeout, opts...)
+	if err != nil {
+		return false, err
+	}
+	switch result.ExitCode {
+	case 0:
+		return true, nil
+	case networkProbeRefusedExitCode:
+		return false, nil
+	}
+	return false, fmt.Errorf("probing %s (%s:%d) from %s: nc exited with code %d: %s",
+		toService, clusterIP, port, describeProbe(options, from), result.ExitCode, strings.TrimSpace(result.Stderr))
+}
+
+// networkProbeRefusedExitCode is the exit code of nc when the connection fails or times out.
+const networkProbeRefusedExitCode = 1
+
+// serviceClusterIP returns the cluster IP of toService, given as "name" in namespace or
+// "name.namespace", so that the probe does not depend on DNS.
+func serviceClusterIP(t testing.TestingT, options *KubectlOptions, namespace, toService string) (string, error) {
+	name, serviceNamespace, found := strings.Cut(toService, ".")
+	if found {
+		namespace, _, _ = strings.Cut(serviceNamespace, ".")
+	}
+
+	client, err := NewClient(t, options)
+	if err != nil {
+		return "", err
+	}
+	service, err := client.CoreV1().Services(namespace).Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("looking up Service %s: %w", toService, err)
+	}
+	if service.Spec.ClusterIP == "" || service.Spec.ClusterIP == corev1.ClusterIPNone {
+		return "", fmt.Errorf("no cluster IP to probe for Service %s/%s", namespace, name)
+	}
+	return service.Spec.ClusterIP, nil
+}
+
+// probeNamespace returns the namespace the probe Pod described by from runs in.
+func probeNamespace(options *KubectlOptions, from ProbePod) string {
+	if from.Namespace != "" {
+		return from.Namespace
+	}
+	if options.Namespace != "" {
+		return options.Namespace
+	}
+	return "default"
+}
+
+// describeProbe describes the source Pod of a connection in assertion messages.
+func describeProbe(options *KubectlOptions, from ProbePod) string {
+	namespace := probeNamespace(options, from)
+	if len(from.Labels) == 0 {
+		return "a Pod in namespace " + namespace
+	}
+	return fmt.Sprintf("a Pod labelled %s in namespace %s", labels.Set(from.Labels), namespace)
+}
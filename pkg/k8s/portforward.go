@@ -0,0 +1,481 @@
+package k8s
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/logger"
+	"github.com/gruntwork-io/terratest/modules/testing"
+
+	"github.com/davidcollom/terratest-utils/pkg/utils"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/client-go/tools/portforward"
+	"k8s.io/client-go/transport/spdy"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// NewPortForwardDialer creates the dialer that opens port-forward streams to a Pod through the
+// portforward subresource. It uses the REST config from utils.GetRestConfigE and, like kubectl,
+// prefers tunneling over WebSockets, falling back to SPDY when the API server or a proxy in between
+// does not support it.
+var NewPortForwardDialer = newPortForwardDialer
+
+func newPortForwardDialer(t testing.TestingT, options *KubectlOptions, name, namespace string) (httpstream.Dialer, error) {
+	cfg, err := utils.GetRestConfigE(t, options)
+	if err != nil {
+		return nil, err
+	}
+	client, err := NewClient(t, options)
+	if err != nil {
+		return nil, err
+	}
+
+	url := client.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(namespace).
+		Name(name).
+		SubResource("portforward").
+		URL()
+	transport, upgrader, err := spdy.RoundTripperFor(cfg)
+	if err != nil {
+		return nil, err
+	}
+	dialer := spdy.NewDialer(upgrader, &http.Client{Transport: transport}, "POST", url)
+	tunneling, err := portforward.NewSPDYOverWebsocketDialer(url, cfg)
+	if err != nil {
+		return nil, err
+	}
+	return portforward.NewFallbackDialer(tunneling, dialer, func(err error) bool {
+		return httpstream.IsUpgradeFailure(err) || httpstream.IsHTTPSProxyError(err)
+	}), nil
+}
+
+// podForward is a running port-forward from a local port to a port of one Pod.
+type podForward struct {
+	pod       string
+	localPort uint16
+	// done is closed when the forward stops, e.g. because the Pod went away.
+	done <-chan struct{}
+	stop func()
+}
+
+// forwardToPod starts forwarding a free local port to port of a Pod, giving up when ctx is done
+// before the forward is ready.
+var forwardToPod = newPodForward
+
+func newPodForward(ctx context.Context, t testing.TestingT, options *KubectlOptions, name, namespace string, port int) (*podForward, error) {
+	dialer, err := NewPortForwardDialer(t, options, name, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	stop, ready := make(chan struct{}), make(chan struct{})
+	forwarder, err := portforward.NewOnAddresses(dialer, []string{"127.0.0.1"}, []string{fmt.Sprintf("0:%d", port)}, stop, ready, io.Discard, io.Discard)
+	if err != nil {
+		return nil, err
+	}
+	done, errs := make(chan struct{}), make(chan error, 1)
+	go func() {
+		defer close(done)
+		errs <- forwarder.ForwardPorts()
+	}()
+	select {
+	case <-ready:
+	case err := <-errs:
+		return nil, fmt.Errorf("port-forwarding to Pod %s/%s: %w", namespace, name, err)
+	case <-ctx.Done():
+		close(stop)
+		return nil, fmt.Errorf("port-forwarding to Pod %s/%s: %w", namespace, name, ctx.Err())
+	}
+
+	ports, err := forwarder.GetPorts()
+	if err != nil {
+		close(stop)
+		return nil, err
+	}
+	return &podForward{pod: name, localPort: ports[0].Local, done: done, stop: sync.OnceFunc(func() { close(stop) })}, nil
+}
+
+// portForward listens on a local address and forwards every connection to a Pod chosen by resolve.
+// The Pod is resolved again whenever its forward has stopped, so the forward survives Pod restarts.
+type portForward struct {
+	t         testing.TestingT
+	options   *KubectlOptions
+	namespace string
+	// resolve chooses the Pod and its port to forward to.
+	resolve func(ctx context.Context) (pod string, port int, err error)
+
+	listener net.Listener
+	handlers sync.WaitGroup
+
+	// ctx is cancelled by close, aborting a reconnect in progress.
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu      sync.Mutex
+	current *podForward
+	// reconnected is closed when the reconnect in progress finishes; nil when there is none.
+	reconnected chan struct{}
+	conns       map[net.Conn]struct{}
+	closed      bool
+}
+
+// forwardConnectTimeout bounds choosing a Pod and connecting to it.
+const forwardConnectTimeout = 30 * time.Second
+
+// PortForwardPod forwards a local port to remotePort of the Pod name in the namespace of options,
+// failing the test if the Pod cannot be reached. The forward is closed when the test finishes, or
+// earlier by calling the returned function. See PortForwardPodE.
+//
+// Example usage:
+//
+//	addr, closeFn := k8s.PortForwardPod(t, options, "velero-7d9c8b6f5-x2kqz", 8085)
+//	defer closeFn()
+//	resp, err := http.Get("http://" + addr + "/metrics")
+//
+// Parameters:
+//   - t: The testing context.
+//   - options: The kubectl options for accessing the Kubernetes cluster.
+//   - name: The name of the Pod.
+//   - remotePort: The port of the Pod to forward to.
+//
+// Returns:
+//   - string: The local "127.0.0.1:port" address that forwards to the Pod.
+//   - func(): Closes the forward.
+func PortForwardPod(t utils.CleanupT, options *KubectlOptions, name string, remotePort int) (string, func()) {
+	addr, closeFn, err := PortForwardPodE(t, options, name, remotePort)
+	require.NoError(t, err)
+	return addr, closeFn
+}
+
+// PortForwardPodE forwards a local port to remotePort of the Pod name in the namespace of options. The
+// connection to the Pod is established up front; when it is lost, the next connection to the local
+// address re-establishes it, so the forward survives a restart of the Pod's containers or the
+// recreation of a Pod of the same name, e.g. of a StatefulSet.
+func PortForwardPodE(t utils.CleanupT, options *KubectlOptions, name string, remotePort int) (string, func(), error) {
+	return startPortForward(t, options, func(ctx context.Context) (string, int, error) {
+		return name, remotePort, nil
+	})
+}
+
+// PortForwardService forwards a local port to remotePort of the Service name in the namespace of
+// options, failing the test if the Service cannot be reached. The forward is closed when the test
+// finishes, or earlier by calling the returned function. See PortForwardServiceE.
+//
+// Example usage:
+//
+//	addr, _ := k8s.PortForwardService(t, k8s.NewKubectlOptions("", "", "argocd"), "argocd-server", 443)
+//	client := k8s.ForwardedHTTPClient(addr, &tls.Config{InsecureSkipVerify: true})
+//	resp, err := client.Get("https://argocd-server.argocd/api/version")
+//
+// Parameters:
+//   - t: The testing context.
+//   - options: The kubectl options for accessing the Kubernetes cluster.
+//   - name: The name of the Service.
+//   - remotePort: The port of the Service to forward to.
+//
+// Returns:
+//   - string: The local "127.0.0.1:port" address that forwards to the Service.
+//   - func(): Closes the forward.
+func PortForwardService(t utils.CleanupT, options *KubectlOptions, name string, remotePort int) (string, func()) {
+	addr, closeFn, err := PortForwardServiceE(t, options, name, remotePort)
+	require.NoError(t, err)
+	return addr, closeFn
+}
+
+// PortForwardServiceE forwards a local port to remotePort of the Service name in the namespace of
+// options. As kubectl does, it forwards to one ready Pod selected by the Service, on the container
+// port the Service port targets. When the connection to that Pod is lost, the next connection to the
+// local address picks a ready Pod again, so the forward survives rollouts and Pod restarts.
+func PortForwardServiceE(t utils.CleanupT, options *KubectlOptions, name string, remotePort int) (string, func(), error) {
+	client, err := NewClient(t, options)
+	if err != nil {
+		return "", nil, err
+	}
+
+	namespace := forwardNamespace(options)
+	return startPortForward(t, options, func(ctx context.Context) (string, int, error) {
+		svc, err := client.CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return "", 0, err
+		}
+		if len(svc.Spec.Selector) == 0 {
+			return "", 0, fmt.Errorf("service %s/%s has no selector", svc.Namespace, name)
+		}
+		pods, err := client.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{LabelSelector: labels.SelectorFromSet(svc.Spec.Selector).String()})
+		if err != nil {
+			return "", 0, err
+		}
+		for _, pod := range pods.Items {
+			if pod.DeletionTimestamp == nil && isPodReady(&pod) {
+				port, err := serviceTargetPort(svc, &pod, remotePort)
+				return pod.Name, port, err
+			}
+		}
+		return "", 0, fmt.Errorf("no ready Pods for Service %s/%s", svc.Namespace, name)
+	})
+}
+
+// serviceTargetPort returns the container port of pod that port of svc targets.
+func serviceTargetPort(svc *corev1.Service, pod *corev1.Pod, port int) (int, error) {
+	for _, sp := range svc.Spec.Ports {
+		if int(sp.Port) != port {
+			continue
+		}
+		if sp.TargetPort.IntValue() != 0 {
+			return sp.TargetPort.IntValue(), nil
+		}
+		if sp.TargetPort.StrVal == "" {
+			return port, nil
+		}
+		for _, c := range pod.Spec.Containers {
+			for _, cp := range c.Ports {
+				if cp.Name == sp.TargetPort.StrVal {
+					return int(cp.ContainerPort), nil
+				}
+			}
+		}
+		return 0, fmt.Errorf("pod %s/%s has no container port named %q", pod.Namespace, pod.Name, sp.TargetPort.StrVal)
+	}
+	return 0, fmt.Errorf("service %s/%s has no port %d", svc.Namespace, svc.Name, port)
+}
+
+// startPortForward listens on a free local port, connects to the Pod chosen by resolve and serves
+// connections in the background until the returned function is called or the test finishes.
+func startPortForward(t utils.CleanupT, options *KubectlOptions, resolve func(ctx context.Context) (string, int, error)) (string, func(), error) {
+	f := &portForward{t: t, options: options, namespace: forwardNamespace(options), resolve: resolve, conns: map[net.Conn]struct{}{}}
+	f.ctx, f.cancel = context.WithCancel(context.Background())
+	if _, err := f.forward(); err != nil {
+		f.close()
+		return "", nil, err
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		f.close()
+		return "", nil, err
+	}
+	f.listener = listener
+	go f.serve()
+
+	closeFn := sync.OnceFunc(f.close)
+	t.Cleanup(closeFn)
+	return listener.Addr().String(), closeFn, nil
+}
+
+// forwardNamespace returns the namespace of options, or "default".
+func forwardNamespace(options *KubectlOptions) string {
+	if options.Namespace == "" {
+		return "default"
+	}
+	return options.Namespace
+}
+
+// forward returns the current forward, starting a new one when there is none or it has stopped.
+// Only one caller reconnects at a time, without holding f.mu, so a slow or hung reconnect does not
+// block accepting connections or closing the forward; the other callers wait for it to finish.
+func (f *portForward) forward() (*podForward, error) {
+	for {
+		f.mu.Lock()
+		if f.closed {
+			f.mu.Unlock()
+			return nil, net.ErrClosed
+		}
+		if f.current != nil {
+			select {
+			case <-f.current.done:
+				logger.Default.Logf(f.t, "Lost port-forward to Pod %s/%s, reconnecting", f.namespace, f.current.pod)
+				f.current = nil
+			default:
+				current := f.current
+				f.mu.Unlock()
+				return current, nil
+			}
+		}
+		if reconnected := f.reconnected; reconnected != nil {
+			f.mu.Unlock()
+			select {
+			case <-reconnected:
+				continue
+			case <-f.ctx.Done():
+				return nil, net.ErrClosed
+			}
+		}
+		reconnected := make(chan struct{})
+		f.reconnected = reconnected
+		f.mu.Unlock()
+
+		current, err := f.connect()
+
+		f.mu.Lock()
+		f.reconnected = nil
+		close(reconnected)
+		if err == nil && f.closed {
+			current.stop()
+			err = net.ErrClosed
+		}
+		if err == nil {
+			f.current = current
+		}
+		f.mu.Unlock()
+		if err != nil {
+			return nil, err
+		}
+		return current, nil
+	}
+}
+
+// connect chooses a Pod with resolve and starts forwarding to it, giving up after
+// forwardConnectTimeout or when the forward is closed.
+func (f *portForward) connect() (*podForward, error) {
+	ctx, cancel := context.WithTimeout(f.ctx, forwardConnectTimeout)
+	defer cancel()
+
+	pod, port, err := f.resolve(ctx)
+	if err != nil {
+		return nil, err
+	}
+	current, err := forwardToPod(ctx, f.t, f.options, pod, f.namespace, port)
+	if err != nil {
+		return nil, err
+	}
+	logger.Default.Logf(f.t, "Port-forwarding to Pod %s/%s port %d", f.namespace, pod, port)
+	return current, nil
+}
+
+// serve accepts connections until the listener is closed.
+func (f *portForward) serve() {
+	for {
+		conn, err := f.listener.Accept()
+		if err != nil {
+			return
+		}
+		f.mu.Lock()
+		if f.closed {
+			f.mu.Unlock()
+			conn.Close()
+			return
+		}
+		f.conns[conn] = struct{}{}
+		f.handlers.Add(1)
+		f.mu.Unlock()
+
+		go func() {
+			defer f.handlers.Done()
+			f.handle(conn)
+			f.mu.Lock()
+			delete(f.conns, conn)
+			f.mu.Unlock()
+		}()
+	}
+}
+
+// handle copies data between conn and the Pod until either side closes.
+func (f *portForward) handle(conn net.Conn) {
+	defer conn.Close()
+
+	upstream, err := f.dial()
+	if err != nil {
+		if !errors.Is(err, net.ErrClosed) {
+			logger.Default.Logf(f.t, "Port-forward connection failed: %v", err)
+		}
+		return
+	}
+	defer upstream.Close()
+
+	done := make(chan struct{}, 2)
+	go func() {
+		_, _ = io.Copy(upstream, conn)
+		done <- struct{}{}
+	}()
+	go func() {
+		_, _ = io.Copy(conn, upstream)
+		done <- struct{}{}
+	}()
+	<-done
+}
+
+// dial connects to the local end of the current forward, reconnecting once if it has gone stale.
+func (f *portForward) dial() (net.Conn, error) {
+	var err error
+	for range 2 {
+		var current *podForward
+		current, err = f.forward()
+		if err != nil {
+			return nil, err
+		}
+		var conn net.Conn
+		conn, err = net.Dial("tcp", fmt.Sprintf("127.0.0.1:%d", current.localPort))
+		if err == nil {
+			return conn, nil
+		}
+		current.stop()
+		<-current.done
+	}
+	return nil, err
+}
+
+// close stops accepting connections, closes the open ones and stops the forward.
+func (f *portForward) close() {
+	f.cancel()
+	f.mu.Lock()
+	f.closed = true
+	for conn := range f.conns {
+		conn.Close()
+	}
+	if f.current != nil {
+		f.current.stop()
+	}
+	f.mu.Unlock()
+
+	if f.listener != nil {
+		f.listener.Close()
+		f.handlers.Wait()
+	}
+}
+
+// ForwardedHTTPClient returns an *http.Client that sends every request to localAddr, whatever the
+// host of the request URL. Requests can therefore use the in-cluster name of the service, which is
+// also the server name HTTPS requests verify. tlsConfig configures HTTPS requests and may be nil.
+//
+// Example usage:
+//
+//	addr, _ := k8s.PortForwardService(t, options, "istio-ingressgateway", 80)
+//	client := k8s.ForwardedHTTPClient(addr, nil)
+//	resp, err := client.Get("http://podinfo.example.com/")
+func ForwardedHTTPClient(localAddr string, tlsConfig *tls.Config) *http.Client {
+	dialer := &net.Dialer{}
+	return &http.Client{Transport: &http.Transport{
+		DialContext: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			return dialer.DialContext(ctx, network, localAddr)
+		},
+		TLSClientConfig: tlsConfig,
+	}}
+}
+
+// ForwardedGRPCConn returns a *grpc.ClientConn to localAddr. It uses insecure transport credentials
+// unless opts set others, e.g. grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)).
+//
+// Example usage:
+//
+//	addr, _ := k8s.PortForwardService(t, options, "argocd-repo-server", 8081)
+//	conn, err := k8s.ForwardedGRPCConn(addr)
+//	require.NoError(t, err)
+//	defer conn.Close()
+func ForwardedGRPCConn(localAddr string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+	opts = append([]grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}, opts...)
+	return grpc.NewClient("passthrough:///"+localAddr, opts...)
+}
@@ -0,0 +1,195 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/k8s"
+	terratesting "github.com/gruntwork-io/terratest/modules/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// fakeForwards stands in for port-forwards to Pods: each Pod is served by a local listener, and a
+// forward to a Pod connects to that listener.
+type fakeForwards struct {
+	mu       sync.Mutex
+	addrs    map[string]string
+	forwards map[string]chan struct{}
+	started  []string
+}
+
+func useFakeForwards(t *testing.T, addrs map[string]string) *fakeForwards {
+	f := &fakeForwards{addrs: addrs, forwards: map[string]chan struct{}{}}
+	forwardToPod = func(ctx context.Context, t terratesting.TestingT, options *k8s.KubectlOptions, name, namespace string, port int) (*podForward, error) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		addr, ok := f.addrs[fmt.Sprintf("%s/%s:%d", namespace, name, port)]
+		if !ok {
+			return nil, fmt.Errorf("no Pod %s/%s listening on %d", namespace, name, port)
+		}
+		_, portStr, _ := net.SplitHostPort(addr)
+		localPort, _ := strconv.Atoi(portStr)
+		done := make(chan struct{})
+		f.forwards[name] = done
+		f.started = append(f.started, name)
+		return &podForward{pod: name, localPort: uint16(localPort), done: done, stop: sync.OnceFunc(func() { close(done) })}, nil
+	}
+	t.Cleanup(func() { forwardToPod = newPodForward })
+	return f
+}
+
+// lose simulates the connection to a Pod being lost.
+func (f *fakeForwards) lose(pod string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	close(f.forwards[pod])
+}
+
+func serveText(t *testing.T, text string) string {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, text)
+	}))
+	t.Cleanup(server.Close)
+	return server.Listener.Addr().String()
+}
+
+func get(t *testing.T, client *http.Client, url string) string {
+	resp, err := client.Get(url)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return string(body)
+}
+
+func TestPortForwardPod(t *testing.T) {
+	useFakeForwards(t, map[string]string{"velero/velero-0:8085": serveText(t, "velero_backup_total 1")})
+
+	addr, closeFn := PortForwardPod(t, &KubectlOptions{Namespace: "velero"}, "velero-0", 8085)
+	client := ForwardedHTTPClient(addr, nil)
+	assert.Equal(t, "velero_backup_total 1", get(t, client, "http://velero.velero:8085/metrics"))
+
+	closeFn()
+	client.CloseIdleConnections()
+	_, err := client.Get("http://velero.velero:8085/metrics")
+	assert.Error(t, err, "the forward is closed")
+
+	_, _, err = PortForwardPodE(t, &KubectlOptions{Namespace: "velero"}, "velero-1", 8085)
+	assert.ErrorContains(t, err, "no Pod velero/velero-1 listening on 8085")
+}
+
+func TestPortForwardServiceReconnects(t *testing.T) {
+	pod := func(name string, ready corev1.ConditionStatus) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "argocd", Labels: map[string]string{"app": "argocd-server"}},
+			Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "server", Ports: []corev1.ContainerPort{{Name: "https", ContainerPort: 8080}}}}},
+			Status:     corev1.PodStatus{Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: ready}}},
+		}
+	}
+	client := NewTestClient(t,
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "argocd-server", Namespace: "argocd"},
+			Spec: corev1.ServiceSpec{
+				Selector: map[string]string{"app": "argocd-server"},
+				Ports:    []corev1.ServicePort{{Port: 443, TargetPort: intstr.FromString("https")}},
+			},
+		},
+		pod("argocd-server-a", corev1.ConditionTrue),
+		pod("argocd-server-b", corev1.ConditionFalse),
+	)
+	forwards := useFakeForwards(t, map[string]string{
+		"argocd/argocd-server-a:8080": serveText(t, "a"),
+		"argocd/argocd-server-b:8080": serveText(t, "b"),
+	})
+
+	addr, _ := PortForwardService(t, &KubectlOptions{Namespace: "argocd"}, "argocd-server", 443)
+	httpClient := ForwardedHTTPClient(addr, nil)
+	assert.Equal(t, "a", get(t, httpClient, "http://argocd-server.argocd/"), "only ready Pods are forwarded to")
+
+	// Roll the Deployment: a goes away and b becomes ready.
+	require.NoError(t, client.CoreV1().Pods("argocd").Delete(context.Background(), "argocd-server-a", metav1.DeleteOptions{}))
+	_, err := client.CoreV1().Pods("argocd").UpdateStatus(context.Background(), pod("argocd-server-b", corev1.ConditionTrue), metav1.UpdateOptions{})
+	require.NoError(t, err)
+	forwards.lose("argocd-server-a")
+	httpClient.CloseIdleConnections()
+
+	assert.Equal(t, "b", get(t, httpClient, "http://argocd-server.argocd/"))
+	assert.Equal(t, []string{"argocd-server-a", "argocd-server-b"}, forwards.started)
+
+	_, _, err = PortForwardServiceE(t, &KubectlOptions{Namespace: "argocd"}, "argocd-server", 80)
+	assert.ErrorContains(t, err, "service argocd/argocd-server has no port 80")
+}
+
+func TestPortForwardCloseDuringReconnect(t *testing.T) {
+	forwards := useFakeForwards(t, map[string]string{"velero/velero-0:8085": serveText(t, "velero_backup_total 1")})
+	resolving := make(chan struct{}, 1)
+	reconnectErr := make(chan error, 1)
+	resolved := 0
+	addr, closeFn, err := startPortForward(t, &KubectlOptions{Namespace: "velero"}, func(ctx context.Context) (string, int, error) {
+		resolved++
+		if resolved == 1 {
+			return "velero-0", 8085, nil
+		}
+		// The API server does not answer while reconnecting.
+		resolving <- struct{}{}
+		<-ctx.Done()
+		reconnectErr <- ctx.Err()
+		return "", 0, ctx.Err()
+	})
+	require.NoError(t, err)
+	forwards.lose("velero-0")
+
+	// The first connection reconnects and the second waits for it.
+	for range 2 {
+		conn, err := net.Dial("tcp", addr)
+		require.NoError(t, err)
+		defer conn.Close()
+	}
+	<-resolving
+
+	closed := make(chan struct{})
+	go func() {
+		closeFn()
+		close(closed)
+	}()
+	select {
+	case <-closed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("closing the forward waited for the hung reconnect")
+	}
+	assert.ErrorIs(t, <-reconnectErr, context.Canceled)
+}
+
+func TestForwardedGRPCConn(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer()
+	healthpb.RegisterHealthServer(server, health.NewServer())
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+	useFakeForwards(t, map[string]string{"argocd/argocd-repo-server-0:8081": listener.Addr().String()})
+
+	addr, _ := PortForwardPod(t, &KubectlOptions{Namespace: "argocd"}, "argocd-repo-server-0", 8081)
+	conn, err := ForwardedGRPCConn(addr)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	resp, err := healthpb.NewHealthClient(conn).Check(context.Background(), &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.Status)
+}
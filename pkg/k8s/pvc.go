@@ -0,0 +1,76 @@
+package k8s
+
+import (
+	"context"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/testing"
+
+	"github.com/davidcollom/terratest-utils/pkg/wait"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// WaitForPersistentVolumeClaimBound waits until the specified PersistentVolumeClaim is Bound or the timeout is reached, failing the test otherwise.
+//
+// Parameters:
+//   - t: The testing context.
+//   - options: The kubectl options for accessing the Kubernetes cluster.
+//   - name: The name of the PersistentVolumeClaim.
+//   - namespace: The namespace where the PersistentVolumeClaim is located.
+//   - timeout: The maximum duration to wait.
+func WaitForPersistentVolumeClaimBound(t testing.TestingT, options *KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) {
+	err := WaitForPersistentVolumeClaimBoundE(t, options, name, namespace, timeout, opts...)
+	require.NoError(t, err, "PersistentVolumeClaim %s/%s was not Bound in time", namespace, name)
+}
+
+// WaitForPersistentVolumeClaimBoundE waits until the specified PersistentVolumeClaim is Bound to a
+// PersistentVolume. It returns a *wait.TerminalStateError as soon as the claim is Lost. Claims of a
+// StorageClass with the WaitForFirstConsumer binding mode stay Pending until a Pod uses them, so
+// create that Pod before waiting.
+func WaitForPersistentVolumeClaimBoundE(t testing.TestingT, options *KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) error {
+	client, err := NewClient(t, options)
+	if err != nil {
+		return err
+	}
+
+	_, err = wait.Waiter[*corev1.PersistentVolumeClaim]{
+		Kind:      "PersistentVolumeClaim",
+		Name:      name,
+		Namespace: namespace,
+		Timeout:   timeout,
+		Get: func(ctx context.Context) (*corev1.PersistentVolumeClaim, error) {
+			return client.CoreV1().PersistentVolumeClaims(namespace).Get(ctx, name, metav1.GetOptions{})
+		},
+		Watch: func(ctx context.Context) (watch.Interface, error) {
+			return client.CoreV1().PersistentVolumeClaims(namespace).Watch(ctx, metav1.SingleObject(metav1.ObjectMeta{Name: name}))
+		},
+		Ready:  IsPersistentVolumeClaimBound,
+		Failed: persistentVolumeClaimLost,
+		Events: wait.KubeEvents(t, options),
+	}.Wait(t, opts...)
+	return err
+}
+
+// IsPersistentVolumeClaimBound reports whether the PersistentVolumeClaim is Bound to a PersistentVolume.
+//
+// Parameters:
+//   - pvc: A pointer to the corev1.PersistentVolumeClaim object to check.
+//
+// Returns:
+//   - bool: True if the claim is Bound, false otherwise.
+func IsPersistentVolumeClaimBound(pvc *corev1.PersistentVolumeClaim) bool {
+	return pvc.Status.Phase == corev1.ClaimBound
+}
+
+// persistentVolumeClaimLost reports a claim whose PersistentVolume has gone as a terminal state; the
+// data is gone and the claim will not be bound again.
+func persistentVolumeClaimLost(pvc *corev1.PersistentVolumeClaim) error {
+	if pvc.Status.Phase == corev1.ClaimLost {
+		return &wait.TerminalStateError{Phase: string(corev1.ClaimLost), Message: "PersistentVolume " + pvc.Spec.VolumeName + " no longer exists"}
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package k8s
+
+import (
+	"testing"
+	"time"
+
+	"github.com/davidcollom/terratest-utils/pkg/wait"
+	"github.com/davidcollom/terratest-utils/pkg/waittest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestWaitForPersistentVolumeClaimBound(t *testing.T) {
+	pvc := func(phase corev1.PersistentVolumeClaimPhase) *corev1.PersistentVolumeClaim {
+		return &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "data", Namespace: "default"},
+			Spec:       corev1.PersistentVolumeClaimSpec{VolumeName: "pv-data"},
+			Status:     corev1.PersistentVolumeClaimStatus{Phase: phase},
+		}
+	}
+
+	t.Run("bound", func(t *testing.T) {
+		clock := waittest.NewClock()
+		waittest.Prepend(t, NewTestClient(t), "persistentvolumeclaims", waittest.NewScript(clock, "default", "data").
+			Return(pvc(corev1.ClaimPending)).
+			At(30*time.Second, pvc(corev1.ClaimBound)))
+
+		WaitForPersistentVolumeClaimBound(t, &KubectlOptions{}, "data", "default", time.Hour, wait.WithClock(clock))
+		assert.Equal(t, 30*time.Second, clock.Elapsed())
+	})
+
+	t.Run("lost", func(t *testing.T) {
+		clock := waittest.NewClock()
+		waittest.Prepend(t, NewTestClient(t), "persistentvolumeclaims", waittest.NewScript(clock, "default", "data").
+			Return(pvc(corev1.ClaimLost)))
+
+		err := WaitForPersistentVolumeClaimBoundE(t, &KubectlOptions{}, "data", "default", time.Hour, wait.WithClock(clock))
+		require.True(t, wait.IsTerminalState(err), "expected a terminal state error, got %v", err)
+		assert.Contains(t, err.Error(), "pv-data")
+	})
+}
@@ -0,0 +1,186 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gruntwork-io/terratest/modules/testing"
+
+	"github.com/davidcollom/terratest-utils/pkg/utils"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ImpersonateOptions returns a copy of options whose RestConfig impersonates user and groups, failing
+// the test if the cluster's REST config cannot be loaded. See ImpersonateOptionsE.
+//
+// Example usage:
+//
+//	dev := k8s.ImpersonateOptions(t, options, "jane@example.com", "developers")
+//	k8s.AssertCannot(t, dev, "delete", "namespaces", "")
+//
+// Parameters:
+//   - t: The testing context.
+//   - options: The kubectl options of an identity allowed to impersonate, typically a cluster admin.
+//   - user: The user name to impersonate.
+//   - groups: The groups to impersonate.
+//
+// Returns:
+//   - *KubectlOptions: Options that act as user.
+func ImpersonateOptions(t testing.TestingT, options *KubectlOptions, user string, groups ...string) *KubectlOptions {
+	impersonated, err := ImpersonateOptionsE(t, options, user, groups...)
+	require.NoError(t, err)
+	return impersonated
+}
+
+// ImpersonateOptionsE returns a copy of options whose RestConfig is a copy of the cluster's REST config
+// from utils.GetRestConfigE that impersonates user and groups. Every helper in this library that
+// builds its clients through pkg/clients, which is all of them, then acts as that identity; clients
+// are cached per RestConfig, so build the options once per identity and reuse them. Commands run
+// through the kubectl binary, such as terratest's RunKubectl, do not use the RestConfig and still
+// run as the original identity.
+func ImpersonateOptionsE(t testing.TestingT, options *KubectlOptions, user string, groups ...string) (*KubectlOptions, error) {
+	cfg, err := utils.GetRestConfigE(t, options)
+	if err != nil {
+		return nil, err
+	}
+
+	impersonated := *options
+	impersonated.InClusterAuth = false
+	impersonated.RestConfig = rest.CopyConfig(cfg)
+	impersonated.RestConfig.Impersonate = rest.ImpersonationConfig{UserName: user, Groups: groups}
+	return &impersonated, nil
+}
+
+// ImpersonateServiceAccount returns a copy of options that impersonates the ServiceAccount name in
+// namespace, with the groups Kubernetes gives ServiceAccount tokens, failing the test if the
+// cluster's REST config cannot be loaded.
+//
+// Example usage:
+//
+//	sa := k8s.ImpersonateServiceAccount(t, options, "ci", "deployer")
+//	k8s.AssertCan(t, sa, "get", "secrets", "app")
+//	k8s.AssertCannot(t, sa, "delete", "namespaces", "")
+//
+// Parameters:
+//   - t: The testing context.
+//   - options: The kubectl options of an identity allowed to impersonate, typically a cluster admin.
+//   - namespace: The namespace of the ServiceAccount.
+//   - name: The name of the ServiceAccount.
+//
+// Returns:
+//   - *KubectlOptions: Options that act as the ServiceAccount.
+func ImpersonateServiceAccount(t testing.TestingT, options *KubectlOptions, namespace, name string) *KubectlOptions {
+	impersonated, err := ImpersonateServiceAccountE(t, options, namespace, name)
+	require.NoError(t, err)
+	return impersonated
+}
+
+// ImpersonateServiceAccountE returns a copy of options that impersonates the ServiceAccount name in
+// namespace. See ImpersonateOptionsE.
+func ImpersonateServiceAccountE(t testing.TestingT, options *KubectlOptions, namespace, name string) (*KubectlOptions, error) {
+	return ImpersonateOptionsE(t, options, "system:serviceaccount:"+namespace+":"+name,
+		"system:serviceaccounts", "system:serviceaccounts:"+namespace, "system:authenticated")
+}
+
+// CanI reports whether the identity of options may perform verb on resource in namespace, failing the
+// test if the access review fails. See CanIE.
+//
+// Parameters:
+//   - t: The testing context.
+//   - options: The kubectl options of the identity to check, e.g. from ImpersonateOptions.
+//   - verb: The verb, e.g. "get", "list", "create" or "delete".
+//   - resource: The resource, as accepted by `kubectl auth can-i`, e.g. "secrets" or "deployments.apps/scale".
+//   - namespace: The namespace; empty for cluster-scoped resources or to check every namespace.
+//
+// Returns:
+//   - bool: True if the request would be allowed.
+func CanI(t testing.TestingT, options *KubectlOptions, verb, resource, namespace string) bool {
+	allowed, err := CanIE(t, options, verb, resource, namespace)
+	require.NoError(t, err)
+	return allowed
+}
+
+// CanIE reports whether the identity of options may perform verb on resource in namespace, as
+// `kubectl auth can-i` does, by creating a SelfSubjectAccessReview. resource is a plural resource
+// name qualified by its group and followed by a subresource where needed, e.g. "pods",
+// "deployments.apps" or "pods/log". A resource starting with "/" is checked as a non-resource URL,
+// e.g. "/metrics".
+func CanIE(t testing.TestingT, options *KubectlOptions, verb, resource, namespace string) (bool, error) {
+	client, err := NewClient(t, options)
+	if err != nil {
+		return false, err
+	}
+
+	review := &authorizationv1.SelfSubjectAccessReview{Spec: authorizationv1.SelfSubjectAccessReviewSpec{}}
+	if strings.HasPrefix(resource, "/") {
+		review.Spec.NonResourceAttributes = &authorizationv1.NonResourceAttributes{Path: resource, Verb: verb}
+	} else {
+		gr, subresource, _ := strings.Cut(resource, "/")
+		parsed := schema.ParseGroupResource(gr)
+		review.Spec.ResourceAttributes = &authorizationv1.ResourceAttributes{
+			Namespace:   namespace,
+			Verb:        verb,
+			Group:       parsed.Group,
+			Resource:    parsed.Resource,
+			Subresource: subresource,
+		}
+	}
+
+	review, err = client.AuthorizationV1().SelfSubjectAccessReviews().Create(context.Background(), review, metav1.CreateOptions{})
+	if err != nil {
+		return false, fmt.Errorf("reviewing access to %s %s: %w", verb, resource, err)
+	}
+	if review.Status.EvaluationError != "" && !review.Status.Allowed {
+		return false, fmt.Errorf("reviewing access to %s %s: %s", verb, resource, review.Status.EvaluationError)
+	}
+	return review.Status.Allowed, nil
+}
+
+// AssertCan checks that the identity of options may perform verb on resource in namespace, failing the
+// test otherwise. See CanIE for the accepted resources.
+//
+// Returns:
+//   - bool: True if the request would be allowed.
+func AssertCan(t testing.TestingT, options *KubectlOptions, verb, resource, namespace string) bool {
+	allowed, err := CanIE(t, options, verb, resource, namespace)
+	if !assert.NoError(t, err) {
+		return false
+	}
+	return assert.True(t, allowed, "%s cannot %s %s%s", identity(options), verb, resource, inNamespace(namespace))
+}
+
+// AssertCannot checks that the identity of options may not perform verb on resource in namespace,
+// failing the test otherwise. See CanIE for the accepted resources.
+//
+// Returns:
+//   - bool: True if the request would be denied.
+func AssertCannot(t testing.TestingT, options *KubectlOptions, verb, resource, namespace string) bool {
+	allowed, err := CanIE(t, options, verb, resource, namespace)
+	if !assert.NoError(t, err) {
+		return false
+	}
+	return assert.False(t, allowed, "%s can %s %s%s", identity(options), verb, resource, inNamespace(namespace))
+}
+
+// identity describes the identity options act as in assertion messages.
+func identity(options *KubectlOptions) string {
+	if options.RestConfig != nil && options.RestConfig.Impersonate.UserName != "" {
+		return fmt.Sprintf("%q", options.RestConfig.Impersonate.UserName)
+	}
+	return "the current identity"
+}
+
+// inNamespace formats namespace for assertion messages.
+func inNamespace(namespace string) string {
+	if namespace == "" {
+		return ""
+	}
+	return " in namespace " + namespace
+}
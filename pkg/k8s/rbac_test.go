@@ -0,0 +1,85 @@
+package k8s
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// useFakeAccessReviews answers SelfSubjectAccessReviews with allowed and records their specs.
+func useFakeAccessReviews(t *testing.T, allowed func(authorizationv1.SelfSubjectAccessReviewSpec) bool) *[]authorizationv1.SelfSubjectAccessReviewSpec {
+	var specs []authorizationv1.SelfSubjectAccessReviewSpec
+	client := NewTestClient(t).(*fake.Clientset)
+	client.PrependReactor("create", "selfsubjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview).DeepCopy()
+		specs = append(specs, review.Spec)
+		review.Status.Allowed = allowed(review.Spec)
+		return true, review, nil
+	})
+	return &specs
+}
+
+func TestCanI(t *testing.T) {
+	specs := useFakeAccessReviews(t, func(spec authorizationv1.SelfSubjectAccessReviewSpec) bool {
+		return spec.ResourceAttributes != nil && spec.ResourceAttributes.Verb == "get"
+	})
+
+	assert.True(t, CanI(t, &KubectlOptions{}, "get", "deployments.apps/scale", "app"))
+	assert.False(t, CanI(t, &KubectlOptions{}, "delete", "namespaces", ""))
+	assert.False(t, CanI(t, &KubectlOptions{}, "get", "/metrics", ""))
+
+	require.Len(t, *specs, 3)
+	assert.Equal(t, &authorizationv1.ResourceAttributes{
+		Namespace:   "app",
+		Verb:        "get",
+		Group:       "apps",
+		Resource:    "deployments",
+		Subresource: "scale",
+	}, (*specs)[0].ResourceAttributes)
+	assert.Equal(t, &authorizationv1.ResourceAttributes{Verb: "delete", Resource: "namespaces"}, (*specs)[1].ResourceAttributes)
+	assert.Equal(t, &authorizationv1.NonResourceAttributes{Path: "/metrics", Verb: "get"}, (*specs)[2].NonResourceAttributes)
+}
+
+func TestCanIEvaluationError(t *testing.T) {
+	client := NewTestClient(t).(*fake.Clientset)
+	client.PrependReactor("create", "selfsubjectaccessreviews", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		review := action.(k8stesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview).DeepCopy()
+		review.Status.EvaluationError = "webhook unavailable"
+		return true, review, nil
+	})
+
+	_, err := CanIE(t, &KubectlOptions{}, "get", "secrets", "app")
+	assert.ErrorContains(t, err, "webhook unavailable")
+}
+
+func TestAssertCan(t *testing.T) {
+	useFakeAccessReviews(t, func(spec authorizationv1.SelfSubjectAccessReviewSpec) bool {
+		return spec.ResourceAttributes.Resource == "secrets"
+	})
+
+	assert.True(t, AssertCan(t, &KubectlOptions{}, "get", "secrets", "app"))
+	assert.True(t, AssertCannot(t, &KubectlOptions{}, "delete", "namespaces", ""))
+}
+
+func TestImpersonateOptions(t *testing.T) {
+	base := &rest.Config{Host: "https://cluster.example", BearerToken: "admin"}
+	options := &KubectlOptions{RestConfig: base, Namespace: "app"}
+
+	dev := ImpersonateOptions(t, options, "jane@example.com", "developers")
+	assert.Equal(t, "app", dev.Namespace)
+	assert.NotSame(t, base, dev.RestConfig)
+	assert.Equal(t, "https://cluster.example", dev.RestConfig.Host)
+	assert.Equal(t, rest.ImpersonationConfig{UserName: "jane@example.com", Groups: []string{"developers"}}, dev.RestConfig.Impersonate)
+	assert.Empty(t, base.Impersonate.UserName, "the original options must not impersonate")
+	assert.Equal(t, `"jane@example.com"`, identity(dev))
+
+	sa := ImpersonateServiceAccount(t, options, "ci", "deployer")
+	assert.Equal(t, "system:serviceaccount:ci:deployer", sa.RestConfig.Impersonate.UserName)
+	assert.Equal(t, []string{"system:serviceaccounts", "system:serviceaccounts:ci", "system:authenticated"}, sa.RestConfig.Impersonate.Groups)
+}
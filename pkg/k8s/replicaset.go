@@ -0,0 +1,146 @@
+package k8s
+
+import (
+	"context"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/testing"
+
+	"github.com/davidcollom/terratest-utils/pkg/wait"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// GetReplicaSet retrieves the specified ReplicaSet from the given namespace, failing the test if it cannot be retrieved.
+//
+// Parameters:
+//   - t: The testing context.
+//   - options: The kubectl options to use when interacting with the cluster.
+//   - name: The name of the ReplicaSet to retrieve.
+//   - namespace: The namespace where the ReplicaSet is located.
+//   - opts: Additional options for the get operation.
+//
+// Returns:
+//   - A pointer to the retrieved appsv1.ReplicaSet object.
+func GetReplicaSet(t testing.TestingT, options *KubectlOptions, name, namespace string, opts metav1.GetOptions) *appsv1.ReplicaSet {
+	replicaSet, err := GetReplicaSetE(t, options, name, namespace, opts)
+	require.NoError(t, err)
+	return replicaSet
+}
+
+// GetReplicaSetE retrieves the specified ReplicaSet from the given namespace.
+//
+// Parameters:
+//   - t: The testing context.
+//   - options: The KubectlOptions to configure the Kubernetes client.
+//   - name: The name of the ReplicaSet to retrieve.
+//   - namespace: The namespace where the ReplicaSet resides.
+//   - opts: Additional options for the Get request.
+//
+// Returns:
+//   - *appsv1.ReplicaSet: The retrieved ReplicaSet object.
+//   - error: An error if the ReplicaSet could not be retrieved.
+func GetReplicaSetE(t testing.TestingT, options *KubectlOptions, name, namespace string, opts metav1.GetOptions) (*appsv1.ReplicaSet, error) {
+	client, err := NewClient(t, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.AppsV1().ReplicaSets(namespace).Get(context.Background(), name, opts)
+}
+
+// ListReplicaSets retrieves the ReplicaSets in the namespace of options, failing the test if an error occurs.
+//
+// Parameters:
+//   - t: The testing context.
+//   - options: The kubectl options specifying the context and namespace.
+//   - opts: The options to filter the list of ReplicaSets.
+//
+// Returns:
+//   - A slice of appsv1.ReplicaSet objects representing the ReplicaSets found.
+func ListReplicaSets(t testing.TestingT, options *KubectlOptions, opts metav1.ListOptions) []appsv1.ReplicaSet {
+	replicaSets, err := ListReplicaSetsE(t, options, opts)
+	require.NoError(t, err)
+	return replicaSets
+}
+
+// ListReplicaSetsE retrieves the ReplicaSets in the namespace of options.
+//
+// Parameters:
+//   - t: The testing context.
+//   - options: The kubectl options specifying the Kubernetes context and namespace.
+//   - opts: The list options to filter the ReplicaSets.
+//
+// Returns:
+//   - A slice of ReplicaSet objects found in the specified namespace.
+//   - An error if the ReplicaSets could not be listed.
+func ListReplicaSetsE(t testing.TestingT, options *KubectlOptions, opts metav1.ListOptions) ([]appsv1.ReplicaSet, error) {
+	client, err := NewClient(t, options)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := client.AppsV1().ReplicaSets(options.Namespace).List(context.Background(), opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return list.Items, nil
+}
+
+// WaitForReplicaSetReady waits until the specified ReplicaSet has all of its replicas ready and available or the timeout is reached, failing the test otherwise.
+//
+// Parameters:
+//   - t: The testing context.
+//   - options: The kubectl options for accessing the Kubernetes cluster.
+//   - name: The name of the ReplicaSet to check.
+//   - namespace: The namespace where the ReplicaSet is located.
+//   - timeout: The maximum duration to wait.
+func WaitForReplicaSetReady(t testing.TestingT, options *KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) {
+	err := WaitForReplicaSetReadyE(t, options, name, namespace, timeout, opts...)
+	require.NoError(t, err, "ReplicaSet %s/%s was not Ready in time", namespace, name)
+}
+
+// WaitForReplicaSetReadyE waits until the specified ReplicaSet has all of its replicas ready and available, using IsReplicaSetReady.
+func WaitForReplicaSetReadyE(t testing.TestingT, options *KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) error {
+	client, err := NewClient(t, options)
+	if err != nil {
+		return err
+	}
+
+	_, err = wait.Waiter[*appsv1.ReplicaSet]{
+		Kind:      "ReplicaSet",
+		Name:      name,
+		Namespace: namespace,
+		Timeout:   timeout,
+		Get: func(ctx context.Context) (*appsv1.ReplicaSet, error) {
+			return client.AppsV1().ReplicaSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		},
+		Watch: func(ctx context.Context) (watch.Interface, error) {
+			return client.AppsV1().ReplicaSets(namespace).Watch(ctx, metav1.SingleObject(metav1.ObjectMeta{Name: name}))
+		},
+		Ready:  IsReplicaSetReady,
+		Events: wait.KubeEvents(t, options),
+	}.Wait(t, opts...)
+	return err
+}
+
+// IsReplicaSetReady reports whether the controller has observed the ReplicaSet's current generation
+// and all desired replicas exist and are ready and available.
+//
+// Parameters:
+//   - replicaSet: A pointer to the appsv1.ReplicaSet object to check.
+//
+// Returns:
+//   - bool: True if the ReplicaSet is ready, false otherwise.
+func IsReplicaSetReady(replicaSet *appsv1.ReplicaSet) bool {
+	desired := replicas(replicaSet.Spec.Replicas)
+	status := replicaSet.Status
+	return status.ObservedGeneration >= replicaSet.Generation &&
+		status.Replicas == desired &&
+		status.ReadyReplicas == desired &&
+		status.AvailableReplicas == desired
+}
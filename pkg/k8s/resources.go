@@ -0,0 +1,184 @@
+package k8s
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/testing"
+
+	"github.com/davidcollom/terratest-utils/pkg/wait"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/status"
+)
+
+// GetResource retrieves an object of any kind as unstructured, failing the test if it cannot be retrieved.
+//
+// Parameters:
+//   - t: The testing context.
+//   - options: The kubectl options to use when interacting with the cluster.
+//   - gvk: The group, version and kind of the object.
+//   - name: The name of the object.
+//   - namespace: The namespace of the object; ignored for cluster-scoped kinds.
+//
+// Returns:
+//   - *unstructured.Unstructured: The retrieved object.
+func GetResource(t testing.TestingT, options *KubectlOptions, gvk schema.GroupVersionKind, name, namespace string) *unstructured.Unstructured {
+	obj, err := GetResourceE(t, options, gvk, name, namespace)
+	require.NoError(t, err)
+	return obj
+}
+
+// GetResourceE retrieves an object of any kind as unstructured using the dynamic client.
+//
+// Parameters:
+//   - t: The testing context.
+//   - options: The kubectl options to use when interacting with the cluster.
+//   - gvk: The group, version and kind of the object.
+//   - name: The name of the object.
+//   - namespace: The namespace of the object; ignored for cluster-scoped kinds.
+//
+// Returns:
+//   - *unstructured.Unstructured: The retrieved object.
+//   - error: An error if the kind is unknown to the cluster or the object could not be retrieved.
+func GetResourceE(t testing.TestingT, options *KubectlOptions, gvk schema.GroupVersionKind, name, namespace string) (*unstructured.Unstructured, error) {
+	resource, err := resourceInterface(t, options, gvk, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	return resource.Get(context.Background(), name, metav1.GetOptions{})
+}
+
+// WaitForResourceReady waits until an object of any kind is ready according to ResourceHealth,
+// failing the test otherwise.
+//
+// Example usage:
+//
+//	gvk := schema.GroupVersionKind{Group: "monitoring.coreos.com", Version: "v1", Kind: "Prometheus"}
+//	k8s.WaitForResourceReady(t, options, gvk, "k8s", "monitoring", 5*time.Minute)
+//
+// Parameters:
+//   - t: The testing context.
+//   - options: The kubectl options for accessing the Kubernetes cluster.
+//   - gvk: The group, version and kind of the object.
+//   - name: The name of the object.
+//   - namespace: The namespace of the object; ignored for cluster-scoped kinds.
+//   - timeout: The maximum duration to wait.
+func WaitForResourceReady(t testing.TestingT, options *KubectlOptions, gvk schema.GroupVersionKind, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) {
+	err := WaitForResourceReadyE(t, options, gvk, name, namespace, timeout, opts...)
+	require.NoError(t, err, "%s %s/%s was not Ready in time", gvk.Kind, namespace, name)
+}
+
+// WaitForResourceReadyE waits until an object of any kind is Current according to ResourceHealth.
+// It returns a *wait.TerminalStateError as soon as the object's status is Failed, e.g. when a
+// Stalled condition is True. The kind is resolved to a resource on every poll, so the wait also
+// covers a CRD that is still being installed.
+func WaitForResourceReadyE(t testing.TestingT, options *KubectlOptions, gvk schema.GroupVersionKind, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) error {
+	client, err := NewDynamicClient(t, options)
+	if err != nil {
+		return err
+	}
+	mapper, err := NewRESTMapper(t, options)
+	if err != nil {
+		return err
+	}
+	resource := func() (dynamic.ResourceInterface, error) {
+		return resourceFor(client, mapper, gvk, namespace)
+	}
+
+	_, err = wait.Waiter[*unstructured.Unstructured]{
+		Kind:      gvk.Kind,
+		Name:      name,
+		Namespace: namespace,
+		Timeout:   timeout,
+		Get: func(ctx context.Context) (*unstructured.Unstructured, error) {
+			r, err := resource()
+			if err != nil {
+				return nil, err
+			}
+			return r.Get(ctx, name, metav1.GetOptions{})
+		},
+		Watch: func(ctx context.Context) (watch.Interface, error) {
+			r, err := resource()
+			if err != nil {
+				return nil, err
+			}
+			return r.Watch(ctx, metav1.SingleObject(metav1.ObjectMeta{Name: name}))
+		},
+		Ready:  IsResourceReady,
+		Failed: resourceFailed,
+		Events: wait.KubeEvents(t, options),
+	}.Wait(t, opts...)
+	return err
+}
+
+// IsResourceReady reports whether ResourceHealth computes the Current status for obj.
+//
+// Parameters:
+//   - obj: The object to check.
+//
+// Returns:
+//   - bool: True if the object is Current, false otherwise or if its status cannot be computed.
+func IsResourceReady(obj *unstructured.Unstructured) bool {
+	result, err := ResourceHealth(obj)
+	return err == nil && result.Status == status.CurrentStatus
+}
+
+// resourceFailed reports an object whose ResourceHealth is Failed as a terminal state.
+func resourceFailed(obj *unstructured.Unstructured) error {
+	result, err := ResourceHealth(obj)
+	if err != nil || result.Status != status.FailedStatus {
+		return nil
+	}
+	return &wait.TerminalStateError{Phase: string(result.Status), Message: result.Message}
+}
+
+// resourceInterface resolves gvk with the cluster's RESTMapper and returns the dynamic client for it.
+func resourceInterface(t testing.TestingT, options *KubectlOptions, gvk schema.GroupVersionKind, namespace string) (dynamic.ResourceInterface, error) {
+	client, err := NewDynamicClient(t, options)
+	if err != nil {
+		return nil, err
+	}
+	mapper, err := NewRESTMapper(t, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return resourceFor(client, mapper, gvk, namespace)
+}
+
+// resourceFor maps gvk to its resource and scopes the dynamic client to namespace when the kind is
+// namespaced.
+func resourceFor(client dynamic.Interface, mapper meta.RESTMapper, gvk schema.GroupVersionKind, namespace string) (dynamic.ResourceInterface, error) {
+	mapping, err := restMapping(mapper, gvk)
+	if err != nil {
+		return nil, err
+	}
+	if mapping.Scope.Name() == meta.RESTScopeNameRoot {
+		return client.Resource(mapping.Resource), nil
+	}
+	return client.Resource(mapping.Resource).Namespace(namespace), nil
+}
+
+// restMapping maps gvk to its resource. When the kind is unknown the mapper's discovery cache is reset
+// and the lookup retried once, so kinds whose CRDs were installed after the cache was filled resolve.
+func restMapping(mapper meta.RESTMapper, gvk schema.GroupVersionKind) (*meta.RESTMapping, error) {
+	mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if meta.IsNoMatchError(err) {
+		if resettable, ok := mapper.(meta.ResettableRESTMapper); ok {
+			resettable.Reset()
+			mapping, err = mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("resolving %s: %w", gvk, err)
+	}
+	return mapping, nil
+}
@@ -0,0 +1,115 @@
+package k8s
+
+import (
+	"testing"
+	"time"
+
+	"github.com/davidcollom/terratest-utils/pkg/wait"
+	"github.com/davidcollom/terratest-utils/pkg/waittest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/cli-utils/pkg/kstatus/status"
+)
+
+var widgetGVK = schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Widget"}
+
+func widget(generation, observed int64, conditions ...map[string]any) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]any{
+		"metadata": map[string]any{"name": "w", "namespace": "default", "generation": generation},
+		"status":   map[string]any{"observedGeneration": observed},
+	}}
+	obj.SetGroupVersionKind(widgetGVK)
+	if len(conditions) > 0 {
+		list := make([]any, len(conditions))
+		for i, c := range conditions {
+			list[i] = c
+		}
+		obj.Object["status"].(map[string]any)["conditions"] = list
+	}
+	return obj
+}
+
+func condition(conditionType, conditionStatus string) map[string]any {
+	return map[string]any{"type": conditionType, "status": conditionStatus, "reason": "Test", "message": conditionType + " is " + conditionStatus}
+}
+
+func TestResourceHealth(t *testing.T) {
+	tests := []struct {
+		name     string
+		obj      *unstructured.Unstructured
+		ready    bool
+		terminal bool
+	}{
+		{name: "ready", obj: widget(2, 2, condition("Ready", "True")), ready: true},
+		{name: "no conditions", obj: widget(1, 1), ready: true},
+		{name: "generation not observed", obj: widget(2, 1, condition("Ready", "True"))},
+		{name: "reconciling", obj: widget(1, 1, condition("Reconciling", "True"))},
+		{name: "stalled", obj: widget(1, 1, condition("Stalled", "True")), terminal: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.ready, IsResourceReady(tc.obj))
+			assert.Equal(t, tc.terminal, wait.IsTerminalState(resourceFailed(tc.obj)))
+		})
+	}
+}
+
+func TestRegisterHealthFunc(t *testing.T) {
+	inProgress := func(obj *unstructured.Unstructured) (*status.Result, error) {
+		return &status.Result{Status: status.InProgressStatus, Message: "never ready"}, nil
+	}
+	failed := func(obj *unstructured.Unstructured) (*status.Result, error) {
+		return &status.Result{Status: status.FailedStatus, Message: "broken"}, nil
+	}
+	anyVersion := schema.GroupVersionKind{Group: widgetGVK.Group, Kind: widgetGVK.Kind}
+	t.Cleanup(func() {
+		RegisterHealthFunc(anyVersion, nil)
+		RegisterHealthFunc(widgetGVK, nil)
+	})
+
+	obj := widget(1, 1, condition("Ready", "True"))
+	require.True(t, IsResourceReady(obj))
+
+	RegisterHealthFunc(anyVersion, inProgress)
+	assert.False(t, IsResourceReady(obj), "a function registered without a version applies to every version")
+
+	RegisterHealthFunc(widgetGVK, failed)
+	assert.True(t, wait.IsTerminalState(resourceFailed(obj)), "an exact version takes precedence")
+
+	RegisterHealthFunc(widgetGVK, nil)
+	RegisterHealthFunc(anyVersion, nil)
+	assert.True(t, IsResourceReady(obj))
+}
+
+func TestWaitForResourceReady(t *testing.T) {
+	NewTestClient(t)
+
+	t.Run("becomes ready", func(t *testing.T) {
+		clock := waittest.NewClock()
+		client := NewTestDynamicClient(t, widget(2, 1))
+		waittest.Prepend(t, client, "widgets", waittest.NewScript(clock, "default", "w").
+			At(30*time.Second, widget(2, 2, condition("Ready", "True"))))
+
+		require.NoError(t, WaitForResourceReadyE(t, &KubectlOptions{}, widgetGVK, "w", "default", 5*time.Minute, wait.WithClock(clock)))
+		assert.GreaterOrEqual(t, clock.Elapsed(), 30*time.Second)
+	})
+
+	t.Run("stalled", func(t *testing.T) {
+		NewTestDynamicClient(t, widget(1, 1, condition("Stalled", "True")))
+
+		err := WaitForResourceReadyE(t, &KubectlOptions{}, widgetGVK, "w", "default", 5*time.Minute, wait.WithClock(waittest.NewClock()))
+		require.Error(t, err)
+		assert.True(t, wait.IsTerminalState(err))
+		assert.Contains(t, err.Error(), "Stalled is True")
+	})
+
+	t.Run("unknown kind", func(t *testing.T) {
+		NewTestDynamicClient(t)
+
+		err := WaitForResourceReadyE(t, &KubectlOptions{}, widgetGVK, "w", "default", time.Minute, wait.WithClock(waittest.NewClock()))
+		require.Error(t, err)
+		assert.True(t, wait.IsTimeout(err))
+	})
+}
@@ -0,0 +1,87 @@
+package k8s
+
+import (
+	"context"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/testing"
+
+	"github.com/davidcollom/terratest-utils/pkg/wait"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/stretchr/testify/require"
+)
+
+// WaitForServiceEndpointsReady waits until the specified Service has at least one ready endpoint address or the timeout is reached, failing the test otherwise.
+//
+// Example usage:
+//
+//	k8s.WaitForServiceEndpointsReady(t, options, "podinfo", "default", 2*time.Minute)
+//	addr, stop := k8s.PortForwardService(t, options, "podinfo", 9898)
+//
+// Parameters:
+//   - t: The testing context.
+//   - options: The kubectl options for accessing the Kubernetes cluster.
+//   - name: The name of the Service.
+//   - namespace: The namespace where the Service is located.
+//   - timeout: The maximum duration to wait.
+func WaitForServiceEndpointsReady(t testing.TestingT, options *KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) {
+	err := WaitForServiceEndpointsReadyE(t, options, name, namespace, timeout, opts...)
+	require.NoError(t, err, "Service %s/%s had no ready endpoints in time", namespace, name)
+}
+
+// WaitForServiceEndpointsReadyE waits until one of the EndpointSlices of the specified Service lists a
+// ready address, i.e. traffic sent to the Service now reaches a backend. It returns a
+// *wait.TerminalStateError straight away for an ExternalName Service, which never has endpoints.
+func WaitForServiceEndpointsReadyE(t testing.TestingT, options *KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) error {
+	client, err := NewClient(t, options)
+	if err != nil {
+		return err
+	}
+
+	service, err := client.CoreV1().Services(namespace).Get(context.Background(), name, metav1.GetOptions{})
+	if err == nil && service.Spec.Type == corev1.ServiceTypeExternalName {
+		return &wait.TerminalStateError{Kind: "Service", Name: name, Namespace: namespace, Phase: "ExternalName", Message: "ExternalName Services have no endpoints"}
+	}
+
+	selector := labels.SelectorFromSet(labels.Set{discoveryv1.LabelServiceName: name}).String()
+	_, err = wait.Waiter[[]discoveryv1.EndpointSlice]{
+		Kind:      "Service",
+		Name:      name,
+		Namespace: namespace,
+		Timeout:   timeout,
+		Get: func(ctx context.Context) ([]discoveryv1.EndpointSlice, error) {
+			list, err := client.DiscoveryV1().EndpointSlices(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+			if err != nil {
+				return nil, err
+			}
+			return list.Items, nil
+		},
+		Ready:  func(slices []discoveryv1.EndpointSlice) bool { return len(ReadyEndpointAddresses(slices)) > 0 },
+		Events: wait.KubeEvents(t, options),
+	}.Wait(t, opts...)
+	return err
+}
+
+// ReadyEndpointAddresses returns the addresses of the ready endpoints in slices. An endpoint whose
+// ready condition is unknown counts as ready, as the EndpointSlice API asks consumers to do.
+//
+// Parameters:
+//   - slices: The EndpointSlices of a Service.
+//
+// Returns:
+//   - []string: The addresses of the ready endpoints.
+func ReadyEndpointAddresses(slices []discoveryv1.EndpointSlice) []string {
+	var addresses []string
+	for _, slice := range slices {
+		for _, endpoint := range slice.Endpoints {
+			if endpoint.Conditions.Ready == nil || *endpoint.Conditions.Ready {
+				addresses = append(addresses, endpoint.Addresses...)
+			}
+		}
+	}
+	return addresses
+}
@@ -2,16 +2,23 @@ package k8s
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gruntwork-io/terratest/modules/testing"
 
+	"github.com/davidcollom/terratest-utils/pkg/wait"
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
 
 	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/watch"
 )
 
 // GetStatefulSet retrieves the specified StatefulSet from the given Kubernetes namespace using the provided KubectlOptions and GetOptions.
@@ -105,7 +112,7 @@ func ListStatefulSetsE(t testing.TestingT, options *KubectlOptions, opts metav1.
 }
 
 // WaitForStatefulSetReady waits until the specified StatefulSet in the given namespace is ready or the timeout is reached.
-// It polls the StatefulSet status every 2 seconds and checks if it is up-to-date using IsStatefulSetUptoDate.
+// It watches the StatefulSet status, falling back to polling, and checks if it is up-to-date using IsStatefulSetUptoDate.
 // If the StatefulSet does not become ready within the timeout, the test fails with a fatal error.
 //
 // Parameters:
@@ -116,28 +123,33 @@ func ListStatefulSetsE(t testing.TestingT, options *KubectlOptions, opts metav1.
 //   - timeout: The maximum duration to wait for the StatefulSet to become ready.
 //
 // WaitForStatefulSetReady waits for the resource condition to be satisfied.
-func WaitForStatefulSetReady(t testing.TestingT, options *KubectlOptions, name, namespace string, timeout time.Duration) {
-	err := WaitForStatefulSetReadyE(t, options, name, namespace, timeout)
+func WaitForStatefulSetReady(t testing.TestingT, options *KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) {
+	err := WaitForStatefulSetReadyE(t, options, name, namespace, timeout, opts...)
 	require.NoError(t, err, "StatefulSet %s/%s was not Ready in time", namespace, name)
 }
 
 // WaitForStatefulSetReadyE waits for the resource condition to be satisfied.
-func WaitForStatefulSetReadyE(t testing.TestingT, options *KubectlOptions, name, namespace string, timeout time.Duration) error {
+func WaitForStatefulSetReadyE(t testing.TestingT, options *KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) error {
 	client, err := NewClient(t, options)
 	if err != nil {
 		return err
 	}
 
-	return wait.PollUntilContextTimeout(context.Background(), 2*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
-		sts, err := client.AppsV1().StatefulSets(namespace).Get(ctx, name, v1.GetOptions{})
-		if err != nil {
-			return false, nil // retry
-		}
-		if IsStatefulSetUptoDate(sts) {
-			return true, nil
-		}
-		return false, nil
-	})
+	_, err = wait.Waiter[*appsv1.StatefulSet]{
+		Kind:      "StatefulSet",
+		Name:      name,
+		Namespace: namespace,
+		Timeout:   timeout,
+		Get: func(ctx context.Context) (*appsv1.StatefulSet, error) {
+			return client.AppsV1().StatefulSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		},
+		Watch: func(ctx context.Context) (watch.Interface, error) {
+			return client.AppsV1().StatefulSets(namespace).Watch(ctx, metav1.SingleObject(metav1.ObjectMeta{Name: name}))
+		},
+		Ready:  IsStatefulSetUptoDate,
+		Events: wait.KubeEvents(t, options),
+	}.Wait(t, opts...)
+	return err
 }
 
 // IsStatefulSetUptoDate checks whether the given StatefulSet has all its replicas updated, available, and current.
@@ -150,9 +162,221 @@ func WaitForStatefulSetReadyE(t testing.TestingT, options *KubectlOptions, name,
 //   - bool: True if the StatefulSet is up-to-date (all replicas are updated, available, and current), false otherwise.
 //
 // This function is useful for determining if a StatefulSet rollout has completed successfully.
+// It does not consider the observed generation, revisions or partitions; use
+// IsStatefulSetRolloutComplete to follow `kubectl rollout status`.
 // IsStatefulSetUptoDate returns whether the resource matches the expected state.
 func IsStatefulSetUptoDate(sts *appsv1.StatefulSet) bool {
 	return sts.Status.UpdatedReplicas == sts.Status.Replicas &&
 		sts.Status.AvailableReplicas == sts.Status.Replicas &&
 		sts.Status.CurrentReplicas == sts.Status.Replicas
 }
+
+// WaitForStatefulSetRolloutComplete waits until the StatefulSet's rollout is complete according to
+// IsStatefulSetRolloutComplete, failing the test otherwise. On timeout the failure names the pods
+// that are still on the old revision.
+//
+// Parameters:
+//   - t: The testing context.
+//   - options: The kubectl options for accessing the Kubernetes cluster.
+//   - name: The name of the StatefulSet to check.
+//   - namespace: The namespace where the StatefulSet is located.
+//   - timeout: The maximum duration to wait for the rollout to complete.
+func WaitForStatefulSetRolloutComplete(t testing.TestingT, options *KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) {
+	err := WaitForStatefulSetRolloutCompleteE(t, options, name, namespace, timeout, opts...)
+	require.NoError(t, err, "StatefulSet %s/%s did not finish rolling out in time", namespace, name)
+}
+
+// WaitForStatefulSetRolloutCompleteE waits until the StatefulSet's rollout is complete according to
+// IsStatefulSetRolloutComplete. When it times out, the *wait.TimeoutError's status message carries
+// the rollout progress from StatefulSetRolloutStatus and the pods that have yet to be updated.
+func WaitForStatefulSetRolloutCompleteE(t testing.TestingT, options *KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) error {
+	client, err := NewClient(t, options)
+	if err != nil {
+		return err
+	}
+
+	sts, err := wait.Waiter[*appsv1.StatefulSet]{
+		Kind:      "StatefulSet",
+		Name:      name,
+		Namespace: namespace,
+		Timeout:   timeout,
+		Get: func(ctx context.Context) (*appsv1.StatefulSet, error) {
+			return client.AppsV1().StatefulSets(namespace).Get(ctx, name, metav1.GetOptions{})
+		},
+		Watch: func(ctx context.Context) (watch.Interface, error) {
+			return client.AppsV1().StatefulSets(namespace).Watch(ctx, metav1.SingleObject(metav1.ObjectMeta{Name: name}))
+		},
+		Ready:  IsStatefulSetRolloutComplete,
+		Events: wait.KubeEvents(t, options),
+	}.Wait(t, opts...)
+
+	var timeoutErr *wait.TimeoutError
+	if errors.As(err, &timeoutErr) && sts != nil {
+		timeoutErr.Status.Message, _ = StatefulSetRolloutStatus(sts)
+		if outdated, listErr := outdatedStatefulSetPods(client, sts); listErr == nil && len(outdated) > 0 {
+			timeoutErr.Status.Message += fmt.Sprintf("; pods not on revision %s: %s", sts.Status.UpdateRevision, podNames(outdated))
+		}
+	}
+	return err
+}
+
+// IsStatefulSetRolloutComplete reports whether the StatefulSet's rollout is complete, as
+// StatefulSetRolloutStatus describes.
+//
+// Parameters:
+//   - sts: A pointer to the appsv1.StatefulSet object to check.
+//
+// Returns:
+//   - bool: True if the rollout is complete, false otherwise.
+func IsStatefulSetRolloutComplete(sts *appsv1.StatefulSet) bool {
+	_, done := StatefulSetRolloutStatus(sts)
+	return done
+}
+
+// StatefulSetRolloutStatus follows `kubectl rollout status` for a StatefulSet. The rollout is complete
+// once the controller has observed the current generation, all desired replicas are ready and:
+//   - RollingUpdate: every pod is on the update revision, or with a partition, every pod with an
+//     ordinal at or above the partition is.
+//   - OnDelete: every desired replica is on the update revision. Pods are only replaced once they are
+//     deleted, so the test has to delete them; `kubectl rollout status` does not support OnDelete.
+//
+// Parameters:
+//   - sts: A pointer to the appsv1.StatefulSet object to check.
+//
+// Returns:
+//   - string: A description of the rollout's progress, matching kubectl's where it has one.
+//   - bool: True if the rollout is complete, false otherwise.
+func StatefulSetRolloutStatus(sts *appsv1.StatefulSet) (string, bool) {
+	status := sts.Status
+	desired := replicas(sts.Spec.Replicas)
+
+	if status.ObservedGeneration == 0 || sts.Generation > status.ObservedGeneration {
+		return "waiting for statefulset spec update to be observed", false
+	}
+	if status.ReadyReplicas < desired {
+		return fmt.Sprintf("waiting for %d pods to be ready (%d/%d ready)", desired-status.ReadyReplicas, status.ReadyReplicas, desired), false
+	}
+
+	if sts.Spec.UpdateStrategy.Type == appsv1.OnDeleteStatefulSetStrategyType {
+		if status.UpdatedReplicas < desired {
+			return fmt.Sprintf("waiting for %d pods to be deleted and recreated at revision %s", desired-status.UpdatedReplicas, status.UpdateRevision), false
+		}
+		return fmt.Sprintf("statefulset rolling update complete %d pods at revision %s", status.UpdatedReplicas, status.UpdateRevision), true
+	}
+
+	if partition := statefulSetPartition(sts); partition > 0 {
+		if status.UpdatedReplicas < desired-partition {
+			return fmt.Sprintf("waiting for partitioned roll out to finish: %d out of %d new pods have been updated", status.UpdatedReplicas, desired-partition), false
+		}
+		return fmt.Sprintf("partitioned roll out complete: %d new pods have been updated", status.UpdatedReplicas), true
+	}
+
+	if status.UpdateRevision != status.CurrentRevision {
+		return fmt.Sprintf("waiting for statefulset rolling update to complete %d pods at revision %s", status.UpdatedReplicas, status.UpdateRevision), false
+	}
+	return fmt.Sprintf("statefulset rolling update complete %d pods at revision %s", status.CurrentReplicas, status.CurrentRevision), true
+}
+
+// statefulSetPartition returns the RollingUpdate partition of sts, or 0 when it has none.
+func statefulSetPartition(sts *appsv1.StatefulSet) int32 {
+	if rolling := sts.Spec.UpdateStrategy.RollingUpdate; rolling != nil && rolling.Partition != nil {
+		return *rolling.Partition
+	}
+	return 0
+}
+
+// ListStatefulSetOutdatedPods lists the pods of a StatefulSet that the current rollout still has to
+// update, ordered by ordinal, failing the test if they cannot be listed.
+//
+// Parameters:
+//   - t: The testing context.
+//   - options: The kubectl options for accessing the Kubernetes cluster.
+//   - name: The name of the StatefulSet.
+//   - namespace: The namespace where the StatefulSet is located.
+//
+// Returns:
+//   - []corev1.Pod: The pods not yet on the StatefulSet's update revision.
+func ListStatefulSetOutdatedPods(t testing.TestingT, options *KubectlOptions, name, namespace string) []corev1.Pod {
+	pods, err := ListStatefulSetOutdatedPodsE(t, options, name, namespace)
+	require.NoError(t, err)
+	return pods
+}
+
+// ListStatefulSetOutdatedPodsE lists the pods of a StatefulSet that the current rollout still has to
+// update, ordered by ordinal. These are the pods owned by the StatefulSet whose
+// controller-revision-hash is not the update revision, excluding ordinals below a RollingUpdate
+// partition, which are not meant to be updated.
+//
+// Parameters:
+//   - t: The testing context.
+//   - options: The kubectl options for accessing the Kubernetes cluster.
+//   - name: The name of the StatefulSet.
+//   - namespace: The namespace where the StatefulSet is located.
+//
+// Returns:
+//   - []corev1.Pod: The pods not yet on the StatefulSet's update revision.
+//   - error: An error if the StatefulSet or its pods could not be retrieved.
+func ListStatefulSetOutdatedPodsE(t testing.TestingT, options *KubectlOptions, name, namespace string) ([]corev1.Pod, error) {
+	client, err := NewClient(t, options)
+	if err != nil {
+		return nil, err
+	}
+	sts, err := client.AppsV1().StatefulSets(namespace).Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return outdatedStatefulSetPods(client, sts)
+}
+
+// outdatedStatefulSetPods implements ListStatefulSetOutdatedPodsE for an already retrieved sts.
+func outdatedStatefulSetPods(client kubernetes.Interface, sts *appsv1.StatefulSet) ([]corev1.Pod, error) {
+	selector, err := metav1.LabelSelectorAsSelector(sts.Spec.Selector)
+	if err != nil {
+		return nil, err
+	}
+	pods, err := client.CoreV1().Pods(sts.Namespace).List(context.Background(), metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, err
+	}
+
+	partition := statefulSetPartition(sts)
+	var outdated []corev1.Pod
+	for _, pod := range pods.Items {
+		ordinal, ok := statefulSetPodOrdinal(sts, &pod)
+		if !ok || ordinal < int(partition) || pod.Labels[appsv1.ControllerRevisionHashLabelKey] == sts.Status.UpdateRevision {
+			continue
+		}
+		outdated = append(outdated, pod)
+	}
+	sort.Slice(outdated, func(i, j int) bool {
+		a, _ := statefulSetPodOrdinal(sts, &outdated[i])
+		b, _ := statefulSetPodOrdinal(sts, &outdated[j])
+		return a < b
+	})
+
+	return outdated, nil
+}
+
+// statefulSetPodOrdinal returns the ordinal of a pod controlled by sts, which is the suffix of its
+// name after "<statefulset>-".
+func statefulSetPodOrdinal(sts *appsv1.StatefulSet, pod *corev1.Pod) (int, bool) {
+	if !metav1.IsControlledBy(pod, sts) {
+		return 0, false
+	}
+	suffix, found := strings.CutPrefix(pod.Name, sts.Name+"-")
+	if !found {
+		return 0, false
+	}
+	ordinal, err := strconv.Atoi(suffix)
+	return ordinal, err == nil
+}
+
+// podNames joins the names of pods for error messages.
+func podNames(pods []corev1.Pod) string {
+	names := make([]string, 0, len(pods))
+	for _, pod := range pods {
+		names = append(names, pod.Name)
+	}
+	return strings.Join(names, ", ")
+}
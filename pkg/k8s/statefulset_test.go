@@ -0,0 +1,121 @@
+package k8s
+
+import (
+	"testing"
+	"time"
+
+	"github.com/davidcollom/terratest-utils/pkg/wait"
+	"github.com/davidcollom/terratest-utils/pkg/waittest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+)
+
+// statefulSet returns a three-replica StatefulSet "web" at generation 2 with the given strategy and status.
+func statefulSet(strategy appsv1.StatefulSetUpdateStrategy, status appsv1.StatefulSetStatus) *appsv1.StatefulSet {
+	return &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", UID: types.UID("web-uid"), Generation: 2},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas:       ptr.To[int32](3),
+			Selector:       &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+			UpdateStrategy: strategy,
+		},
+		Status: status,
+	}
+}
+
+func TestStatefulSetRolloutStatus(t *testing.T) {
+	rolling := appsv1.StatefulSetUpdateStrategy{Type: appsv1.RollingUpdateStatefulSetStrategyType}
+	partitioned := appsv1.StatefulSetUpdateStrategy{
+		Type:          appsv1.RollingUpdateStatefulSetStrategyType,
+		RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{Partition: ptr.To[int32](2)},
+	}
+	onDelete := appsv1.StatefulSetUpdateStrategy{Type: appsv1.OnDeleteStatefulSetStrategyType}
+
+	tests := []struct {
+		name     string
+		sts      *appsv1.StatefulSet
+		complete bool
+		message  string
+	}{
+		{
+			name:    "new spec not observed",
+			sts:     statefulSet(rolling, appsv1.StatefulSetStatus{ObservedGeneration: 1, Replicas: 3, ReadyReplicas: 3, UpdatedReplicas: 3, CurrentReplicas: 3, AvailableReplicas: 3}),
+			message: "waiting for statefulset spec update to be observed",
+		},
+		{
+			name:    "pods not ready",
+			sts:     statefulSet(rolling, appsv1.StatefulSetStatus{ObservedGeneration: 2, Replicas: 3, ReadyReplicas: 2, CurrentRevision: "v1", UpdateRevision: "v1"}),
+			message: "waiting for 1 pods to be ready (2/3 ready)",
+		},
+		{
+			name:    "rolling update in progress",
+			sts:     statefulSet(rolling, appsv1.StatefulSetStatus{ObservedGeneration: 2, Replicas: 3, ReadyReplicas: 3, UpdatedReplicas: 1, CurrentRevision: "v1", UpdateRevision: "v2"}),
+			message: "waiting for statefulset rolling update to complete 1 pods at revision v2",
+		},
+		{
+			name:     "rolling update complete",
+			sts:      statefulSet(rolling, appsv1.StatefulSetStatus{ObservedGeneration: 2, Replicas: 3, ReadyReplicas: 3, UpdatedReplicas: 3, CurrentReplicas: 3, CurrentRevision: "v2", UpdateRevision: "v2"}),
+			complete: true,
+		},
+		{
+			name:    "partition not reached",
+			sts:     statefulSet(partitioned, appsv1.StatefulSetStatus{ObservedGeneration: 2, Replicas: 3, ReadyReplicas: 3, UpdatedReplicas: 0, CurrentRevision: "v1", UpdateRevision: "v2"}),
+			message: "waiting for partitioned roll out to finish: 0 out of 1 new pods have been updated",
+		},
+		{
+			name:     "partition reached",
+			sts:      statefulSet(partitioned, appsv1.StatefulSetStatus{ObservedGeneration: 2, Replicas: 3, ReadyReplicas: 3, UpdatedReplicas: 1, CurrentRevision: "v1", UpdateRevision: "v2"}),
+			complete: true,
+		},
+		{
+			name:    "on delete awaiting recreation",
+			sts:     statefulSet(onDelete, appsv1.StatefulSetStatus{ObservedGeneration: 2, Replicas: 3, ReadyReplicas: 3, UpdatedReplicas: 1, CurrentRevision: "v1", UpdateRevision: "v2"}),
+			message: "waiting for 2 pods to be deleted and recreated at revision v2",
+		},
+		{
+			name:     "on delete recreated",
+			sts:      statefulSet(onDelete, appsv1.StatefulSetStatus{ObservedGeneration: 2, Replicas: 3, ReadyReplicas: 3, UpdatedReplicas: 3, CurrentRevision: "v1", UpdateRevision: "v2"}),
+			complete: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			message, complete := StatefulSetRolloutStatus(tc.sts)
+			assert.Equal(t, tc.complete, complete)
+			assert.Equal(t, tc.complete, IsStatefulSetRolloutComplete(tc.sts))
+			if tc.message != "" {
+				assert.Equal(t, tc.message, message)
+			}
+		})
+	}
+}
+
+func TestWaitForStatefulSetRolloutCompleteReportsOutdatedPods(t *testing.T) {
+	strategy := appsv1.StatefulSetUpdateStrategy{
+		Type:          appsv1.RollingUpdateStatefulSetStrategyType,
+		RollingUpdate: &appsv1.RollingUpdateStatefulSetStrategy{Partition: ptr.To[int32](1)},
+	}
+	sts := statefulSet(strategy, appsv1.StatefulSetStatus{ObservedGeneration: 2, Replicas: 3, ReadyReplicas: 3, UpdatedReplicas: 0, CurrentRevision: "v1", UpdateRevision: "v2"})
+	pod := func(name, revision string) *corev1.Pod {
+		return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       "default",
+			Labels:          map[string]string{"app": "web", appsv1.ControllerRevisionHashLabelKey: revision},
+			OwnerReferences: []metav1.OwnerReference{*metav1.NewControllerRef(sts, appsv1.SchemeGroupVersion.WithKind("StatefulSet"))},
+		}}
+	}
+	NewTestClient(t, sts, pod("web-0", "v1"), pod("web-10", "v1"), pod("web-2", "v1"), pod("web-1", "v2"))
+
+	err := WaitForStatefulSetRolloutCompleteE(t, &KubectlOptions{}, "web", "default", time.Minute, wait.WithClock(waittest.NewClock()))
+	require.True(t, wait.IsTimeout(err), "expected a timeout, got %v", err)
+	assert.Contains(t, err.Error(), "waiting for partitioned roll out to finish: 0 out of 2 new pods have been updated")
+	assert.Contains(t, err.Error(), "pods not on revision v2: web-2, web-10", "pods below the partition are not expected to update")
+
+	outdated := ListStatefulSetOutdatedPods(t, &KubectlOptions{}, "web", "default")
+	assert.Equal(t, "web-2, web-10", podNames(outdated))
+}
@@ -0,0 +1,126 @@
+package k8s
+
+import (
+	"testing"
+	"time"
+
+	"github.com/davidcollom/terratest-utils/pkg/wait"
+	"github.com/davidcollom/terratest-utils/pkg/waittest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+)
+
+func TestIsDeploymentReady(t *testing.T) {
+	deployment := func(generation, observed int64, status appsv1.DeploymentStatus) *appsv1.Deployment {
+		status.ObservedGeneration = observed
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Generation: generation},
+			Spec:       appsv1.DeploymentSpec{Replicas: ptr.To[int32](3)},
+			Status:     status,
+		}
+	}
+
+	tests := []struct {
+		name       string
+		deployment *appsv1.Deployment
+		ready      bool
+		terminal   bool
+	}{
+		{name: "rolled out", deployment: deployment(2, 2, appsv1.DeploymentStatus{Replicas: 3, UpdatedReplicas: 3, AvailableReplicas: 3}), ready: true},
+		{name: "generation not observed", deployment: deployment(3, 2, appsv1.DeploymentStatus{Replicas: 3, UpdatedReplicas: 3, AvailableReplicas: 3})},
+		{name: "old replicas remain", deployment: deployment(2, 2, appsv1.DeploymentStatus{Replicas: 4, UpdatedReplicas: 3, AvailableReplicas: 3})},
+		{name: "updated replicas unavailable", deployment: deployment(2, 2, appsv1.DeploymentStatus{Replicas: 3, UpdatedReplicas: 3, AvailableReplicas: 2})},
+		{
+			name: "progress deadline exceeded",
+			deployment: deployment(2, 2, appsv1.DeploymentStatus{Replicas: 3, UpdatedReplicas: 1, AvailableReplicas: 2, Conditions: []appsv1.DeploymentCondition{{
+				Type: appsv1.DeploymentProgressing, Status: corev1.ConditionFalse, Reason: "ProgressDeadlineExceeded",
+			}}}),
+			terminal: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.ready, IsDeploymentReady(tc.deployment))
+			assert.Equal(t, tc.terminal, wait.IsTerminalState(deploymentFailed(tc.deployment)))
+		})
+	}
+}
+
+func TestIsDaemonSetAndReplicaSetReady(t *testing.T) {
+	assert.True(t, IsDaemonSetReady(&appsv1.DaemonSet{Status: appsv1.DaemonSetStatus{DesiredNumberScheduled: 2, UpdatedNumberScheduled: 2, NumberAvailable: 2}}))
+	assert.False(t, IsDaemonSetReady(&appsv1.DaemonSet{Status: appsv1.DaemonSetStatus{DesiredNumberScheduled: 2, UpdatedNumberScheduled: 1, NumberAvailable: 2}}))
+	assert.False(t, IsDaemonSetReady(&appsv1.DaemonSet{ObjectMeta: metav1.ObjectMeta{Generation: 2}, Status: appsv1.DaemonSetStatus{ObservedGeneration: 1}}))
+
+	assert.True(t, IsReplicaSetReady(&appsv1.ReplicaSet{Status: appsv1.ReplicaSetStatus{Replicas: 1, ReadyReplicas: 1, AvailableReplicas: 1}}), "replicas default to 1")
+	assert.False(t, IsReplicaSetReady(&appsv1.ReplicaSet{Spec: appsv1.ReplicaSetSpec{Replicas: ptr.To[int32](2)}, Status: appsv1.ReplicaSetStatus{Replicas: 2, ReadyReplicas: 1, AvailableReplicas: 1}}))
+}
+
+func TestIsJobComplete(t *testing.T) {
+	condition := func(condType batchv1.JobConditionType, reason string) []batchv1.JobCondition {
+		return []batchv1.JobCondition{{Type: condType, Status: corev1.ConditionTrue, Reason: reason}}
+	}
+
+	tests := []struct {
+		name     string
+		job      *batchv1.Job
+		complete bool
+		terminal bool
+	}{
+		{name: "running", job: &batchv1.Job{Status: batchv1.JobStatus{Active: 1}}},
+		{name: "complete", job: &batchv1.Job{Status: batchv1.JobStatus{Succeeded: 1, Conditions: condition(batchv1.JobComplete, "")}}, complete: true},
+		{name: "failed condition", job: &batchv1.Job{Status: batchv1.JobStatus{Conditions: condition(batchv1.JobFailed, "DeadlineExceeded")}}, terminal: true},
+		{name: "within backoffLimit", job: &batchv1.Job{Spec: batchv1.JobSpec{BackoffLimit: ptr.To[int32](2)}, Status: batchv1.JobStatus{Failed: 2}}},
+		{name: "backoffLimit exceeded", job: &batchv1.Job{Spec: batchv1.JobSpec{BackoffLimit: ptr.To[int32](2)}, Status: batchv1.JobStatus{Failed: 3}}, terminal: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.complete, IsJobComplete(tc.job))
+			assert.Equal(t, tc.terminal, wait.IsTerminalState(jobFailed(tc.job)))
+		})
+	}
+}
+
+func TestIsCronJobSucceeded(t *testing.T) {
+	scheduled := metav1.NewTime(time.Date(2025, time.January, 1, 10, 0, 0, 0, time.UTC))
+	earlier := metav1.NewTime(scheduled.Add(-time.Hour))
+
+	assert.False(t, IsCronJobSucceeded(&batchv1.CronJob{}))
+	assert.False(t, IsCronJobSucceeded(&batchv1.CronJob{Status: batchv1.CronJobStatus{LastScheduleTime: &scheduled, LastSuccessfulTime: &earlier}}))
+	assert.True(t, IsCronJobSucceeded(&batchv1.CronJob{Status: batchv1.CronJobStatus{LastScheduleTime: &scheduled, LastSuccessfulTime: &scheduled}}))
+}
+
+func TestWaitForJobComplete(t *testing.T) {
+	job := func(status batchv1.JobStatus) *batchv1.Job {
+		return &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Name: "migrate", Namespace: "default"},
+			Spec:       batchv1.JobSpec{BackoffLimit: ptr.To[int32](1)},
+			Status:     status,
+		}
+	}
+
+	t.Run("completes", func(t *testing.T) {
+		clock := waittest.NewClock()
+		waittest.Prepend(t, NewTestClient(t), "jobs", waittest.NewScript(clock, "default", "migrate").
+			Return(job(batchv1.JobStatus{Active: 1})).
+			At(time.Minute, job(batchv1.JobStatus{Succeeded: 1, Conditions: []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}})))
+
+		require.NoError(t, WaitForJobCompleteE(t, &KubectlOptions{}, "migrate", "default", 5*time.Minute, wait.WithClock(clock)))
+		assert.Equal(t, time.Minute, clock.Elapsed())
+	})
+
+	t.Run("fails fast once backoffLimit is exceeded", func(t *testing.T) {
+		clock := waittest.NewClock()
+		waittest.Prepend(t, NewTestClient(t), "jobs", waittest.NewScript(clock, "default", "migrate").
+			Return(job(batchv1.JobStatus{Active: 1, Failed: 1})).
+			At(30*time.Second, job(batchv1.JobStatus{Failed: 2})))
+
+		err := WaitForJobCompleteE(t, &KubectlOptions{}, "migrate", "default", 5*time.Minute, wait.WithClock(clock))
+		require.True(t, wait.IsTerminalState(err), "expected a terminal state error, got %v", err)
+		assert.Contains(t, err.Error(), "BackoffLimitExceeded")
+	})
+}
@@ -6,11 +6,12 @@ import (
 
 	"github.com/gruntwork-io/terratest/modules/testing"
 
+	"github.com/davidcollom/terratest-utils/pkg/wait"
 	"github.com/gruntwork-io/terratest/modules/k8s"
 	linkerdpolicyv1alpha1 "github.com/linkerd/linkerd2/controller/gen/apis/policy/v1alpha1"
 	"github.com/stretchr/testify/require"
 	v1meta "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
 )
 
 // ListAuthorizationPolicies retrieves all Linkerd AuthorizationPolicy resources in the specified namespace using the provided KubectlOptions.
@@ -83,7 +84,7 @@ func GetAuthorizationPolicyE(t testing.TestingT, options *k8s.KubectlOptions, na
 }
 
 // WaitForAuthorizationPolicyExists waits until the specified AuthorizationPolicy exists in the given namespace or the timeout is reached.
-// It polls the AuthorizationPolicy every 2 seconds until it exists.
+// It watches for the AuthorizationPolicy, falling back to polling, until it exists.
 //
 // Parameters:
 //   - t: The testing context.
@@ -93,21 +94,28 @@ func GetAuthorizationPolicyE(t testing.TestingT, options *k8s.KubectlOptions, na
 //   - timeout: The maximum duration to wait for the resource to exist.
 //
 // WaitForAuthorizationPolicyExists waits for the resource condition to be satisfied.
-func WaitForAuthorizationPolicyExists(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) {
-	err := WaitForAuthorizationPolicyExistsE(t, options, name, namespace, timeout)
+func WaitForAuthorizationPolicyExists(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) {
+	err := WaitForAuthorizationPolicyExistsE(t, options, name, namespace, timeout, opts...)
 	require.NoError(t, err, "AuthorizationPolicy %s/%s did not exist within timeout", namespace, name)
 }
 
 // WaitForAuthorizationPolicyExistsE waits for the resource condition to be satisfied.
-func WaitForAuthorizationPolicyExistsE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) error {
+func WaitForAuthorizationPolicyExistsE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) error {
 	linkerdClient := NewClient(t, options)
 
-	ctx := context.Background()
-	return wait.PollUntilContextTimeout(ctx, 2*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
-		_, err := linkerdClient.PolicyV1alpha1().AuthorizationPolicies(namespace).Get(ctx, name, v1meta.GetOptions{})
-		if err != nil {
-			return false, nil
-		}
-		return true, nil
-	})
+	_, err := wait.Waiter[*linkerdpolicyv1alpha1.AuthorizationPolicy]{
+		Kind:      "AuthorizationPolicy",
+		Name:      name,
+		Namespace: namespace,
+		Timeout:   timeout,
+		Get: func(ctx context.Context) (*linkerdpolicyv1alpha1.AuthorizationPolicy, error) {
+			return linkerdClient.PolicyV1alpha1().AuthorizationPolicies(namespace).Get(ctx, name, v1meta.GetOptions{})
+		},
+		Watch: func(ctx context.Context) (watch.Interface, error) {
+			return linkerdClient.PolicyV1alpha1().AuthorizationPolicies(namespace).Watch(ctx, v1meta.SingleObject(v1meta.ObjectMeta{Name: name}))
+		},
+		Ready:  wait.Exists[*linkerdpolicyv1alpha1.AuthorizationPolicy],
+		Events: wait.KubeEvents(t, options),
+	}.Wait(t, opts...)
+	return err
 }
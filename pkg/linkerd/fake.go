@@ -0,0 +1,53 @@
+package linkerd
+
+import (
+	linkerdclientset "github.com/linkerd/linkerd2/controller/gen/client/clientset/versioned"
+	fakelinkerd "github.com/linkerd/linkerd2/controller/gen/client/clientset/versioned/fake"
+
+	"github.com/davidcollom/terratest-utils/pkg/utils"
+	"github.com/gruntwork-io/terratest/modules/k8s"
+	"github.com/gruntwork-io/terratest/modules/testing"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+)
+
+// NewTestClient creates a fake Linkerd clientset seeded with objs and makes NewClient return it
+// for the rest of the test, so that every typed helper in this package runs against the fake.
+// NewClient is restored when the test finishes.
+//
+// Example usage:
+//
+//	linkerd.NewTestClient(t, &linkerdserverv1beta1.Server{...})
+//	linkerd.WaitForServerExists(t, &k8s.KubectlOptions{}, "my-server", "default", time.Second)
+func NewTestClient(t utils.CleanupT, objs ...runtime.Object) linkerdclientset.Interface {
+	client := fakelinkerd.NewSimpleClientset(objs...)
+
+	NewClient = func(t testing.TestingT, options *k8s.KubectlOptions) linkerdclientset.Interface {
+		return client
+	}
+	t.Cleanup(func() {
+		NewClient = newClient
+	})
+
+	return client
+}
+
+// NewTestDynamicClient creates a fake dynamic client seeded with objs (typically
+// *unstructured.Unstructured TrafficSplits) and makes NewDynamicClient return it for the rest of the
+// test. NewDynamicClient is restored when the test finishes.
+func NewTestDynamicClient(t utils.CleanupT, objs ...runtime.Object) dynamic.Interface {
+	client := fakedynamic.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		TrafficSplitGVR: "TrafficSplitList",
+	}, objs...)
+
+	NewDynamicClient = func(t testing.TestingT, options *k8s.KubectlOptions) dynamic.Interface {
+		return client
+	}
+	t.Cleanup(func() {
+		NewDynamicClient = newDynamicClient
+	})
+
+	return client
+}
@@ -6,11 +6,12 @@ import (
 
 	"github.com/gruntwork-io/terratest/modules/testing"
 
+	"github.com/davidcollom/terratest-utils/pkg/wait"
 	"github.com/gruntwork-io/terratest/modules/k8s"
 	linkerdpolicyv1alpha1 "github.com/linkerd/linkerd2/controller/gen/apis/policy/v1alpha1"
 	"github.com/stretchr/testify/require"
 	v1meta "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
 )
 
 // ListHTTPRoutes retrieves all Linkerd HTTPRoute resources in the specified namespace using the provided KubectlOptions.
@@ -83,7 +84,7 @@ func GetHTTPRouteE(t testing.TestingT, options *k8s.KubectlOptions, name, namesp
 }
 
 // WaitForHTTPRouteExists waits until the specified HTTPRoute exists in the given namespace or the timeout is reached.
-// It polls the HTTPRoute every 2 seconds until it exists.
+// It watches for the HTTPRoute, falling back to polling, until it exists.
 //
 // Parameters:
 //   - t: The testing context.
@@ -93,21 +94,28 @@ func GetHTTPRouteE(t testing.TestingT, options *k8s.KubectlOptions, name, namesp
 //   - timeout: The maximum duration to wait for the resource to exist.
 //
 // WaitForHTTPRouteExists waits for the resource condition to be satisfied.
-func WaitForHTTPRouteExists(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) {
-	err := WaitForHTTPRouteExistsE(t, options, name, namespace, timeout)
+func WaitForHTTPRouteExists(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) {
+	err := WaitForHTTPRouteExistsE(t, options, name, namespace, timeout, opts...)
 	require.NoError(t, err, "HTTPRoute %s/%s did not exist within timeout", namespace, name)
 }
 
 // WaitForHTTPRouteExistsE waits for the resource condition to be satisfied.
-func WaitForHTTPRouteExistsE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) error {
+func WaitForHTTPRouteExistsE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) error {
 	linkerdClient := NewClient(t, options)
 
-	ctx := context.Background()
-	return wait.PollUntilContextTimeout(ctx, 2*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
-		_, err := linkerdClient.PolicyV1alpha1().HTTPRoutes(namespace).Get(ctx, name, v1meta.GetOptions{})
-		if err != nil {
-			return false, nil
-		}
-		return true, nil
-	})
+	_, err := wait.Waiter[*linkerdpolicyv1alpha1.HTTPRoute]{
+		Kind:      "HTTPRoute",
+		Name:      name,
+		Namespace: namespace,
+		Timeout:   timeout,
+		Get: func(ctx context.Context) (*linkerdpolicyv1alpha1.HTTPRoute, error) {
+			return linkerdClient.PolicyV1alpha1().HTTPRoutes(namespace).Get(ctx, name, v1meta.GetOptions{})
+		},
+		Watch: func(ctx context.Context) (watch.Interface, error) {
+			return linkerdClient.PolicyV1alpha1().HTTPRoutes(namespace).Watch(ctx, v1meta.SingleObject(v1meta.ObjectMeta{Name: name}))
+		},
+		Ready:  wait.Exists[*linkerdpolicyv1alpha1.HTTPRoute],
+		Events: wait.KubeEvents(t, options),
+	}.Wait(t, opts...)
+	return err
 }
@@ -3,29 +3,30 @@ package linkerd
 import (
 	"github.com/gruntwork-io/terratest/modules/testing"
 
-	"github.com/davidcollom/terratest-utils/pkg/utils"
+	"github.com/davidcollom/terratest-utils/pkg/clients"
 	"github.com/gruntwork-io/terratest/modules/k8s"
 	linkerdclientset "github.com/linkerd/linkerd2/controller/gen/client/clientset/versioned"
 	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/rest"
 )
 
 // NewClient creates and returns a new Linkerd Client for use in tests.
 // It initializes the Kubernetes REST configuration and the Linkerd clientset,
-// failing the test if any errors occur during setup.
+// failing the test if any errors occur during setup. The clientset is cached
+// per cluster by pkg/clients.
 //
 // Parameters:
 //   - t: The testing context used for logging and error handling.
 //   - options: The kubectl options specifying the context and namespace.
 //
 // Returns:
-//   - *linkerdclientset.Clientset: A pointer to the initialized Linkerd client.
-//
-// NewClient creates a new client or helper instance.
-func NewClient(t testing.TestingT, options *k8s.KubectlOptions) *linkerdclientset.Clientset {
-	cfg, err := utils.GetRestConfigE(t, options)
-	require.NoError(t, err)
+//   - linkerdclientset.Interface: The initialized Linkerd clientset.
+var NewClient = newClient
 
-	client, err := linkerdclientset.NewForConfig(cfg)
+func newClient(t testing.TestingT, options *k8s.KubectlOptions) linkerdclientset.Interface {
+	client, err := clients.Get(t, options, "linkerd", func(cfg *rest.Config) (linkerdclientset.Interface, error) {
+		return linkerdclientset.NewForConfig(cfg)
+	})
 	require.NoError(t, err, "Failed to create Linkerd client")
 
 	return client
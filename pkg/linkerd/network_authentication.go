@@ -6,11 +6,12 @@ import (
 
 	"github.com/gruntwork-io/terratest/modules/testing"
 
+	"github.com/davidcollom/terratest-utils/pkg/wait"
 	"github.com/gruntwork-io/terratest/modules/k8s"
 	linkerdpolicyv1alpha1 "github.com/linkerd/linkerd2/controller/gen/apis/policy/v1alpha1"
 	"github.com/stretchr/testify/require"
 	v1meta "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
 )
 
 // ListNetworkAuthentications retrieves all Linkerd NetworkAuthentication resources in the specified namespace using the provided KubectlOptions.
@@ -83,7 +84,7 @@ func GetNetworkAuthenticationE(t testing.TestingT, options *k8s.KubectlOptions,
 }
 
 // WaitForNetworkAuthenticationExists waits until the specified NetworkAuthentication exists in the given namespace or the timeout is reached.
-// It polls the NetworkAuthentication every 2 seconds until it exists.
+// It watches for the NetworkAuthentication, falling back to polling, until it exists.
 //
 // Parameters:
 //   - t: The testing context.
@@ -93,21 +94,28 @@ func GetNetworkAuthenticationE(t testing.TestingT, options *k8s.KubectlOptions,
 //   - timeout: The maximum duration to wait for the resource to exist.
 //
 // WaitForNetworkAuthenticationExists waits for the resource condition to be satisfied.
-func WaitForNetworkAuthenticationExists(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) {
-	err := WaitForNetworkAuthenticationExistsE(t, options, name, namespace, timeout)
+func WaitForNetworkAuthenticationExists(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) {
+	err := WaitForNetworkAuthenticationExistsE(t, options, name, namespace, timeout, opts...)
 	require.NoError(t, err, "NetworkAuthentication %s/%s did not exist within timeout", namespace, name)
 }
 
 // WaitForNetworkAuthenticationExistsE waits for the resource condition to be satisfied.
-func WaitForNetworkAuthenticationExistsE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) error {
+func WaitForNetworkAuthenticationExistsE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) error {
 	linkerdClient := NewClient(t, options)
 
-	ctx := context.Background()
-	return wait.PollUntilContextTimeout(ctx, 2*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
-		_, err := linkerdClient.PolicyV1alpha1().NetworkAuthentications(namespace).Get(ctx, name, v1meta.GetOptions{})
-		if err != nil {
-			return false, nil
-		}
-		return true, nil
-	})
+	_, err := wait.Waiter[*linkerdpolicyv1alpha1.NetworkAuthentication]{
+		Kind:      "NetworkAuthentication",
+		Name:      name,
+		Namespace: namespace,
+		Timeout:   timeout,
+		Get: func(ctx context.Context) (*linkerdpolicyv1alpha1.NetworkAuthentication, error) {
+			return linkerdClient.PolicyV1alpha1().NetworkAuthentications(namespace).Get(ctx, name, v1meta.GetOptions{})
+		},
+		Watch: func(ctx context.Context) (watch.Interface, error) {
+			return linkerdClient.PolicyV1alpha1().NetworkAuthentications(namespace).Watch(ctx, v1meta.SingleObject(v1meta.ObjectMeta{Name: name}))
+		},
+		Ready:  wait.Exists[*linkerdpolicyv1alpha1.NetworkAuthentication],
+		Events: wait.KubeEvents(t, options),
+	}.Wait(t, opts...)
+	return err
 }
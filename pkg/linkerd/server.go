@@ -6,11 +6,12 @@ import (
 
 	"github.com/gruntwork-io/terratest/modules/testing"
 
+	"github.com/davidcollom/terratest-utils/pkg/wait"
 	"github.com/gruntwork-io/terratest/modules/k8s"
 	linkerdserverv1beta1 "github.com/linkerd/linkerd2/controller/gen/apis/server/v1beta1"
 	"github.com/stretchr/testify/require"
 	v1meta "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
 )
 
 // ListServers retrieves all Linkerd Server resources in the specified namespace using the provided KubectlOptions.
@@ -83,7 +84,7 @@ func GetServerE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace
 }
 
 // WaitForServerExists waits until the specified Server exists in the given namespace or the timeout is reached.
-// It polls the Server every 2 seconds until it exists.
+// It watches for the Server, falling back to polling, until it exists.
 //
 // Parameters:
 //   - t: The testing context.
@@ -93,21 +94,28 @@ func GetServerE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace
 //   - timeout: The maximum duration to wait for the resource to exist.
 //
 // WaitForServerExists waits for the resource condition to be satisfied.
-func WaitForServerExists(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) {
-	err := WaitForServerExistsE(t, options, name, namespace, timeout)
+func WaitForServerExists(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) {
+	err := WaitForServerExistsE(t, options, name, namespace, timeout, opts...)
 	require.NoError(t, err, "Server %s/%s did not exist within timeout", namespace, name)
 }
 
 // WaitForServerExistsE waits for the resource condition to be satisfied.
-func WaitForServerExistsE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) error {
+func WaitForServerExistsE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) error {
 	linkerdClient := NewClient(t, options)
 
-	ctx := context.Background()
-	return wait.PollUntilContextTimeout(ctx, 2*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
-		_, err := linkerdClient.ServerV1beta1().Servers(namespace).Get(ctx, name, v1meta.GetOptions{})
-		if err != nil {
-			return false, nil
-		}
-		return true, nil
-	})
+	_, err := wait.Waiter[*linkerdserverv1beta1.Server]{
+		Kind:      "Server",
+		Name:      name,
+		Namespace: namespace,
+		Timeout:   timeout,
+		Get: func(ctx context.Context) (*linkerdserverv1beta1.Server, error) {
+			return linkerdClient.ServerV1beta1().Servers(namespace).Get(ctx, name, v1meta.GetOptions{})
+		},
+		Watch: func(ctx context.Context) (watch.Interface, error) {
+			return linkerdClient.ServerV1beta1().Servers(namespace).Watch(ctx, v1meta.SingleObject(v1meta.ObjectMeta{Name: name}))
+		},
+		Ready:  wait.Exists[*linkerdserverv1beta1.Server],
+		Events: wait.KubeEvents(t, options),
+	}.Wait(t, opts...)
+	return err
 }
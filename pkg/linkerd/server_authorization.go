@@ -6,11 +6,12 @@ import (
 
 	"github.com/gruntwork-io/terratest/modules/testing"
 
+	"github.com/davidcollom/terratest-utils/pkg/wait"
 	"github.com/gruntwork-io/terratest/modules/k8s"
 	linkerdserverauthorizationv1beta1 "github.com/linkerd/linkerd2/controller/gen/apis/serverauthorization/v1beta1"
 	"github.com/stretchr/testify/require"
 	v1meta "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
 )
 
 // ListServerAuthorizations retrieves all Linkerd ServerAuthorization resources in the specified namespace using the provided KubectlOptions.
@@ -83,7 +84,7 @@ func GetServerAuthorizationE(t testing.TestingT, options *k8s.KubectlOptions, na
 }
 
 // WaitForServerAuthorizationExists waits until the specified ServerAuthorization exists in the given namespace or the timeout is reached.
-// It polls the ServerAuthorization every 2 seconds until it exists.
+// It watches for the ServerAuthorization, falling back to polling, until it exists.
 //
 // Parameters:
 //   - t: The testing context.
@@ -93,21 +94,28 @@ func GetServerAuthorizationE(t testing.TestingT, options *k8s.KubectlOptions, na
 //   - timeout: The maximum duration to wait for the resource to exist.
 //
 // WaitForServerAuthorizationExists waits for the resource condition to be satisfied.
-func WaitForServerAuthorizationExists(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) {
-	err := WaitForServerAuthorizationExistsE(t, options, name, namespace, timeout)
+func WaitForServerAuthorizationExists(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) {
+	err := WaitForServerAuthorizationExistsE(t, options, name, namespace, timeout, opts...)
 	require.NoError(t, err, "ServerAuthorization %s/%s did not exist within timeout", namespace, name)
 }
 
 // WaitForServerAuthorizationExistsE waits for the resource condition to be satisfied.
-func WaitForServerAuthorizationExistsE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) error {
+func WaitForServerAuthorizationExistsE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) error {
 	linkerdClient := NewClient(t, options)
 
-	ctx := context.Background()
-	return wait.PollUntilContextTimeout(ctx, 2*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
-		_, err := linkerdClient.ServerauthorizationV1beta1().ServerAuthorizations(namespace).Get(ctx, name, v1meta.GetOptions{})
-		if err != nil {
-			return false, nil
-		}
-		return true, nil
-	})
+	_, err := wait.Waiter[*linkerdserverauthorizationv1beta1.ServerAuthorization]{
+		Kind:      "ServerAuthorization",
+		Name:      name,
+		Namespace: namespace,
+		Timeout:   timeout,
+		Get: func(ctx context.Context) (*linkerdserverauthorizationv1beta1.ServerAuthorization, error) {
+			return linkerdClient.ServerauthorizationV1beta1().ServerAuthorizations(namespace).Get(ctx, name, v1meta.GetOptions{})
+		},
+		Watch: func(ctx context.Context) (watch.Interface, error) {
+			return linkerdClient.ServerauthorizationV1beta1().ServerAuthorizations(namespace).Watch(ctx, v1meta.SingleObject(v1meta.ObjectMeta{Name: name}))
+		},
+		Ready:  wait.Exists[*linkerdserverauthorizationv1beta1.ServerAuthorization],
+		Events: wait.KubeEvents(t, options),
+	}.Wait(t, opts...)
+	return err
 }
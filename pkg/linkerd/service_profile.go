@@ -6,11 +6,12 @@ import (
 
 	"github.com/gruntwork-io/terratest/modules/testing"
 
+	"github.com/davidcollom/terratest-utils/pkg/wait"
 	"github.com/gruntwork-io/terratest/modules/k8s"
 	linkerdv1alpha2 "github.com/linkerd/linkerd2/controller/gen/apis/serviceprofile/v1alpha2"
 	"github.com/stretchr/testify/require"
 	v1meta "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
 )
 
 // ListServiceProfiles retrieves all Linkerd ServiceProfile resources in the specified namespace using the provided KubectlOptions.
@@ -83,7 +84,7 @@ func GetServiceProfileE(t testing.TestingT, options *k8s.KubectlOptions, name, n
 }
 
 // WaitForServiceProfileExists waits until the specified ServiceProfile exists in the given namespace or the timeout is reached.
-// It polls the ServiceProfile every 2 seconds until it exists.
+// It watches for the ServiceProfile, falling back to polling, until it exists.
 //
 // Parameters:
 //   - t: The testing context.
@@ -93,21 +94,28 @@ func GetServiceProfileE(t testing.TestingT, options *k8s.KubectlOptions, name, n
 //   - timeout: The maximum duration to wait for the resource to exist.
 //
 // WaitForServiceProfileExists waits for the resource condition to be satisfied.
-func WaitForServiceProfileExists(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) {
-	err := WaitForServiceProfileExistsE(t, options, name, namespace, timeout)
+func WaitForServiceProfileExists(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) {
+	err := WaitForServiceProfileExistsE(t, options, name, namespace, timeout, opts...)
 	require.NoError(t, err, "ServiceProfile %s/%s did not exist within timeout", namespace, name)
 }
 
 // WaitForServiceProfileExistsE waits for the resource condition to be satisfied.
-func WaitForServiceProfileExistsE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) error {
+func WaitForServiceProfileExistsE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) error {
 	linkerdClient := NewClient(t, options)
 
-	ctx := context.Background()
-	return wait.PollUntilContextTimeout(ctx, 2*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
-		_, err := linkerdClient.LinkerdV1alpha2().ServiceProfiles(namespace).Get(ctx, name, v1meta.GetOptions{})
-		if err != nil {
-			return false, nil
-		}
-		return true, nil
-	})
+	_, err := wait.Waiter[*linkerdv1alpha2.ServiceProfile]{
+		Kind:      "ServiceProfile",
+		Name:      name,
+		Namespace: namespace,
+		Timeout:   timeout,
+		Get: func(ctx context.Context) (*linkerdv1alpha2.ServiceProfile, error) {
+			return linkerdClient.LinkerdV1alpha2().ServiceProfiles(namespace).Get(ctx, name, v1meta.GetOptions{})
+		},
+		Watch: func(ctx context.Context) (watch.Interface, error) {
+			return linkerdClient.LinkerdV1alpha2().ServiceProfiles(namespace).Watch(ctx, v1meta.SingleObject(v1meta.ObjectMeta{Name: name}))
+		},
+		Ready:  wait.Exists[*linkerdv1alpha2.ServiceProfile],
+		Events: wait.KubeEvents(t, options),
+	}.Wait(t, opts...)
+	return err
 }
@@ -6,14 +6,16 @@ import (
 
 	"github.com/gruntwork-io/terratest/modules/testing"
 
-	"github.com/davidcollom/terratest-utils/pkg/utils"
+	"github.com/davidcollom/terratest-utils/pkg/clients"
+	"github.com/davidcollom/terratest-utils/pkg/wait"
 	"github.com/gruntwork-io/terratest/modules/k8s"
 	"github.com/stretchr/testify/require"
 	v1meta "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
-	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
 )
 
 var (
@@ -94,7 +96,7 @@ func GetTrafficSplitE(t testing.TestingT, options *k8s.KubectlOptions, name, nam
 }
 
 // WaitForTrafficSplitExists waits until the specified TrafficSplit exists in the given namespace or the timeout is reached.
-// It polls the TrafficSplit every 2 seconds until it exists.
+// It watches for the TrafficSplit, falling back to polling, until it exists.
 //
 // Parameters:
 //   - t: The testing context.
@@ -104,28 +106,36 @@ func GetTrafficSplitE(t testing.TestingT, options *k8s.KubectlOptions, name, nam
 //   - timeout: The maximum duration to wait for the resource to exist.
 //
 // WaitForTrafficSplitExists waits for the resource condition to be satisfied.
-func WaitForTrafficSplitExists(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) {
-	err := WaitForTrafficSplitExistsE(t, options, name, namespace, timeout)
+func WaitForTrafficSplitExists(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) {
+	err := WaitForTrafficSplitExistsE(t, options, name, namespace, timeout, opts...)
 	require.NoError(t, err, "TrafficSplit %s/%s did not exist within timeout", namespace, name)
 }
 
 // WaitForTrafficSplitExistsE waits for the resource condition to be satisfied.
-func WaitForTrafficSplitExistsE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) error {
+func WaitForTrafficSplitExistsE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) error {
 	dynamicClient := NewDynamicClient(t, options)
 
-	ctx := context.Background()
-	return wait.PollUntilContextTimeout(ctx, 2*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
-		_, err := dynamicClient.Resource(TrafficSplitGVR).Namespace(namespace).Get(ctx, name, v1meta.GetOptions{})
-		if err != nil {
-			return false, nil
-		}
-		return true, nil
-	})
+	_, err := wait.Waiter[*unstructured.Unstructured]{
+		Kind:      "TrafficSplit",
+		Name:      name,
+		Namespace: namespace,
+		Timeout:   timeout,
+		Get: func(ctx context.Context) (*unstructured.Unstructured, error) {
+			return dynamicClient.Resource(TrafficSplitGVR).Namespace(namespace).Get(ctx, name, v1meta.GetOptions{})
+		},
+		Watch: func(ctx context.Context) (watch.Interface, error) {
+			return dynamicClient.Resource(TrafficSplitGVR).Namespace(namespace).Watch(ctx, v1meta.SingleObject(v1meta.ObjectMeta{Name: name}))
+		},
+		Ready:  wait.Exists[*unstructured.Unstructured],
+		Events: wait.KubeEvents(t, options),
+	}.Wait(t, opts...)
+	return err
 }
 
 // NewDynamicClient creates and returns a new dynamic Kubernetes client for use with custom resources.
 // It initializes the Kubernetes REST configuration and the dynamic client,
-// failing the test if any errors occur during setup.
+// failing the test if any errors occur during setup. The client is cached
+// per cluster by pkg/clients.
 //
 // Parameters:
 //   - t: The testing context used for logging and error handling.
@@ -133,13 +143,12 @@ func WaitForTrafficSplitExistsE(t testing.TestingT, options *k8s.KubectlOptions,
 //
 // Returns:
 //   - dynamic.Interface: A dynamic client for interacting with custom resources.
-//
-// NewDynamicClient creates a new client or helper instance.
-func NewDynamicClient(t testing.TestingT, options *k8s.KubectlOptions) dynamic.Interface {
-	cfg, err := utils.GetRestConfigE(t, options)
-	require.NoError(t, err)
+var NewDynamicClient = newDynamicClient
 
-	client, err := dynamic.NewForConfig(cfg)
+func newDynamicClient(t testing.TestingT, options *k8s.KubectlOptions) dynamic.Interface {
+	client, err := clients.Get(t, options, "dynamic", func(cfg *rest.Config) (dynamic.Interface, error) {
+		return dynamic.NewForConfig(cfg)
+	})
 	require.NoError(t, err, "Failed to create dynamic client")
 
 	return client
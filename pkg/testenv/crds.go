@@ -0,0 +1,157 @@
+package testenv
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// CRDSource locates CRD manifests shipped inside a Go module.
+type CRDSource struct {
+	// Module is the path of the Go module, as listed in go.mod.
+	Module string
+	// Paths are directories or files, relative to the module root, holding the manifests. Documents
+	// that are not CustomResourceDefinitions, such as kustomization.yaml, are ignored.
+	Paths []string
+}
+
+// CRDSources are the CRD manifests installed by New, taken from the modules this module already
+// depends on so that their versions match the generated clients.
+var CRDSources = []CRDSource{
+	{Module: "github.com/cert-manager/cert-manager", Paths: []string{"deploy/crds"}},
+	{Module: "github.com/argoproj/argo-cd/v3", Paths: []string{"manifests/crds"}},
+	{Module: "github.com/argoproj/argo-workflows/v3", Paths: []string{"manifests/base/crds/full"}},
+	{Module: "github.com/argoproj/argo-events", Paths: []string{"manifests/base/crds"}},
+	{Module: "github.com/argoproj/argo-rollouts", Paths: []string{"manifests/crds"}},
+	{Module: "istio.io/api", Paths: []string{"kubernetes/customresourcedefinitions.gen.yaml"}},
+	{Module: "github.com/vmware-tanzu/velero", Paths: []string{"config/crd/v1/bases"}},
+	{Module: "sigs.k8s.io/gateway-api", Paths: []string{"config/crd/experimental"}},
+}
+
+// CRDPaths resolves the manifests of sources to absolute paths in the module cache using
+// `go list -m`, so replace directives in the calling module are honoured.
+//
+// Parameters:
+//   - sources: The CRD sources to resolve.
+//
+// Returns:
+//   - []string: The absolute paths of every source's manifests.
+//   - error: An error if the go command fails or a module has not been downloaded.
+func CRDPaths(sources []CRDSource) ([]string, error) {
+	if len(sources) == 0 {
+		return nil, nil
+	}
+
+	args := []string{"list", "-m", "-json"}
+	for _, source := range sources {
+		args = append(args, source.Module)
+	}
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command("go", args...)
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("locating CRD modules: %w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	dirs := map[string]string{}
+	decoder := json.NewDecoder(&stdout)
+	for decoder.More() {
+		var module struct {
+			Path string
+			Dir  string
+		}
+		if err := decoder.Decode(&module); err != nil {
+			return nil, fmt.Errorf("locating CRD modules: %w", err)
+		}
+		dirs[module.Path] = module.Dir
+	}
+
+	var paths []string
+	for _, source := range sources {
+		dir := dirs[source.Module]
+		if dir == "" {
+			return nil, fmt.Errorf("module %s is not in the module cache; run `go mod download %s`", source.Module, source.Module)
+		}
+		for _, path := range source.Paths {
+			paths = append(paths, filepath.Join(dir, filepath.FromSlash(path)))
+		}
+	}
+
+	return paths, nil
+}
+
+// generatedCRD describes a CRD whose project publishes no plain manifests in its Go module.
+type generatedCRD struct {
+	group, version, kind, plural string
+	scope                        apiextensionsv1.ResourceScope
+}
+
+// generated lists the kinds used by the Flux, External Secrets and Linkerd helpers. Flux's and
+// External Secrets' API modules ship Go types only and Linkerd ships its CRDs as Helm templates.
+var generated = []generatedCRD{
+	{"kustomize.toolkit.fluxcd.io", "v1", "Kustomization", "kustomizations", apiextensionsv1.NamespaceScoped},
+	{"helm.toolkit.fluxcd.io", "v2", "HelmRelease", "helmreleases", apiextensionsv1.NamespaceScoped},
+	{"source.toolkit.fluxcd.io", "v1", "GitRepository", "gitrepositories", apiextensionsv1.NamespaceScoped},
+	{"source.toolkit.fluxcd.io", "v1", "HelmRepository", "helmrepositories", apiextensionsv1.NamespaceScoped},
+	{"source.toolkit.fluxcd.io", "v1", "HelmChart", "helmcharts", apiextensionsv1.NamespaceScoped},
+	{"source.toolkit.fluxcd.io", "v1", "Bucket", "buckets", apiextensionsv1.NamespaceScoped},
+	{"source.toolkit.fluxcd.io", "v1", "OCIRepository", "ocirepositories", apiextensionsv1.NamespaceScoped},
+	{"external-secrets.io", "v1", "ExternalSecret", "externalsecrets", apiextensionsv1.NamespaceScoped},
+	{"external-secrets.io", "v1", "ClusterExternalSecret", "clusterexternalsecrets", apiextensionsv1.ClusterScoped},
+	{"external-secrets.io", "v1", "SecretStore", "secretstores", apiextensionsv1.NamespaceScoped},
+	{"external-secrets.io", "v1", "ClusterSecretStore", "clustersecretstores", apiextensionsv1.ClusterScoped},
+	{"external-secrets.io", "v1alpha1", "PushSecret", "pushsecrets", apiextensionsv1.NamespaceScoped},
+	{"policy.linkerd.io", "v1beta1", "Server", "servers", apiextensionsv1.NamespaceScoped},
+	{"policy.linkerd.io", "v1beta1", "ServerAuthorization", "serverauthorizations", apiextensionsv1.NamespaceScoped},
+	{"policy.linkerd.io", "v1alpha1", "AuthorizationPolicy", "authorizationpolicies", apiextensionsv1.NamespaceScoped},
+	{"policy.linkerd.io", "v1alpha1", "HTTPRoute", "httproutes", apiextensionsv1.NamespaceScoped},
+	{"policy.linkerd.io", "v1alpha1", "MeshTLSAuthentication", "meshtlsauthentications", apiextensionsv1.NamespaceScoped},
+	{"policy.linkerd.io", "v1alpha1", "NetworkAuthentication", "networkauthentications", apiextensionsv1.NamespaceScoped},
+	{"linkerd.io", "v1alpha2", "ServiceProfile", "serviceprofiles", apiextensionsv1.NamespaceScoped},
+	{"split.smi-spec.io", "v1alpha1", "TrafficSplit", "trafficsplits", apiextensionsv1.NamespaceScoped},
+}
+
+// GeneratedCRDs returns schemaless CRDs, with a status subresource, for the kinds whose projects
+// publish no plain manifests in their Go modules. The API server accepts any spec and status for
+// these kinds, so tests can seed them freely but get no schema validation.
+func GeneratedCRDs() []*apiextensionsv1.CustomResourceDefinition {
+	preserve := true
+	crds := make([]*apiextensionsv1.CustomResourceDefinition, 0, len(generated))
+	for _, g := range generated {
+		crds = append(crds, &apiextensionsv1.CustomResourceDefinition{
+			ObjectMeta: metav1.ObjectMeta{Name: g.plural + "." + g.group},
+			Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+				Group: g.group,
+				Names: apiextensionsv1.CustomResourceDefinitionNames{
+					Kind:     g.kind,
+					ListKind: g.kind + "List",
+					Plural:   g.plural,
+					Singular: strings.ToLower(g.kind),
+				},
+				Scope: g.scope,
+				Versions: []apiextensionsv1.CustomResourceDefinitionVersion{{
+					Name:    g.version,
+					Served:  true,
+					Storage: true,
+					Schema: &apiextensionsv1.CustomResourceValidation{
+						OpenAPIV3Schema: &apiextensionsv1.JSONSchemaProps{
+							Type:                   "object",
+							XPreserveUnknownFields: &preserve,
+						},
+					},
+					Subresources: &apiextensionsv1.CustomResourceSubresources{
+						Status: &apiextensionsv1.CustomResourceSubresourceStatus{},
+					},
+				}},
+			},
+		})
+	}
+
+	return crds
+}
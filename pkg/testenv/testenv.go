@@ -0,0 +1,119 @@
+// Package testenv starts a local kube-apiserver and etcd with controller-runtime's envtest and
+// installs the CRDs of every project supported by terratest-utils, so that the helpers in each
+// package can be exercised against a real API server without a cluster or network access. Tests
+// create resources and drive their status subresources themselves, playing the controller's part.
+//
+// The kube-apiserver and etcd binaries are located through the KUBEBUILDER_ASSETS environment
+// variable, which `setup-envtest use -p path` prints.
+//
+// Example usage:
+//
+//	func TestCertificate(t *testing.T) {
+//	    options := testenv.Start(t)
+//	    // create a Certificate, set its Ready condition through the status subresource, then:
+//	    certmanager.WaitForCertificateReady(t, options, "my-cert", "default", time.Minute)
+//	}
+package testenv
+
+import (
+	"fmt"
+
+	"github.com/davidcollom/terratest-utils/pkg/clients"
+	"github.com/davidcollom/terratest-utils/pkg/utils"
+	"github.com/gruntwork-io/terratest/modules/k8s"
+	"github.com/gruntwork-io/terratest/modules/testing"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+)
+
+// Environment is a local control plane with the supported CRDs installed. Most tests use Start,
+// which ties the control plane to the test; Environment suits a TestMain that shares one control
+// plane across a package's tests.
+type Environment struct {
+	// Env is the underlying envtest environment. Fields such as BinaryAssetsDirectory or
+	// ControlPlane may be adjusted before calling Start.
+	Env *envtest.Environment
+}
+
+// New returns an Environment configured to install the CRDs from CRDSources and GeneratedCRDs.
+// The CRD manifests are located in the module cache, so every module in CRDSources must be
+// downloaded.
+//
+// Returns:
+//   - *Environment: The configured, not yet started, environment.
+//   - error: An error if a module's manifests could not be located.
+func New() (*Environment, error) {
+	paths, err := CRDPaths(CRDSources)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Environment{
+		Env: &envtest.Environment{
+			CRDDirectoryPaths:     paths,
+			CRDs:                  GeneratedCRDs(),
+			ErrorIfCRDPathMissing: true,
+		},
+	}, nil
+}
+
+// Start starts the control plane, installs the CRDs and waits for them to be served.
+//
+// Returns:
+//   - *k8s.KubectlOptions: Options for the "default" namespace with RestConfig pointing at the
+//     control plane, ready to pass to any helper in this module.
+//   - error: An error if the control plane could not be started or the CRDs installed.
+func (e *Environment) Start() (*k8s.KubectlOptions, error) {
+	cfg, err := e.Env.Start()
+	if err != nil {
+		return nil, fmt.Errorf("starting envtest control plane: %w", err)
+	}
+
+	return &k8s.KubectlOptions{Namespace: "default", RestConfig: cfg}, nil
+}
+
+// Stop stops the control plane.
+func (e *Environment) Stop() error {
+	return e.Env.Stop()
+}
+
+// Start starts an Environment for the duration of the test and returns options that point at it.
+// The control plane is stopped, and the clients cached for it discarded, when the test finishes.
+// The test fails if the environment cannot be started.
+//
+// Parameters:
+//   - t: The testing context; it must support Cleanup.
+//
+// Returns:
+//   - *k8s.KubectlOptions: Options for the "default" namespace with RestConfig set.
+func Start(t utils.CleanupT) *k8s.KubectlOptions {
+	options, err := StartE(t)
+	require.NoError(t, err)
+
+	return options
+}
+
+// StartE is like Start but returns an error instead of failing the test.
+func StartE(t utils.CleanupT) (*k8s.KubectlOptions, error) {
+	env, err := New()
+	if err != nil {
+		return nil, err
+	}
+	options, err := env.Start()
+	if err != nil {
+		return nil, err
+	}
+	t.Cleanup(func() {
+		stop(t, env, options)
+	})
+
+	return options, nil
+}
+
+// stop invalidates the cached clients for options and stops env, reporting a failure to stop.
+func stop(t testing.TestingT, env *Environment, options *k8s.KubectlOptions) {
+	clients.Invalidate(t, options)
+	if err := env.Stop(); err != nil {
+		t.Errorf("stopping envtest control plane: %v", err)
+	}
+}
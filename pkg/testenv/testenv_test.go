@@ -0,0 +1,104 @@
+package testenv_test
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	cmv1 "github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1"
+	cmmetav1 "github.com/cert-manager/cert-manager/pkg/apis/meta/v1"
+	"github.com/davidcollom/terratest-utils/pkg/certmanager"
+	"github.com/davidcollom/terratest-utils/pkg/flux"
+	"github.com/davidcollom/terratest-utils/pkg/testenv"
+	kustomizev1 "github.com/fluxcd/kustomize-controller/api/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCRDPaths(t *testing.T) {
+	paths, err := testenv.CRDPaths(testenv.CRDSources)
+	require.NoError(t, err)
+	require.NotEmpty(t, paths)
+	for _, path := range paths {
+		_, err := os.Stat(path)
+		assert.NoError(t, err)
+	}
+
+	_, err = testenv.CRDPaths([]testenv.CRDSource{{Module: "example.com/not-a-dependency"}})
+	assert.Error(t, err)
+}
+
+func TestGeneratedCRDs(t *testing.T) {
+	seen := map[string]bool{}
+	for _, crd := range testenv.GeneratedCRDs() {
+		assert.Equal(t, crd.Spec.Names.Plural+"."+crd.Spec.Group, crd.Name)
+		assert.False(t, seen[crd.Name], "duplicate CRD %s", crd.Name)
+		seen[crd.Name] = true
+		require.Len(t, crd.Spec.Versions, 1)
+		assert.NotNil(t, crd.Spec.Versions[0].Subresources.Status)
+	}
+}
+
+// requireAssets skips the test unless the envtest control plane binaries are available.
+func requireAssets(t *testing.T) {
+	if os.Getenv("KUBEBUILDER_ASSETS") != "" {
+		return
+	}
+	if _, err := os.Stat("/usr/local/kubebuilder/bin/kube-apiserver"); err != nil {
+		t.Skip("envtest binaries not found; set KUBEBUILDER_ASSETS, e.g. with `setup-envtest use -p path`")
+	}
+}
+
+func TestStart(t *testing.T) {
+	requireAssets(t)
+	options := testenv.Start(t)
+	ctx := context.Background()
+
+	t.Run("cert-manager Certificate", func(t *testing.T) {
+		client, err := certmanager.NewClient(t, options)
+		require.NoError(t, err)
+
+		cert, err := client.CertmanagerV1().Certificates("default").Create(ctx, &cmv1.Certificate{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-cert", Namespace: "default"},
+			Spec: cmv1.CertificateSpec{
+				SecretName: "test-cert",
+				DNSNames:   []string{"example.com"},
+				IssuerRef:  cmmetav1.IssuerReference{Name: "test-issuer"},
+			},
+		}, metav1.CreateOptions{})
+		require.NoError(t, err)
+
+		cert.Status.Conditions = []cmv1.CertificateCondition{{
+			Type: cmv1.CertificateConditionReady, Status: cmmetav1.ConditionTrue, Reason: "Ready",
+		}}
+		_, err = client.CertmanagerV1().Certificates("default").UpdateStatus(ctx, cert, metav1.UpdateOptions{})
+		require.NoError(t, err)
+
+		certmanager.WaitForCertificateReady(t, options, "test-cert", "default", 30*time.Second)
+	})
+
+	t.Run("Flux Kustomization", func(t *testing.T) {
+		client, err := flux.NewFluxClient(t, options)
+		require.NoError(t, err)
+
+		ks := &kustomizev1.Kustomization{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-ks", Namespace: "default"},
+			Spec: kustomizev1.KustomizationSpec{
+				Interval: metav1.Duration{Duration: time.Minute},
+				Path:     "./",
+				Prune:    true,
+			},
+		}
+		require.NoError(t, client.Create(ctx, ks))
+
+		apimeta.SetStatusCondition(&ks.Status.Conditions, metav1.Condition{
+			Type: "Ready", Status: metav1.ConditionTrue, Reason: "ReconciliationSucceeded",
+		})
+		require.NoError(t, client.Status().Update(ctx, ks))
+
+		flux.WaitForKustomizationReady(t, options, "test-ks", "default", 30*time.Second)
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/gruntwork-io/terratest/modules/testing"
 	"k8s.io/client-go/rest"
 
+	"github.com/davidcollom/terratest-utils/pkg/clients"
 	"github.com/gruntwork-io/terratest/modules/k8s"
 )
 
@@ -11,6 +12,7 @@ import (
 // If the RestConfig field in options is already set, it returns that configuration directly.
 // Otherwise, it attempts to load the configuration from the kubeconfig file path specified in options,
 // using the provided context name. Returns the REST config or an error if loading fails.
+// Loaded configurations are cached per kubeconfig path and context by clients.RestConfigE.
 //
 // Parameters:
 //   - t: The testing context, used for logging and helper tracking.
@@ -20,13 +22,5 @@ import (
 //   - *rest.Config: The Kubernetes REST client configuration.
 //   - error: An error if the configuration could not be loaded.
 func GetRestConfigE(t testing.TestingT, options *k8s.KubectlOptions) (*rest.Config, error) {
-	if options.RestConfig != nil {
-		return options.RestConfig, nil
-	}
-
-	cfgPath, err := options.GetConfigPath(t)
-	if err != nil {
-		return nil, err
-	}
-	return k8s.LoadApiClientConfigE(cfgPath, options.ContextName)
+	return clients.RestConfigE(t, options)
 }
@@ -0,0 +1,20 @@
+package utils
+
+import (
+	"github.com/gruntwork-io/terratest/modules/testing"
+)
+
+// CleanupT is a testing.TestingT that can register cleanup functions. Both *testing.T and *testing.B
+// satisfy it. The NewTestClient helpers take a CleanupT so that they can restore the constructors they
+// override once the test finishes, which testing.TestingT alone does not allow.
+type CleanupT interface {
+	testing.TestingT
+	Cleanup(func())
+}
+
+// FailureT is a CleanupT that reports whether the test has failed. Both *testing.T and *testing.B
+// satisfy it. Helpers that gather diagnostics only when a test fails take a FailureT.
+type FailureT interface {
+	CleanupT
+	Failed() bool
+}
@@ -3,14 +3,17 @@ package velero
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/gruntwork-io/terratest/modules/testing"
 
+	"github.com/davidcollom/terratest-utils/pkg/wait"
 	"github.com/gruntwork-io/terratest/modules/k8s"
 	"github.com/stretchr/testify/require"
 	velerov1 "github.com/vmware-tanzu/velero/pkg/apis/velero/v1"
-	"k8s.io/apimachinery/pkg/util/wait"
+	"github.com/vmware-tanzu/velero/pkg/label"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -36,7 +39,7 @@ func ListBackups(t testing.TestingT, options *k8s.KubectlOptions, namespace stri
 
 // ListBackupsE lists matching resources.
 func ListBackupsE(t testing.TestingT, options *k8s.KubectlOptions, namespace string) ([]velerov1.Backup, error) {
-	client, err := NewVeleroClient(options.RestConfig)
+	client, err := NewClient(t, options)
 	if err != nil {
 		return nil, err
 	}
@@ -52,7 +55,7 @@ func ListBackupsE(t testing.TestingT, options *k8s.KubectlOptions, namespace str
 }
 
 // WaitForBackupSucceeded waits until the specified Velero backup reaches the "Completed" phase or the timeout is reached.
-// It polls the backup status every 2 seconds and fails the test if the backup does not complete successfully within the given timeout.
+// It watches the backup status, falling back to polling, and fails the test if the backup does not complete successfully within the given timeout.
 //
 // Parameters:
 //   - t: The testing context.
@@ -62,28 +65,163 @@ func ListBackupsE(t testing.TestingT, options *k8s.KubectlOptions, namespace str
 //   - timeout: The maximum duration to wait for the backup to complete.
 //
 // WaitForBackupSucceeded waits for the resource condition to be satisfied.
-func WaitForBackupSucceeded(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) {
-	err := WaitForBackupSucceededE(t, options, name, namespace, timeout)
+func WaitForBackupSucceeded(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) {
+	err := WaitForBackupSucceededE(t, options, name, namespace, timeout, opts...)
 	require.NoError(t, err, "Backup %s/%s did not complete successfully", namespace, name)
 }
 
 // WaitForBackupSucceededE waits for the resource condition to be satisfied.
-func WaitForBackupSucceededE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) error {
-	client, err := NewVeleroClient(options.RestConfig)
+// It returns a *wait.TerminalStateError as soon as the Backup is Failed, PartiallyFailed or FailedValidation.
+func WaitForBackupSucceededE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) error {
+	client, err := NewClient(t, options)
 	if err != nil {
 		return err
 	}
+	_, err = wait.Waiter[*velerov1.Backup]{
+		Kind:      "Backup",
+		Name:      name,
+		Namespace: namespace,
+		Timeout:   timeout,
+		Get: func(ctx context.Context) (*velerov1.Backup, error) {
+			var backup velerov1.Backup
+			err := client.Get(ctx, ctrlclient.ObjectKey{Name: name, Namespace: namespace}, &backup)
+			return &backup, err
+		},
+		Watch: wait.ControllerRuntimeWatch(client, &velerov1.BackupList{}, namespace, name),
+		Ready: func(backup *velerov1.Backup) bool {
+			return backup.Status.Phase == velerov1.BackupPhaseCompleted
+		},
+		Failed: backupFailed,
+		Events: wait.KubeEvents(t, options),
+	}.Wait(t, opts...)
+	return err
+}
+
+// backupFailed reports the Failed, PartiallyFailed and FailedValidation phases as terminal states.
+// Velero never moves a Backup out of these phases.
+func backupFailed(backup *velerov1.Backup) error {
+	switch backup.Status.Phase {
+	case velerov1.BackupPhaseFailed, velerov1.BackupPhaseFailedValidation, velerov1.BackupPhasePartiallyFailed:
+		return &wait.TerminalStateError{
+			Phase:   string(backup.Status.Phase),
+			Message: failureMessage(backup.Status.FailureReason, backup.Status.ValidationErrors, backup.Status.Errors),
+		}
+	}
+	return nil
+}
+
+// DeleteBackup asks Velero to delete the specified Backup by creating a DeleteBackupRequest for it, as
+// `velero backup delete` does, and fails the test if the request cannot be created. Deleting the Backup
+// object directly would leave its data in object storage and let Velero sync it back.
+//
+// Parameters:
+//   - t: The testing context.
+//   - options: The kubectl options containing the Kubernetes REST config.
+//   - name: The name of the Velero backup to delete.
+//   - namespace: The namespace where the backup resides.
+//
+// Returns:
+//   - The created velerov1.DeleteBackupRequest.
+func DeleteBackup(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string) *velerov1.DeleteBackupRequest {
+	request, err := DeleteBackupE(t, options, name, namespace)
+	require.NoError(t, err, "Failed to request deletion of Backup %s/%s", namespace, name)
+	return request
+}
+
+// DeleteBackupE creates a DeleteBackupRequest for the specified Backup, labelled with the Backup's
+// name and UID so that WaitForBackupDeletedE can find it. It returns an error if the Backup does not exist.
+func DeleteBackupE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string) (*velerov1.DeleteBackupRequest, error) {
+	client, err := NewClient(t, options)
+	if err != nil {
+		return nil, err
+	}
+
 	ctx := context.Background()
+	var backup velerov1.Backup
+	if err := client.Get(ctx, ctrlclient.ObjectKey{Name: name, Namespace: namespace}, &backup); err != nil {
+		return nil, err
+	}
 
-	key := ctrlclient.ObjectKey{Name: name, Namespace: namespace}
+	request := &velerov1.DeleteBackupRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: name + "-",
+			Namespace:    namespace,
+			Labels: map[string]string{
+				velerov1.BackupNameLabel: label.GetValidName(name),
+				velerov1.BackupUIDLabel:  string(backup.UID),
+			},
+		},
+		Spec: velerov1.DeleteBackupRequestSpec{BackupName: name},
+	}
+	if err := client.Create(ctx, request); err != nil {
+		return nil, err
+	}
+	return request, nil
+}
+
+// WaitForBackupDeleted waits until the specified Velero backup no longer exists or the timeout is reached.
+// Use DeleteBackup to start the deletion.
+//
+// Parameters:
+//   - t: The testing context.
+//   - options: The kubectl options containing the Kubernetes REST config.
+//   - name: The name of the Velero backup.
+//   - namespace: The namespace where the backup resides.
+//   - timeout: The maximum duration to wait for the backup to be deleted.
+func WaitForBackupDeleted(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) {
+	err := WaitForBackupDeletedE(t, options, name, namespace, timeout, opts...)
+	require.NoError(t, err, "Backup %s/%s was not deleted", namespace, name)
+}
 
-	return wait.PollUntilContextTimeout(ctx, 2*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
-		var backup velerov1.Backup
-		err := client.Get(ctx, key, &backup)
-		if err != nil {
-			fmt.Printf("Retrying: Backup %s/%s not found: %v\n", namespace, name, err)
-			return false, nil
+// WaitForBackupDeletedE waits until the Backup is gone, which Velero does once a DeleteBackupRequest
+// for it has removed the backup's data from object storage. It returns a *wait.TerminalStateError as
+// soon as a DeleteBackupRequest for the Backup is Processed with errors, since Velero then keeps the Backup.
+func WaitForBackupDeletedE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) error {
+	client, err := NewClient(t, options)
+	if err != nil {
+		return err
+	}
+
+	_, err = wait.Waiter[*velerov1.Backup]{
+		Kind:         "Backup",
+		Name:         name,
+		Namespace:    namespace,
+		Timeout:      timeout,
+		UntilDeleted: true,
+		Get: func(ctx context.Context) (*velerov1.Backup, error) {
+			var backup velerov1.Backup
+			err := client.Get(ctx, ctrlclient.ObjectKey{Name: name, Namespace: namespace}, &backup)
+			return &backup, err
+		},
+		Watch: wait.ControllerRuntimeWatch(client, &velerov1.BackupList{}, namespace, name),
+		Failed: func(backup *velerov1.Backup) error {
+			return backupDeletionFailed(client, backup)
+		},
+		Events: wait.KubeEvents(t, options),
+	}.Wait(t, opts...)
+	return err
+}
+
+// backupDeletionFailed reports a DeleteBackupRequest for backup that Velero processed with errors as
+// a terminal state. Requests for an earlier Backup of the same name are ignored.
+func backupDeletionFailed(client ctrlclient.Client, backup *velerov1.Backup) error {
+	var requests velerov1.DeleteBackupRequestList
+	err := client.List(context.Background(), &requests, ctrlclient.InNamespace(backup.Namespace),
+		ctrlclient.MatchingLabels{velerov1.BackupNameLabel: label.GetValidName(backup.Name)})
+	if err != nil {
+		// Not being able to list requests does not mean the deletion failed; keep waiting.
+		return nil
+	}
+	for _, request := range requests.Items {
+		if uid := request.Labels[velerov1.BackupUIDLabel]; uid != "" && uid != string(backup.UID) {
+			continue
 		}
-		return backup.Status.Phase == velerov1.BackupPhaseCompleted, nil
-	})
+		if request.Status.Phase == velerov1.DeleteBackupRequestPhaseProcessed && len(request.Status.Errors) > 0 {
+			return &wait.TerminalStateError{
+				Reason:  "DeleteBackupRequestFailed",
+				Message: fmt.Sprintf("DeleteBackupRequest %s: %s", request.Name, strings.Join(request.Status.Errors, "; ")),
+			}
+		}
+	}
+	return nil
 }
@@ -2,15 +2,14 @@ package velero
 
 import (
 	"context"
-	"fmt"
 	"time"
 
 	"github.com/gruntwork-io/terratest/modules/testing"
 
+	"github.com/davidcollom/terratest-utils/pkg/wait"
 	"github.com/gruntwork-io/terratest/modules/k8s"
 	"github.com/stretchr/testify/require"
 	velerov1 "github.com/vmware-tanzu/velero/pkg/apis/velero/v1"
-	"k8s.io/apimachinery/pkg/util/wait"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -35,7 +34,7 @@ func ListBackupStorageLocation(t testing.TestingT, options *k8s.KubectlOptions,
 
 // ListBackupStorageLocationE lists matching resources.
 func ListBackupStorageLocationE(t testing.TestingT, options *k8s.KubectlOptions, namespace string) ([]velerov1.BackupStorageLocation, error) {
-	client, err := NewVeleroClient(options.RestConfig)
+	client, err := NewClient(t, options)
 	if err != nil {
 		return nil, err
 	}
@@ -51,8 +50,8 @@ func ListBackupStorageLocationE(t testing.TestingT, options *k8s.KubectlOptions,
 }
 
 // WaitForBackupStorageLocationReady waits until the specified Velero BackupStorageLocation resource
-// reaches the "Available" phase or the provided timeout is reached. It polls the resource status
-// every 2 seconds. If the resource does not become available within the timeout, the test fails.
+// reaches the "Available" phase or the provided timeout is reached. It watches the resource status,
+// falling back to polling. If the resource does not become available within the timeout, the test fails.
 //
 // Parameters:
 //   - t: The testing context.
@@ -64,28 +63,32 @@ func ListBackupStorageLocationE(t testing.TestingT, options *k8s.KubectlOptions,
 // This function is intended for use in integration or end-to-end tests to ensure that
 // a Velero BackupStorageLocation is ready before proceeding.
 // WaitForBackupStorageLocationReady waits for the resource condition to be satisfied.
-func WaitForBackupStorageLocationReady(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) {
-	err := WaitForBackupStorageLocationReadyE(t, options, name, namespace, timeout)
+func WaitForBackupStorageLocationReady(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) {
+	err := WaitForBackupStorageLocationReadyE(t, options, name, namespace, timeout, opts...)
 	require.NoError(t, err, "BackupStorageLocation %s/%s did not become Available", namespace, name)
 }
 
 // WaitForBackupStorageLocationReadyE waits for the resource condition to be satisfied.
-func WaitForBackupStorageLocationReadyE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) error {
-	client, err := NewVeleroClient(options.RestConfig)
+func WaitForBackupStorageLocationReadyE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) error {
+	client, err := NewClient(t, options)
 	if err != nil {
 		return err
 	}
-	ctx := context.Background()
-
-	key := ctrlclient.ObjectKey{Name: name, Namespace: namespace}
-
-	return wait.PollUntilContextTimeout(ctx, 2*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
-		var bsl velerov1.BackupStorageLocation
-		err := client.Get(ctx, key, &bsl)
-		if err != nil {
-			fmt.Printf("Retrying: BSL %s/%s not found: %v\n", namespace, name, err)
-			return false, nil
-		}
-		return bsl.Status.Phase == velerov1.BackupStorageLocationPhaseAvailable, nil
-	})
+	_, err = wait.Waiter[*velerov1.BackupStorageLocation]{
+		Kind:      "BackupStorageLocation",
+		Name:      name,
+		Namespace: namespace,
+		Timeout:   timeout,
+		Get: func(ctx context.Context) (*velerov1.BackupStorageLocation, error) {
+			var bsl velerov1.BackupStorageLocation
+			err := client.Get(ctx, ctrlclient.ObjectKey{Name: name, Namespace: namespace}, &bsl)
+			return &bsl, err
+		},
+		Watch: wait.ControllerRuntimeWatch(client, &velerov1.BackupStorageLocationList{}, namespace, name),
+		Ready: func(bsl *velerov1.BackupStorageLocation) bool {
+			return bsl.Status.Phase == velerov1.BackupStorageLocationPhaseAvailable
+		},
+		Events: wait.KubeEvents(t, options),
+	}.Wait(t, opts...)
+	return err
 }
@@ -0,0 +1,39 @@
+package velero
+
+import (
+	"github.com/davidcollom/terratest-utils/pkg/utils"
+	"github.com/gruntwork-io/terratest/modules/k8s"
+	"github.com/gruntwork-io/terratest/modules/testing"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+// NewTestClient creates a fake controller-runtime client, registered with the Velero scheme and
+// seeded with objs, and makes NewClient return it for the rest of the test, so that every helper in this
+// package runs against the fake. NewClient is restored when the test finishes. The returned client
+// implements client.WithWatch, so the WaitFor* helpers watch it just as they would a real cluster.
+//
+// Example usage:
+//
+//	velero.NewTestClient(t, &velerov1.Backup{...})
+//	velero.WaitForBackupSucceeded(t, &k8s.KubectlOptions{}, "my-backup", "default", time.Second)
+func NewTestClient(t utils.CleanupT, objs ...runtime.Object) client.WithWatch {
+	return NewTestClientWithInterceptors(t, interceptor.Funcs{}, objs...)
+}
+
+// NewTestClientWithInterceptors is NewTestClient with funcs intercepting the fake client's calls,
+// for example a waittest.Script's Interceptor to script how a resource's status changes.
+func NewTestClientWithInterceptors(t utils.CleanupT, funcs interceptor.Funcs, objs ...runtime.Object) client.WithWatch {
+	c := fake.NewClientBuilder().WithScheme(newScheme()).WithRuntimeObjects(objs...).WithInterceptorFuncs(funcs).Build()
+
+	NewClient = func(t testing.TestingT, options *k8s.KubectlOptions) (client.Client, error) {
+		return c, nil
+	}
+	t.Cleanup(func() {
+		NewClient = newClient
+	})
+
+	return c
+}
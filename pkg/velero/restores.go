@@ -2,7 +2,6 @@ package velero
 
 import (
 	"context"
-	"fmt"
 	"time"
 
 	"github.com/gruntwork-io/terratest/modules/testing"
@@ -12,7 +11,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
-	"k8s.io/apimachinery/pkg/util/wait"
+	"github.com/davidcollom/terratest-utils/pkg/wait"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -38,7 +37,7 @@ func ListRestores(t testing.TestingT, options *k8s.KubectlOptions, namespace str
 
 // ListRestoresE lists matching resources.
 func ListRestoresE(t testing.TestingT, options *k8s.KubectlOptions, namespace string) ([]velerov1.Restore, error) {
-	client, err := NewVeleroClient(options.RestConfig)
+	client, err := NewClient(t, options)
 	if err != nil {
 		return nil, err
 	}
@@ -54,8 +53,8 @@ func ListRestoresE(t testing.TestingT, options *k8s.KubectlOptions, namespace st
 }
 
 // WaitForRestoreCompleted waits until a Velero Restore resource reaches the "Completed" phase or the specified timeout is reached.
-// It polls the status of the Restore resource every 2 seconds. If the Restore does not reach the "Completed" phase within the timeout,
-// the test fails with a fatal error. If the Restore resource is not found during polling, it logs a retry message and continues polling.
+// It watches the status of the Restore resource, falling back to polling. If the Restore does not reach the "Completed" phase within the timeout,
+// the test fails with a fatal error. If the Restore resource is not found, it logs a retry message and keeps waiting.
 //
 // Parameters:
 //   - t: The testing context.
@@ -65,28 +64,46 @@ func ListRestoresE(t testing.TestingT, options *k8s.KubectlOptions, namespace st
 //   - timeout: The maximum duration to wait for the Restore to complete.
 //
 // WaitForRestoreCompleted waits for the resource condition to be satisfied.
-func WaitForRestoreCompleted(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) {
-	err := WaitForRestoreCompletedE(t, options, name, namespace, timeout)
+func WaitForRestoreCompleted(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) {
+	err := WaitForRestoreCompletedE(t, options, name, namespace, timeout, opts...)
 	require.NoError(t, err, "Restore %s/%s did not complete", namespace, name)
 }
 
 // WaitForRestoreCompletedE waits for the resource condition to be satisfied.
-func WaitForRestoreCompletedE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) error {
-	client, err := NewVeleroClient(options.RestConfig)
+// It returns a *wait.TerminalStateError as soon as the Restore is Failed, PartiallyFailed or FailedValidation.
+func WaitForRestoreCompletedE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) error {
+	client, err := NewClient(t, options)
 	if err != nil {
 		return err
 	}
-	ctx := context.Background()
-
-	key := ctrlclient.ObjectKey{Name: name, Namespace: namespace}
+	_, err = wait.Waiter[*velerov1.Restore]{
+		Kind:      "Restore",
+		Name:      name,
+		Namespace: namespace,
+		Timeout:   timeout,
+		Get: func(ctx context.Context) (*velerov1.Restore, error) {
+			var restore velerov1.Restore
+			err := client.Get(ctx, ctrlclient.ObjectKey{Name: name, Namespace: namespace}, &restore)
+			return &restore, err
+		},
+		Watch: wait.ControllerRuntimeWatch(client, &velerov1.RestoreList{}, namespace, name),
+		Ready: func(restore *velerov1.Restore) bool {
+			return restore.Status.Phase == velerov1.RestorePhaseCompleted
+		},
+		Failed: restoreFailed,
+		Events: wait.KubeEvents(t, options),
+	}.Wait(t, opts...)
+	return err
+}
 
-	return wait.PollUntilContextTimeout(ctx, 2*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
-		var restore velerov1.Restore
-		err := client.Get(ctx, key, &restore)
-		if err != nil {
-			fmt.Printf("Retrying: Restore %s/%s not found: %v\n", namespace, name, err)
-			return false, nil
+// restoreFailed reports the Failed, PartiallyFailed and FailedValidation phases as terminal states.
+func restoreFailed(restore *velerov1.Restore) error {
+	switch restore.Status.Phase {
+	case velerov1.RestorePhaseFailed, velerov1.RestorePhaseFailedValidation, velerov1.RestorePhasePartiallyFailed:
+		return &wait.TerminalStateError{
+			Phase:   string(restore.Status.Phase),
+			Message: failureMessage(restore.Status.FailureReason, restore.Status.ValidationErrors, restore.Status.Errors),
 		}
-		return restore.Status.Phase == velerov1.RestorePhaseCompleted, nil
-	})
+	}
+	return nil
 }
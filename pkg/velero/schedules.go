@@ -2,7 +2,6 @@ package velero
 
 import (
 	"context"
-	"fmt"
 	"time"
 
 	"github.com/gruntwork-io/terratest/modules/testing"
@@ -12,7 +11,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
-	"k8s.io/apimachinery/pkg/util/wait"
+	"github.com/davidcollom/terratest-utils/pkg/wait"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -37,7 +36,7 @@ func ListSchedules(t testing.TestingT, options *k8s.KubectlOptions, namespace st
 
 // ListSchedulesE lists matching resources.
 func ListSchedulesE(t testing.TestingT, options *k8s.KubectlOptions, namespace string) ([]velerov1.Schedule, error) {
-	client, err := NewVeleroClient(options.RestConfig)
+	client, err := NewClient(t, options)
 	if err != nil {
 		return nil, err
 	}
@@ -53,8 +52,8 @@ func ListSchedulesE(t testing.TestingT, options *k8s.KubectlOptions, namespace s
 }
 
 // WaitForScheduleToExist waits until a Velero Schedule resource with the specified name and namespace exists
-// and is in the "Enabled" phase, or until the given timeout is reached. It polls the Kubernetes API at regular
-// intervals and fails the test if the schedule does not become enabled within the timeout period.
+// and is in the "Enabled" phase, or until the given timeout is reached. It watches the Kubernetes API, falling
+// back to polling, and fails the test if the schedule does not become enabled within the timeout period.
 //
 // Parameters:
 //   - t: The testing context.
@@ -65,28 +64,42 @@ func ListSchedulesE(t testing.TestingT, options *k8s.KubectlOptions, namespace s
 //
 // This function logs retries and fails the test with a fatal error if the schedule does not become enabled in time.
 // WaitForScheduleToExist waits for the resource condition to be satisfied.
-func WaitForScheduleToExist(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) {
-	err := WaitForScheduleToExistE(t, options, name, namespace, timeout)
+func WaitForScheduleToExist(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) {
+	err := WaitForScheduleToExistE(t, options, name, namespace, timeout, opts...)
 	require.NoError(t, err, "Schedule %s/%s did not become enabled", namespace, name)
 }
 
 // WaitForScheduleToExistE waits for the resource condition to be satisfied.
-func WaitForScheduleToExistE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration) error {
-	client, err := NewVeleroClient(options.RestConfig)
+// It returns a *wait.TerminalStateError as soon as the Schedule fails validation.
+func WaitForScheduleToExistE(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...wait.WaitOption) error {
+	client, err := NewClient(t, options)
 	if err != nil {
 		return err
 	}
-	ctx := context.Background()
-
-	key := ctrlclient.ObjectKey{Name: name, Namespace: namespace}
-
-	return wait.PollUntilContextTimeout(ctx, 2*time.Second, timeout, true, func(ctx context.Context) (bool, error) {
-		var schedule velerov1.Schedule
-		err := client.Get(ctx, key, &schedule)
-		if err != nil {
-			fmt.Printf("Retrying: Schedule %s/%s not found: %v\n", namespace, name, err)
-			return false, nil
-		}
-		return schedule.Status.Phase == velerov1.SchedulePhaseEnabled, nil
-	})
+	_, err = wait.Waiter[*velerov1.Schedule]{
+		Kind:      "Schedule",
+		Name:      name,
+		Namespace: namespace,
+		Timeout:   timeout,
+		Get: func(ctx context.Context) (*velerov1.Schedule, error) {
+			var schedule velerov1.Schedule
+			err := client.Get(ctx, ctrlclient.ObjectKey{Name: name, Namespace: namespace}, &schedule)
+			return &schedule, err
+		},
+		Watch: wait.ControllerRuntimeWatch(client, &velerov1.ScheduleList{}, namespace, name),
+		Ready: func(schedule *velerov1.Schedule) bool {
+			return schedule.Status.Phase == velerov1.SchedulePhaseEnabled
+		},
+		Failed: func(schedule *velerov1.Schedule) error {
+			if schedule.Status.Phase == velerov1.SchedulePhaseFailedValidation {
+				return &wait.TerminalStateError{
+					Phase:   string(schedule.Status.Phase),
+					Message: failureMessage("", schedule.Status.ValidationErrors, 0),
+				}
+			}
+			return nil
+		},
+		Events: wait.KubeEvents(t, options),
+	}.Wait(t, opts...)
+	return err
 }
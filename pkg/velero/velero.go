@@ -4,6 +4,13 @@
 package velero
 
 import (
+	"fmt"
+	"strings"
+
+	"github.com/gruntwork-io/terratest/modules/testing"
+
+	"github.com/davidcollom/terratest-utils/pkg/clients"
+	"github.com/gruntwork-io/terratest/modules/k8s"
 	velerov1 "github.com/vmware-tanzu/velero/pkg/apis/velero/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/rest"
@@ -18,12 +25,49 @@ import (
 //   - cfg: A pointer to a rest.Config object containing the Kubernetes API server configuration.
 //
 // Returns:
-//   - client.Client: A controller-runtime client configured for Velero resources.
+//   - client.Client: A controller-runtime client configured for Velero resources. It also implements
+//     client.WithWatch so that the WaitFor* helpers can watch rather than poll.
 //   - error: An error if the client could not be created.
 //
 // NewVeleroClient creates a new client or helper instance.
 func NewVeleroClient(cfg *rest.Config) (client.Client, error) {
+	return client.NewWithWatch(cfg, client.Options{Scheme: newScheme()})
+}
+
+// newScheme returns a runtime scheme with the Velero v1 API registered.
+func newScheme() *runtime.Scheme {
 	scheme := runtime.NewScheme()
 	_ = velerov1.AddToScheme(scheme)
-	return client.New(cfg, client.Options{Scheme: scheme})
+	return scheme
+}
+
+// NewClient returns the Velero client for the cluster described by options. Unlike NewVeleroClient it
+// does not require options.RestConfig to be set, and the client is built once per cluster and cached
+// by pkg/clients. The helpers in this package use it, so tests can replace it to inject a fake client.
+//
+// Parameters:
+//   - t: The testing context.
+//   - options: The kubectl options describing the cluster.
+//
+// Returns:
+//   - client.Client: A controller-runtime client configured for Velero resources.
+//   - error: An error if the REST config or client could not be created.
+var NewClient = newClient
+
+func newClient(t testing.TestingT, options *k8s.KubectlOptions) (client.Client, error) {
+	return clients.Get(t, options, "velero", NewVeleroClient)
+}
+
+// failureMessage summarises why a Backup, Restore or Schedule ended in a failed phase, preferring
+// the controller's failure reason, then any validation errors, then the item error count.
+func failureMessage(failureReason string, validationErrors []string, errs int) string {
+	switch {
+	case failureReason != "":
+		return failureReason
+	case len(validationErrors) > 0:
+		return strings.Join(validationErrors, "; ")
+	case errs > 0:
+		return fmt.Sprintf("%d item(s) failed", errs)
+	}
+	return ""
 }
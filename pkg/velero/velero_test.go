@@ -0,0 +1,109 @@
+package velero
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/davidcollom/terratest-utils/pkg/wait"
+	"github.com/davidcollom/terratest-utils/pkg/waittest"
+	"github.com/gruntwork-io/terratest/modules/k8s"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	velerov1 "github.com/vmware-tanzu/velero/pkg/apis/velero/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestWaitForBackupSucceeded(t *testing.T) {
+	tests := []struct {
+		name           string
+		phase          velerov1.BackupPhase
+		expectError    bool
+		expectTerminal bool
+	}{
+		{name: "completed", phase: velerov1.BackupPhaseCompleted},
+		{name: "in progress", phase: velerov1.BackupPhaseInProgress, expectError: true},
+		{name: "failed", phase: velerov1.BackupPhaseFailed, expectError: true, expectTerminal: true},
+		{name: "partially failed", phase: velerov1.BackupPhasePartiallyFailed, expectError: true, expectTerminal: true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			NewTestClient(t, &velerov1.Backup{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-backup", Namespace: "velero"},
+				Status:     velerov1.BackupStatus{Phase: tc.phase},
+			})
+
+			err := WaitForBackupSucceededE(t, &k8s.KubectlOptions{}, "test-backup", "velero", 200*time.Millisecond, wait.WithInterval(10*time.Millisecond))
+
+			if tc.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectTerminal, wait.IsTerminalState(err))
+		})
+	}
+}
+
+func TestWaitForBackupSucceededScripted(t *testing.T) {
+	backup := func(phase velerov1.BackupPhase) *velerov1.Backup {
+		return &velerov1.Backup{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-backup", Namespace: "velero"},
+			Status:     velerov1.BackupStatus{Phase: phase},
+		}
+	}
+
+	clock := waittest.NewClock()
+	script := waittest.NewScript(clock, "velero", "test-backup").
+		At(0, backup(velerov1.BackupPhaseNew)).
+		At(time.Minute, backup(velerov1.BackupPhaseInProgress)).
+		At(5*time.Minute, backup(velerov1.BackupPhaseCompleted))
+	NewTestClientWithInterceptors(t, script.Interceptor())
+
+	err := WaitForBackupSucceededE(t, &k8s.KubectlOptions{}, "test-backup", "velero", 10*time.Minute, wait.WithClock(clock), wait.WithInterval(30*time.Second))
+	assert.NoError(t, err)
+	assert.Equal(t, 5*time.Minute, clock.Elapsed())
+	assert.Equal(t, 11, script.Gets())
+}
+
+func TestDeleteBackup(t *testing.T) {
+	backup := &velerov1.Backup{ObjectMeta: metav1.ObjectMeta{Name: "nightly", Namespace: "velero", UID: "1234"}}
+
+	t.Run("deleted", func(t *testing.T) {
+		clock := waittest.NewClock()
+		script := waittest.NewScript(clock, "velero", "nightly").
+			At(0, backup).
+			AtError(time.Minute, apierrors.NewNotFound(velerov1.Resource("backups"), "nightly"))
+		client := NewTestClientWithInterceptors(t, script.Interceptor(), backup)
+
+		request := DeleteBackup(t, &k8s.KubectlOptions{}, "nightly", "velero")
+		assert.Equal(t, "nightly", request.Spec.BackupName)
+		var requests velerov1.DeleteBackupRequestList
+		require.NoError(t, client.List(context.Background(), &requests))
+		require.Len(t, requests.Items, 1)
+		assert.Equal(t, "1234", requests.Items[0].Labels[velerov1.BackupUIDLabel])
+
+		WaitForBackupDeleted(t, &k8s.KubectlOptions{}, "nightly", "velero", 10*time.Minute, wait.WithClock(clock))
+		assert.Equal(t, time.Minute, clock.Elapsed())
+	})
+
+	t.Run("deletion failed", func(t *testing.T) {
+		NewTestClient(t, backup, &velerov1.DeleteBackupRequest{
+			ObjectMeta: metav1.ObjectMeta{Name: "nightly-x7k2p", Namespace: "velero", Labels: map[string]string{
+				velerov1.BackupNameLabel: "nightly",
+				velerov1.BackupUIDLabel:  "1234",
+			}},
+			Spec: velerov1.DeleteBackupRequestSpec{BackupName: "nightly"},
+			Status: velerov1.DeleteBackupRequestStatus{
+				Phase:  velerov1.DeleteBackupRequestPhaseProcessed,
+				Errors: []string{"error deleting backup from backup storage: access denied"},
+			},
+		})
+
+		err := WaitForBackupDeletedE(t, &k8s.KubectlOptions{}, "nightly", "velero", 10*time.Minute, wait.WithClock(waittest.NewClock()))
+		require.True(t, wait.IsTerminalState(err), "expected a terminal state error, got %v", err)
+		assert.Contains(t, err.Error(), "DeleteBackupRequest nightly-x7k2p: error deleting backup from backup storage: access denied")
+	})
+}
@@ -0,0 +1,213 @@
+package wait
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/k8s"
+	"github.com/gruntwork-io/terratest/modules/logger"
+	"github.com/gruntwork-io/terratest/modules/testing"
+	"github.com/stretchr/testify/require"
+)
+
+// DefaultConcurrency is the number of targets a Group waits on at once when Concurrency is not set.
+const DefaultConcurrency = 10
+
+// Target is one resource waited on by WaitForAll. Build Targets for the WaitFor*E helpers with For,
+// or set Wait directly for helpers that take extra arguments.
+type Target struct {
+	// Name identifies the target in logs and in the Report, e.g. "HelmRelease flux-system/podinfo".
+	Name string
+	// Wait waits for the target to become ready within timeout.
+	Wait func(t testing.TestingT, options *k8s.KubectlOptions, timeout time.Duration, opts ...WaitOption) error
+}
+
+// For returns a Target that waits with waitE, which has the signature shared by the WaitFor*E helpers
+// of every package.
+//
+// Example usage:
+//
+//	wait.For("HelmRelease", "podinfo", "flux-system", flux.WaitForHelmReleaseReadyE)
+func For(kind, name, namespace string, waitE func(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...WaitOption) error) Target {
+	return Target{
+		Name: resourceRef(kind, namespace, name),
+		Wait: func(t testing.TestingT, options *k8s.KubectlOptions, timeout time.Duration, opts ...WaitOption) error {
+			return waitE(t, options, name, namespace, timeout, opts...)
+		},
+	}
+}
+
+// Result is the outcome of waiting on one Target.
+type Result struct {
+	// Target is the Name of the target.
+	Target string
+	// Ready is true when the target became ready before the deadline.
+	Ready bool
+	// Elapsed is the time from the start of WaitForAll until the target's wait finished.
+	Elapsed time.Duration
+	// Err is why the target did not become ready, typically a *TerminalStateError or *TimeoutError.
+	Err error
+}
+
+// Report is the outcome of WaitForAll, with one Result per Target in the order they were given.
+type Report struct {
+	Results []Result
+	// Elapsed is the time WaitForAll took overall.
+	Elapsed time.Duration
+}
+
+// Ready returns the Results of the targets that became ready.
+func (r *Report) Ready() []Result {
+	return r.filter(true)
+}
+
+// NotReady returns the Results of the targets that did not become ready.
+func (r *Report) NotReady() []Result {
+	return r.filter(false)
+}
+
+func (r *Report) filter(ready bool) []Result {
+	var results []Result
+	for _, result := range r.Results {
+		if result.Ready == ready {
+			results = append(results, result)
+		}
+	}
+	return results
+}
+
+// Err returns nil when every target became ready, and otherwise an error listing each target that
+// did not. The per-target errors are wrapped, so IsTerminalState, IsTimeout and errors.As see them.
+func (r *Report) Err() error {
+	notReady := r.NotReady()
+	if len(notReady) == 0 {
+		return nil
+	}
+	errs := make([]error, 0, len(notReady))
+	for _, result := range notReady {
+		errs = append(errs, fmt.Errorf("%s: %w", result.Target, result.Err))
+	}
+	return fmt.Errorf("%d of %d targets not ready after %s:\n%w", len(notReady), len(r.Results), r.Elapsed.Round(time.Millisecond), errors.Join(errs...))
+}
+
+// String renders the Report as one line per target, for logging.
+func (r *Report) String() string {
+	var b strings.Builder
+	for _, result := range r.Results {
+		if result.Ready {
+			fmt.Fprintf(&b, "READY     %s (%s)\n", result.Target, result.Elapsed.Round(time.Millisecond))
+		} else {
+			fmt.Fprintf(&b, "NOT READY %s (%s): %s\n", result.Target, result.Elapsed.Round(time.Millisecond), firstLine(result.Err))
+		}
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// firstLine returns the first line of err's message; TimeoutErrors continue over several lines.
+func firstLine(err error) string {
+	msg, _, _ := strings.Cut(err.Error(), "\n")
+	return msg
+}
+
+// Group waits on many targets at once under a shared deadline. The zero value is ready to use.
+type Group struct {
+	// Concurrency caps how many targets are waited on at once. Defaults to DefaultConcurrency.
+	Concurrency int
+	// Options are passed to every target's wait, e.g. WithInterval or WithContext.
+	Options []WaitOption
+}
+
+// WaitForAll waits until every target is ready, sharing a single deadline of timeout between them,
+// and fails the test listing the targets that did not become ready. Targets are waited on
+// concurrently, DefaultConcurrency at a time.
+//
+// Example usage:
+//
+//	wait.WaitForAll(t, options, 10*time.Minute,
+//	    wait.For("HelmRelease", "podinfo", "flux-system", flux.WaitForHelmReleaseReadyE),
+//	    wait.For("Certificate", "web-tls", "default", certmanager.WaitForCertificateReadyE),
+//	)
+//
+// Returns:
+//   - *Report: What became ready and when; returned for logging even though failures end the test.
+func WaitForAll(t testing.TestingT, options *k8s.KubectlOptions, timeout time.Duration, targets ...Target) *Report {
+	return Group{}.WaitForAll(t, options, timeout, targets...)
+}
+
+// WaitForAllE is like WaitForAll but returns the Report's error instead of failing the test.
+func WaitForAllE(t testing.TestingT, options *k8s.KubectlOptions, timeout time.Duration, targets ...Target) (*Report, error) {
+	return Group{}.WaitForAllE(t, options, timeout, targets...)
+}
+
+// WaitForAll is like the package-level WaitForAll, using the Group's concurrency and options.
+func (g Group) WaitForAll(t testing.TestingT, options *k8s.KubectlOptions, timeout time.Duration, targets ...Target) *Report {
+	report, err := g.WaitForAllE(t, options, timeout, targets...)
+	require.NoError(t, err)
+
+	return report
+}
+
+// WaitForAllE waits on targets concurrently until each is ready, has failed, or the shared deadline
+// passes. A target that is still queued for a free slot when the deadline passes is not started and
+// reported as timed out. WaitForAllE always returns a Report; the error is the Report's Err.
+func (g Group) WaitForAllE(t testing.TestingT, options *k8s.KubectlOptions, timeout time.Duration, targets ...Target) (*Report, error) {
+	s := Waiter[any]{}.settings(g.Options)
+	now := time.Now
+	if s.clock != nil {
+		now = s.clock.Now
+	}
+	concurrency := g.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultConcurrency
+	}
+
+	start := now()
+	deadline := start.Add(timeout)
+	report := &Report{Results: make([]Result, len(targets))}
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		finished int
+		slots    = make(chan struct{}, concurrency)
+	)
+	for i, target := range targets {
+		slots <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
+
+			var err error
+			remaining := deadline.Sub(now())
+			switch {
+			case s.ctx.Err() != nil:
+				err = fmt.Errorf("not started: %w", s.ctx.Err())
+			case remaining <= 0:
+				err = fmt.Errorf("not started before the %s deadline: %w", timeout, context.DeadlineExceeded)
+			default:
+				err = target.Wait(t, options, remaining, g.Options...)
+			}
+			result := Result{Target: target.Name, Ready: err == nil, Elapsed: now().Sub(start), Err: err}
+			report.Results[i] = result
+
+			mu.Lock()
+			finished++
+			progress := fmt.Sprintf("%d/%d", finished, len(targets))
+			mu.Unlock()
+			if result.Ready {
+				logger.Default.Logf(t, "[%s] %s ready after %s", progress, target.Name, result.Elapsed.Round(time.Millisecond))
+			} else {
+				logger.Default.Logf(t, "[%s] %s not ready after %s: %s", progress, target.Name, result.Elapsed.Round(time.Millisecond), firstLine(err))
+			}
+		}()
+	}
+	wg.Wait()
+	report.Elapsed = now().Sub(start)
+
+	return report, report.Err()
+}
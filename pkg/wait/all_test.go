@@ -0,0 +1,124 @@
+package wait
+
+import (
+	"context"
+	"sync/atomic"
+	gotesting "testing"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/k8s"
+	"github.com/gruntwork-io/terratest/modules/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// widget returns a Target for a Widget that becomes ready after readyAfter, fails with failed, or
+// never becomes ready when readyAfter is negative.
+func widget(name string, readyAfter time.Duration, failed error) Target {
+	var polls atomic.Int32
+	return Target{
+		Name: resourceRef("Widget", "default", name),
+		Wait: func(t testing.TestingT, options *k8s.KubectlOptions, timeout time.Duration, opts ...WaitOption) error {
+			start := time.Now()
+			_, err := Waiter[string]{
+				Kind:      "Widget",
+				Name:      name,
+				Namespace: "default",
+				Timeout:   timeout,
+				Get: func(ctx context.Context) (string, error) {
+					polls.Add(1)
+					switch {
+					case failed != nil:
+						return "Failed", nil
+					case readyAfter >= 0 && time.Since(start) >= readyAfter:
+						return "Ready", nil
+					}
+					return "Pending", nil
+				},
+				Ready: func(state string) bool { return state == "Ready" },
+				Failed: func(state string) error {
+					if state == "Failed" {
+						return failed
+					}
+					return nil
+				},
+			}.Wait(t, opts...)
+			return err
+		},
+	}
+}
+
+func TestWaitForAll(t *gotesting.T) {
+	options := &k8s.KubectlOptions{}
+	group := Group{Options: []WaitOption{WithInterval(5 * time.Millisecond)}}
+
+	t.Run("all ready", func(t *gotesting.T) {
+		report := group.WaitForAll(t, options, time.Second,
+			widget("a", 0, nil),
+			widget("b", 50*time.Millisecond, nil),
+			widget("c", 20*time.Millisecond, nil),
+		)
+		require.Len(t, report.Ready(), 3)
+		assert.Empty(t, report.NotReady())
+		assert.NoError(t, report.Err())
+		assert.Equal(t, "Widget default/b", report.Results[1].Target, "results keep the order of the targets")
+		assert.GreaterOrEqual(t, report.Results[1].Elapsed, 50*time.Millisecond)
+		assert.Less(t, report.Elapsed, 500*time.Millisecond, "targets are waited on concurrently")
+	})
+
+	t.Run("reports what did not become ready and why", func(t *gotesting.T) {
+		report, err := group.WaitForAllE(t, options, 100*time.Millisecond,
+			widget("ready", 0, nil),
+			widget("failed", 0, &TerminalStateError{Phase: "Failed", Message: "boom"}),
+			widget("stuck", -1, nil),
+		)
+		require.Error(t, err)
+		assert.True(t, IsTerminalState(err))
+		assert.True(t, IsTimeout(err))
+		assert.Contains(t, err.Error(), "2 of 3 targets not ready")
+
+		assert.True(t, report.Results[0].Ready)
+		assert.True(t, IsTerminalState(report.Results[1].Err))
+		assert.Less(t, report.Results[1].Elapsed, 100*time.Millisecond, "terminal states still fail fast")
+		assert.True(t, IsTimeout(report.Results[2].Err))
+		assert.Contains(t, report.String(), "NOT READY Widget default/stuck")
+	})
+
+	t.Run("concurrency limit shares the deadline", func(t *gotesting.T) {
+		var running, peak atomic.Int32
+		limited := func(target Target) Target {
+			wait := target.Wait
+			target.Wait = func(t testing.TestingT, options *k8s.KubectlOptions, timeout time.Duration, opts ...WaitOption) error {
+				if n := running.Add(1); n > peak.Load() {
+					peak.Store(n)
+				}
+				defer running.Add(-1)
+				return wait(t, options, timeout, opts...)
+			}
+			return target
+		}
+
+		g := group
+		g.Concurrency = 1
+		report, err := g.WaitForAllE(t, options, 100*time.Millisecond,
+			limited(widget("stuck", -1, nil)),
+			limited(widget("queued", 0, nil)),
+		)
+		require.Error(t, err)
+		assert.Equal(t, int32(1), peak.Load())
+		assert.True(t, IsTimeout(report.Results[0].Err))
+		assert.False(t, report.Results[1].Ready, "the queued target is not given a fresh timeout")
+		assert.ErrorIs(t, report.Results[1].Err, context.DeadlineExceeded)
+	})
+
+	t.Run("For adapts WaitFor*E helpers", func(t *gotesting.T) {
+		var got []string
+		target := For("Widget", "test", "default", func(t testing.TestingT, options *k8s.KubectlOptions, name, namespace string, timeout time.Duration, opts ...WaitOption) error {
+			got = append(got, namespace, name)
+			return nil
+		})
+		assert.Equal(t, "Widget default/test", target.Name)
+		require.NoError(t, target.Wait(t, options, time.Second))
+		assert.Equal(t, []string{"default", "test"}, got)
+	})
+}
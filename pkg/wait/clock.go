@@ -0,0 +1,12 @@
+package wait
+
+import "time"
+
+// Clock is the source of time for a wait. The wall clock is used unless a wait is given another
+// with WithClock; k8s.io/utils/clock.RealClock and waittest.Clock both satisfy it.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// After returns a channel that receives the current time once d has elapsed.
+	After(d time.Duration) <-chan time.Time
+}
@@ -0,0 +1,220 @@
+package wait
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/k8s"
+	"github.com/gruntwork-io/terratest/modules/testing"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/davidcollom/terratest-utils/pkg/clients"
+)
+
+const (
+	// maxEvents is the number of most recent Events included in a TimeoutError.
+	maxEvents = 10
+	// eventsTimeout bounds how long a timed out Waiter spends listing Events.
+	eventsTimeout = 10 * time.Second
+)
+
+// Status is a summary of the status stanza of an observed object.
+type Status struct {
+	Phase      string
+	Message    string
+	Conditions []Condition
+	// Finalizers are the finalizers still holding up the deletion of an object that is being
+	// deleted; it is empty for objects that are not.
+	Finalizers []string
+}
+
+// Condition is a single status condition, independent of the API group that defined it.
+type Condition struct {
+	Type    string
+	Status  string
+	Reason  string
+	Message string
+}
+
+// String formats the condition as "Type=Status (Reason): Message", omitting empty parts.
+func (c Condition) String() string {
+	s := fmt.Sprintf("%s=%s", c.Type, c.Status)
+	if c.Reason != "" {
+		s += fmt.Sprintf(" (%s)", c.Reason)
+	}
+	if c.Message != "" {
+		s += ": " + c.Message
+	}
+	return s
+}
+
+// StatusOf summarises the phase, message and conditions of any Kubernetes object. Objects are read
+// through their JSON representation, so it works for every typed API the helpers use: phase is
+// taken from status.phase (or status.state for ACME resources) and conditions from
+// status.conditions, status.listeners[].conditions and status.parents[].conditions. Once the
+// object has a deletionTimestamp, its metadata.finalizers, and the spec.finalizers of a Namespace,
+// are reported as Finalizers. It returns an empty Status for values that are not objects.
+func StatusOf(obj any) Status {
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return Status{}
+	}
+	status, _ := u["status"].(map[string]any)
+
+	summary := Status{Phase: stringField(status, "phase"), Message: stringField(status, "message")}
+	if summary.Phase == "" {
+		summary.Phase = stringField(status, "state")
+	}
+	summary.Conditions = conditionsOf(status, "")
+	for _, l := range sliceField(status, "listeners") {
+		summary.Conditions = append(summary.Conditions, conditionsOf(l, fmt.Sprintf("listeners[%s].", stringField(l, "name")))...)
+	}
+	for _, p := range sliceField(status, "parents") {
+		ref, _ := p["parentRef"].(map[string]any)
+		name := stringField(ref, "name")
+		if section := stringField(ref, "sectionName"); section != "" {
+			name += "/" + section
+		}
+		summary.Conditions = append(summary.Conditions, conditionsOf(p, fmt.Sprintf("parents[%s].", name))...)
+	}
+	if metadata, _ := u["metadata"].(map[string]any); stringField(metadata, "deletionTimestamp") != "" {
+		spec, _ := u["spec"].(map[string]any)
+		summary.Finalizers = append(stringsField(metadata, "finalizers"), stringsField(spec, "finalizers")...)
+	}
+	return summary
+}
+
+// conditionsOf returns the conditions listed under m["conditions"], with prefix prepended to their
+// types. Gateway API objects report conditions per listener and per route parent as well, which
+// StatusOf includes as e.g. "listeners[https].Programmed" and "parents[public].Accepted".
+func conditionsOf(m map[string]any, prefix string) []Condition {
+	var conditions []Condition
+	for _, cond := range sliceField(m, "conditions") {
+		conditions = append(conditions, Condition{
+			Type:    prefix + stringField(cond, "type"),
+			Status:  stringField(cond, "status"),
+			Reason:  stringField(cond, "reason"),
+			Message: stringField(cond, "message"),
+		})
+	}
+	return conditions
+}
+
+// sliceField returns the objects listed under m[key].
+func sliceField(m map[string]any, key string) []map[string]any {
+	items, _ := m[key].([]any)
+	var objects []map[string]any
+	for _, item := range items {
+		if obj, ok := item.(map[string]any); ok {
+			objects = append(objects, obj)
+		}
+	}
+	return objects
+}
+
+// stringsField returns the strings listed under m[key].
+func stringsField(m map[string]any, key string) []string {
+	items, _ := m[key].([]any)
+	var strs []string
+	for _, item := range items {
+		if s, ok := item.(string); ok {
+			strs = append(strs, s)
+		}
+	}
+	return strs
+}
+
+// stringField returns m[key] if it is a string.
+func stringField(m map[string]any, key string) string {
+	s, _ := m[key].(string)
+	return s
+}
+
+// EventLister lists the Events recorded for a resource. It is called by a Waiter when it times out.
+type EventLister func(ctx context.Context, kind, namespace, name string) ([]corev1.Event, error)
+
+// ClientEvents returns an EventLister backed by the given clientset.
+//
+// Events for cluster-scoped resources are recorded in the "default" namespace, so that namespace is
+// searched when namespace is empty. The returned Events are the most recent ones for the resource,
+// oldest first.
+func ClientEvents(client kubernetes.Interface) EventLister {
+	return func(ctx context.Context, kind, namespace, name string) ([]corev1.Event, error) {
+		if namespace == "" {
+			namespace = metav1.NamespaceDefault
+		}
+		list, err := client.CoreV1().Events(namespace).List(ctx, metav1.ListOptions{
+			FieldSelector: fmt.Sprintf("involvedObject.kind=%s,involvedObject.name=%s", kind, name),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		// Filter again client side; not every API server (or fake) honours the field selector.
+		var events []corev1.Event
+		for _, event := range list.Items {
+			if event.InvolvedObject.Kind == kind && event.InvolvedObject.Name == name {
+				events = append(events, event)
+			}
+		}
+		sort.SliceStable(events, func(i, j int) bool {
+			return EventTime(events[i]).Before(EventTime(events[j]))
+		})
+		if len(events) > maxEvents {
+			events = events[len(events)-maxEvents:]
+		}
+		return events, nil
+	}
+}
+
+// KubeEvents returns an EventLister that connects to the cluster described by options. The
+// clientset is only looked up when Events are first listed, so waits that succeed never build it,
+// and is shared with every other helper through pkg/clients.
+func KubeEvents(t testing.TestingT, options *k8s.KubectlOptions) EventLister {
+	return func(ctx context.Context, kind, namespace, name string) ([]corev1.Event, error) {
+		client, err := NewEventsClient(t, options)
+		if err != nil {
+			return nil, err
+		}
+		return ClientEvents(client)(ctx, kind, namespace, name)
+	}
+}
+
+// NewEventsClient returns the clientset KubeEvents lists Events with. It shares the cached core
+// clientset with pkg/k8s, whose NewTestClient replaces it with a fake for the duration of a test.
+var NewEventsClient = newEventsClient
+
+func newEventsClient(t testing.TestingT, options *k8s.KubectlOptions) (kubernetes.Interface, error) {
+	return clients.Get(t, options, "kubernetes", func(cfg *rest.Config) (kubernetes.Interface, error) {
+		return kubernetes.NewForConfig(cfg)
+	})
+}
+
+// EventTime returns the most recent time an Event was observed, taking Event series and the
+// deprecated and current timestamp fields into account.
+func EventTime(event corev1.Event) time.Time {
+	switch {
+	case event.Series != nil && !event.Series.LastObservedTime.IsZero():
+		return event.Series.LastObservedTime.Time
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp.Time
+	case !event.EventTime.IsZero():
+		return event.EventTime.Time
+	}
+	return event.CreationTimestamp.Time
+}
+
+// FormatEvent formats an Event as "Type Reason (xCount): Message", as timeout errors list them.
+func FormatEvent(event corev1.Event) string {
+	s := fmt.Sprintf("%s %s", event.Type, event.Reason)
+	if event.Count > 1 {
+		s += fmt.Sprintf(" (x%d)", event.Count)
+	}
+	return s + ": " + event.Message
+}
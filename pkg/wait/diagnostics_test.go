@@ -0,0 +1,136 @@
+package wait
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newEvent(name, kind, objName, reason, message string, at time.Time) *corev1.Event {
+	return &corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Name: name, Namespace: "default"},
+		InvolvedObject: corev1.ObjectReference{Kind: kind, Name: objName, Namespace: "default"},
+		Type:           corev1.EventTypeWarning,
+		Reason:         reason,
+		Message:        message,
+		Count:          1,
+		LastTimestamp:  metav1.NewTime(at),
+	}
+}
+
+func TestWaiterWaitTimeoutDiagnostics(t *testing.T) {
+	now := time.Now()
+	client := fake.NewClientset(
+		newEvent("pod.2", "Pod", "test", "BackOff", "Back-off pulling image", now),
+		newEvent("pod.1", "Pod", "test", "Failed", "Failed to pull image", now.Add(-time.Minute)),
+		newEvent("other.1", "Pod", "other", "Scheduled", "Successfully assigned", now),
+	)
+
+	pod := &corev1.Pod{
+		Status: corev1.PodStatus{
+			Phase: corev1.PodPending,
+			Conditions: []corev1.PodCondition{
+				{Type: corev1.PodReady, Status: corev1.ConditionFalse, Reason: "ContainersNotReady", Message: "containers with unready status: [app]"},
+			},
+		},
+	}
+
+	calls := 0
+	_, err := Waiter[*corev1.Pod]{
+		Kind:      "Pod",
+		Name:      "test",
+		Namespace: "default",
+		Timeout:   100 * time.Millisecond,
+		Interval:  5 * time.Millisecond,
+		Get: func(ctx context.Context) (*corev1.Pod, error) {
+			calls++
+			if calls%2 == 0 {
+				return nil, errors.New("connection refused")
+			}
+			return pod, nil
+		},
+		Ready:  func(pod *corev1.Pod) bool { return false },
+		Events: ClientEvents(client),
+	}.Wait(t)
+
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.True(t, IsTimeout(err))
+
+	var timeout *TimeoutError
+	require.ErrorAs(t, err, &timeout)
+	assert.Same(t, pod, timeout.Object)
+	assert.Equal(t, "Pending", timeout.Status.Phase)
+	require.Len(t, timeout.Status.Conditions, 1)
+	assert.Equal(t, "Ready=False (ContainersNotReady): containers with unready status: [app]", timeout.Status.Conditions[0].String())
+	assert.EqualError(t, timeout.LastGetError, "connection refused")
+	require.Len(t, timeout.Events, 2)
+	assert.Equal(t, "Failed", timeout.Events[0].Reason)
+	assert.Equal(t, "BackOff", timeout.Events[1].Reason)
+
+	msg := err.Error()
+	assert.Contains(t, msg, "timed out after 100ms waiting for Pod default/test: context deadline exceeded")
+	assert.Contains(t, msg, "phase: Pending")
+	assert.Contains(t, msg, "Ready=False (ContainersNotReady)")
+	assert.Contains(t, msg, "last get error: connection refused")
+	assert.Contains(t, msg, "Warning BackOff: Back-off pulling image")
+	assert.NotContains(t, msg, "Successfully assigned")
+}
+
+func TestWaiterWaitTimeoutNeverObserved(t *testing.T) {
+	_, err := Waiter[*corev1.Pod]{
+		Kind:     "Pod",
+		Name:     "test",
+		Timeout:  50 * time.Millisecond,
+		Interval: 5 * time.Millisecond,
+		Get: func(ctx context.Context) (*corev1.Pod, error) {
+			return nil, errors.New(`pods "test" not found`)
+		},
+		Ready: func(pod *corev1.Pod) bool { return true },
+		Events: func(ctx context.Context, kind, namespace, name string) ([]corev1.Event, error) {
+			return nil, errors.New("forbidden")
+		},
+	}.Wait(t)
+
+	var timeout *TimeoutError
+	require.ErrorAs(t, err, &timeout)
+	assert.Nil(t, timeout.Object)
+	assert.Contains(t, err.Error(), "resource was never observed")
+	assert.Contains(t, err.Error(), `last get error: pods "test" not found`)
+	assert.Contains(t, err.Error(), "events unavailable: forbidden")
+}
+
+func TestStatusOf(t *testing.T) {
+	assert.Equal(t, Status{}, StatusOf("not an object"))
+	assert.Equal(t, Status{}, StatusOf(&corev1.ConfigMap{}))
+
+	route := &unstructured.Unstructured{Object: map[string]any{"status": map[string]any{
+		"conditions": []any{map[string]any{"type": "Ready", "status": "False"}},
+		"listeners":  []any{map[string]any{"name": "https", "conditions": []any{map[string]any{"type": "Programmed", "status": "True"}}}},
+		"parents": []any{map[string]any{
+			"parentRef":  map[string]any{"name": "public", "sectionName": "https"},
+			"conditions": []any{map[string]any{"type": "Accepted", "status": "False", "reason": "NotAllowedByListeners"}},
+		}},
+	}}}
+	assert.Equal(t, []Condition{
+		{Type: "Ready", Status: "False"},
+		{Type: "listeners[https].Programmed", Status: "True"},
+		{Type: "parents[public/https].Accepted", Status: "False", Reason: "NotAllowedByListeners"},
+	}, StatusOf(route).Conditions)
+
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Finalizers: []string{"example.com/cleanup"}},
+		Spec:       corev1.NamespaceSpec{Finalizers: []corev1.FinalizerName{corev1.FinalizerKubernetes}},
+	}
+	assert.Empty(t, StatusOf(ns).Finalizers)
+	ns.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+	assert.Equal(t, []string{"example.com/cleanup", "kubernetes"}, StatusOf(ns).Finalizers)
+}
@@ -0,0 +1,136 @@
+package wait
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// TerminalStateError is returned by a Waiter when the resource reaches a state from which it will
+// not become ready without outside intervention, such as a Failed Workflow, a PartiallyFailed Velero
+// Backup or a Certificate whose issuance Failed. WaitFor* helpers return it as soon as the state is
+// observed instead of waiting out the full timeout.
+//
+// Resources that report conditions rather than phases set Phase to "<Type>=<Status>", e.g. "Ready=False".
+type TerminalStateError struct {
+	Kind      string
+	Namespace string
+	Name      string
+	Phase     string
+	Reason    string
+	Message   string
+}
+
+// Error implements the error interface.
+func (e *TerminalStateError) Error() string {
+	msg := fmt.Sprintf("%s reached terminal state", resourceRef(e.Kind, e.Namespace, e.Name))
+	if e.Phase != "" {
+		msg += fmt.Sprintf(" %q", e.Phase)
+	}
+	if e.Reason != "" {
+		msg += ": " + e.Reason
+	}
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	return msg
+}
+
+// IsTerminalState reports whether err, or any error it wraps, is a *TerminalStateError.
+func IsTerminalState(err error) bool {
+	var terminal *TerminalStateError
+	return errors.As(err, &terminal)
+}
+
+// TimeoutError is returned by a Waiter when the timeout elapses before the resource becomes ready.
+// It carries everything that was known about the resource at that point so that the failure message
+// explains why the resource never became ready, e.g. that an Issuer is Ready=False because a Secret
+// is missing. It wraps the context error, so errors.Is(err, context.DeadlineExceeded) still holds.
+type TimeoutError struct {
+	Kind      string
+	Namespace string
+	Name      string
+	Timeout   time.Duration
+	// UntilDeleted is set when the wait was for the resource to be deleted.
+	UntilDeleted bool
+
+	// Object is the last object returned by Get, or nil if Get never succeeded.
+	Object any
+	// Status summarises the phase, message and conditions of Object.
+	Status Status
+	// LastGetError is the most recent error returned by Get, if any.
+	LastGetError error
+	// Events are the most recent Events recorded for the resource, oldest first.
+	Events []corev1.Event
+	// EventsError is set when the Events could not be listed.
+	EventsError error
+
+	// Err is the underlying context error.
+	Err error
+}
+
+// Error implements the error interface. The first line matches the short form of the error; the
+// diagnostics follow on indented lines.
+func (e *TimeoutError) Error() string {
+	var b strings.Builder
+	target := resourceRef(e.Kind, e.Namespace, e.Name)
+	if e.UntilDeleted {
+		target += " to be deleted"
+	}
+	fmt.Fprintf(&b, "timed out after %s waiting for %s: %v", e.Timeout, target, e.Err)
+
+	if e.Object == nil {
+		b.WriteString("\n  resource was never observed")
+	} else {
+		if e.Status.Phase != "" {
+			fmt.Fprintf(&b, "\n  phase: %s", e.Status.Phase)
+		}
+		if e.Status.Message != "" {
+			fmt.Fprintf(&b, "\n  message: %s", e.Status.Message)
+		}
+		if len(e.Status.Conditions) > 0 {
+			b.WriteString("\n  conditions:")
+			for _, cond := range e.Status.Conditions {
+				fmt.Fprintf(&b, "\n    %s", cond)
+			}
+		}
+		if len(e.Status.Finalizers) > 0 {
+			fmt.Fprintf(&b, "\n  deletion blocked by finalizers: %s", strings.Join(e.Status.Finalizers, ", "))
+		}
+	}
+	if e.LastGetError != nil {
+		fmt.Fprintf(&b, "\n  last get error: %v", e.LastGetError)
+	}
+	if e.EventsError != nil {
+		fmt.Fprintf(&b, "\n  events unavailable: %v", e.EventsError)
+	} else if len(e.Events) > 0 {
+		b.WriteString("\n  recent events:")
+		for _, event := range e.Events {
+			fmt.Fprintf(&b, "\n    %s", FormatEvent(event))
+		}
+	}
+	return b.String()
+}
+
+// Unwrap returns the underlying context error.
+func (e *TimeoutError) Unwrap() error {
+	return e.Err
+}
+
+// IsTimeout reports whether err, or any error it wraps, is a *TimeoutError.
+func IsTimeout(err error) bool {
+	var timeout *TimeoutError
+	return errors.As(err, &timeout)
+}
+
+// resourceRef formats a resource reference as "Kind namespace/name", omitting the namespace for
+// cluster-scoped resources.
+func resourceRef(kind, namespace, name string) string {
+	if namespace == "" {
+		return fmt.Sprintf("%s %s", kind, name)
+	}
+	return fmt.Sprintf("%s %s/%s", kind, namespace, name)
+}
@@ -0,0 +1,147 @@
+package wait
+
+import (
+	"context"
+	"time"
+)
+
+// WaitOption customises a single wait. Every WaitFor* helper accepts a trailing ...WaitOption, so
+// existing callers are unaffected:
+//
+//	certmanager.WaitForCertificateReady(t, options, name, namespace, time.Minute,
+//	    wait.WithContext(t.Context()), wait.WithInterval(500*time.Millisecond))
+type WaitOption func(*settings)
+
+// settings are the values a Waiter uses for one call to Wait.
+type settings struct {
+	ctx         context.Context
+	interval    time.Duration
+	maxInterval time.Duration
+	immediate   bool
+	clock       Clock
+}
+
+// WithContext runs the wait under ctx, so that cancelling ctx (for example the test's own
+// t.Context()) stops the wait early. The Waiter's timeout still applies.
+func WithContext(ctx context.Context) WaitOption {
+	return func(s *settings) {
+		s.ctx = ctx
+	}
+}
+
+// WithInterval overrides the delay between polls. Unit tests against fake clients typically use a
+// few milliseconds.
+func WithInterval(interval time.Duration) WaitOption {
+	return func(s *settings) {
+		s.interval = interval
+		s.maxInterval = 0
+	}
+}
+
+// WithExponentialBackoff starts polling every initial and doubles the delay after each poll, up to
+// max. It suits long waits on resources that are slow to converge.
+func WithExponentialBackoff(initial, max time.Duration) WaitOption {
+	return func(s *settings) {
+		s.interval = initial
+		s.maxInterval = max
+	}
+}
+
+// WithImmediate controls whether the resource is checked as soon as the wait starts (the default)
+// or only after the first interval has elapsed.
+func WithImmediate(immediate bool) WaitOption {
+	return func(s *settings) {
+		s.immediate = immediate
+	}
+}
+
+// WithClock runs the wait on clock instead of the wall clock: the timeout and every delay between
+// polls are measured with clock, and the Waiter polls rather than watches because a watch blocks in
+// real time. Together with a virtual clock such as waittest.Clock it lets unit tests exercise long
+// waits, backoff and timeouts instantly and deterministically.
+func WithClock(clock Clock) WaitOption {
+	return func(s *settings) {
+		s.clock = clock
+	}
+}
+
+// settings resolves the Waiter's defaults and the given options.
+func (w Waiter[T]) settings(opts []WaitOption) settings {
+	s := settings{
+		ctx:       context.Background(),
+		interval:  w.Interval,
+		immediate: true,
+	}
+	for _, opt := range opts {
+		opt(&s)
+	}
+	if s.interval <= 0 {
+		s.interval = DefaultInterval
+	}
+	return s
+}
+
+// withDeadline derives the wait's context from the caller's. On the wall clock it carries the
+// timeout; on a custom clock the deadline is instead enforced by sleep.
+func (s settings) withDeadline(timeout time.Duration) (context.Context, time.Time, context.CancelFunc) {
+	if s.clock == nil {
+		ctx, cancel := context.WithTimeout(s.ctx, timeout)
+		deadline, _ := ctx.Deadline()
+		return ctx, deadline, cancel
+	}
+	ctx, cancel := context.WithCancel(s.ctx)
+	return ctx, s.clock.Now().Add(timeout), cancel
+}
+
+// sleep waits for d, returning the context's error if it is done first. On a custom clock it
+// returns context.DeadlineExceeded once the sleep reaches deadline.
+func (s settings) sleep(ctx context.Context, deadline time.Time, d time.Duration) error {
+	if s.clock == nil {
+		timer := time.NewTimer(d)
+		defer timer.Stop()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+			return nil
+		}
+	}
+
+	remaining := deadline.Sub(s.clock.Now())
+	expired := d >= remaining
+	if expired {
+		d = remaining
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-s.clock.After(d):
+	}
+	if expired {
+		return context.DeadlineExceeded
+	}
+	return nil
+}
+
+// next returns the delay before the poll that follows one made after delay.
+func (s settings) next(delay time.Duration) time.Duration {
+	if s.maxInterval <= 0 {
+		return delay
+	}
+	return min(delay*2, s.maxInterval)
+}
+
+// Remaining starts a deadline of timeout on the clock set by opts, or the wall clock, and returns a
+// function reporting how much of it is left. Helpers that run several waits one after the other use
+// it to share a single timeout between them.
+func Remaining(timeout time.Duration, opts ...WaitOption) func() time.Duration {
+	s := Waiter[any]{}.settings(opts)
+	now := time.Now
+	if s.clock != nil {
+		now = s.clock.Now
+	}
+	deadline := now().Add(timeout)
+	return func() time.Duration {
+		return deadline.Sub(now())
+	}
+}
@@ -0,0 +1,97 @@
+package wait
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/davidcollom/terratest-utils/pkg/waittest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+func TestWaitOptions(t *testing.T) {
+	pending := Waiter[string]{
+		Kind:    "Widget",
+		Name:    "test",
+		Timeout: time.Minute,
+		Get:     func(ctx context.Context) (string, error) { return "Pending", nil },
+		Ready:   func(state string) bool { return state == "Ready" },
+	}
+
+	t.Run("WithContext cancels the wait", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		start := time.Now()
+		_, err := pending.Wait(t, WithContext(ctx), WithInterval(5*time.Millisecond))
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Less(t, time.Since(start), 5*time.Second)
+
+		ctx, cancel = context.WithCancel(context.Background())
+		cancel()
+		_, err = pending.Wait(t, WithContext(ctx))
+		require.ErrorIs(t, err, context.Canceled)
+		assert.False(t, IsTimeout(err))
+	})
+
+	t.Run("WithImmediate(false) delays the first poll", func(t *testing.T) {
+		var first time.Duration
+		start := time.Now()
+		w := pending
+		w.Get = func(ctx context.Context) (string, error) {
+			first = time.Since(start)
+			return "Ready", nil
+		}
+		_, err := w.Wait(t, WithImmediate(false), WithInterval(50*time.Millisecond))
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, first, 50*time.Millisecond)
+	})
+
+	t.Run("WithExponentialBackoff doubles up to the maximum", func(t *testing.T) {
+		s := pending.settings([]WaitOption{WithExponentialBackoff(time.Second, 5*time.Second)})
+		assert.Equal(t, time.Second, s.interval)
+		assert.Equal(t, 2*time.Second, s.next(time.Second))
+		assert.Equal(t, 5*time.Second, s.next(4*time.Second))
+
+		s = pending.settings([]WaitOption{WithExponentialBackoff(time.Second, 5*time.Second), WithInterval(time.Millisecond)})
+		assert.Equal(t, time.Millisecond, s.next(time.Millisecond))
+	})
+
+	t.Run("WithClock runs the wait in virtual time", func(t *testing.T) {
+		clock := waittest.NewClock()
+		var polls []time.Duration
+		w := pending
+		w.Timeout = 10 * time.Minute
+		w.Get = func(ctx context.Context) (string, error) {
+			polls = append(polls, clock.Elapsed())
+			return "Pending", nil
+		}
+		w.Watch = func(ctx context.Context) (watch.Interface, error) {
+			t.Fatal("a wait on a custom clock must not watch")
+			return nil, nil
+		}
+
+		start := time.Now()
+		_, err := w.Wait(t, WithClock(clock), WithExponentialBackoff(time.Minute, 4*time.Minute))
+		require.True(t, IsTimeout(err))
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Less(t, time.Since(start), time.Second)
+		assert.Equal(t, 10*time.Minute, clock.Elapsed())
+		assert.Equal(t, []time.Duration{0, time.Minute, 3 * time.Minute, 7 * time.Minute}, polls)
+	})
+
+	t.Run("Remaining measures a shared deadline on the wait's clock", func(t *testing.T) {
+		clock := waittest.NewClock()
+		remaining := Remaining(10*time.Minute, WithClock(clock))
+		clock.Step(4 * time.Minute)
+		assert.Equal(t, 6*time.Minute, remaining())
+	})
+
+	t.Run("defaults", func(t *testing.T) {
+		s := pending.settings(nil)
+		assert.Equal(t, DefaultInterval, s.interval)
+		assert.True(t, s.immediate)
+	})
+}
@@ -0,0 +1,269 @@
+// Package wait provides the condition-waiting engine shared by every WaitFor* helper in
+// terratest-utils. A Waiter observes a single resource, through a watch when one is available and
+// by polling a typed getter otherwise, until a readiness predicate is satisfied, a terminal-failure
+// predicate trips, or the timeout elapses, so that poll interval, logging and timeout reporting
+// behave the same across all packages.
+//
+// Example usage:
+//
+//	_, err := wait.Waiter[*certv1.Certificate]{
+//	    Kind:      "Certificate",
+//	    Name:      name,
+//	    Namespace: namespace,
+//	    Timeout:   timeout,
+//	    Get: func(ctx context.Context) (*certv1.Certificate, error) {
+//	        return client.CertmanagerV1().Certificates(namespace).Get(ctx, name, metav1.GetOptions{})
+//	    },
+//	    Watch: func(ctx context.Context) (watch.Interface, error) {
+//	        return client.CertmanagerV1().Certificates(namespace).Watch(ctx, metav1.SingleObject(metav1.ObjectMeta{Name: name}))
+//	    },
+//	    Ready: IsCertificateReady,
+//	}.Wait(t)
+package wait
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/logger"
+	"github.com/gruntwork-io/terratest/modules/testing"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+// DefaultInterval is the poll interval used when a Waiter does not set one.
+const DefaultInterval = 2 * time.Second
+
+// Waiter waits for a single resource of type T to reach a desired state.
+//
+// Get is called once per poll. Errors returned by Get are treated as transient and retried
+// until the timeout elapses. Ready reports whether the observed object has reached the desired
+// state. Failed is optional; when it returns a non-nil error the wait stops immediately and
+// that error is returned, which lets helpers fail fast on terminal states. Ready is checked
+// first, so a ready resource that still reports an earlier failure does not fail the wait. Failed should
+// return a *TerminalStateError; its Kind, Name and Namespace are filled in from the Waiter
+// when left empty.
+//
+// With UntilDeleted set the Waiter instead waits for the resource to be gone: a NotFound error
+// from Get or a Deleted watch event ends the wait, and Ready is not used.
+type Waiter[T any] struct {
+	// Kind, Name and Namespace identify the resource in log and error messages.
+	Kind      string
+	Name      string
+	Namespace string
+
+	// Get fetches the current state of the resource.
+	Get func(ctx context.Context) (T, error)
+	// Ready reports whether the resource has reached the desired state.
+	Ready func(obj T) bool
+	// Failed reports a terminal failure state; nil means the resource may still become ready.
+	Failed func(obj T) error
+	// UntilDeleted waits for the resource to be deleted rather than ready.
+	UntilDeleted bool
+	// Watch opens a watch on the resource. Optional; when nil the Waiter polls every Interval.
+	Watch func(ctx context.Context) (watch.Interface, error)
+	// Events lists the resource's Events when the wait times out. Optional.
+	Events EventLister
+
+	// Timeout is the maximum duration to wait.
+	Timeout time.Duration
+	// Interval is the delay between polls. Defaults to DefaultInterval; see also WithInterval.
+	Interval time.Duration
+}
+
+// Wait waits for the resource until it is ready, has failed, or the timeout elapses. It returns
+// the last observed object alongside any error. On timeout the error is a *TimeoutError that
+// describes the last observed status, the last Get error and the resource's recent Events.
+//
+// When Watch is set, changes are observed through the watch as soon as they happen and Get is
+// only called to seed the wait and whenever the watch is re-established. If the watch cannot be
+// opened or reports an error, Wait logs it and polls for the rest of the wait. Waits run with
+// WithClock always poll.
+//
+// Parameters:
+//   - t: The testing context, used for logging.
+//   - opts: Options overriding the context, poll interval and backoff of this wait.
+//
+// Returns:
+//   - T: The last object returned by Get, or the zero value if Get never succeeded.
+//   - error: nil once Ready returns true, the error returned by Failed, a *TimeoutError, or the
+//     context error if the context passed with WithContext is cancelled.
+func (w Waiter[T]) Wait(t testing.TestingT, opts ...WaitOption) (T, error) {
+	s := w.settings(opts)
+	interval := s.interval
+
+	ctx, deadline, cancel := s.withDeadline(w.Timeout)
+	defer cancel()
+
+	var (
+		st       state[T]
+		watching = w.Watch != nil && s.clock == nil
+	)
+	if !s.immediate {
+		if err := s.sleep(ctx, deadline, interval); err != nil {
+			return st.last, w.doneError(err, st)
+		}
+		interval = s.next(interval)
+	}
+	for {
+		obj, err := w.Get(ctx)
+		if err != nil && w.UntilDeleted && apierrors.IsNotFound(err) {
+			return st.last, nil
+		} else if err != nil {
+			// Only log when the error changes so a missing resource does not flood the output.
+			if st.lastErr == nil || err.Error() != st.lastErr.Error() {
+				logger.Default.Logf(t, "Retrying: %s not available: %v", w.Resource(), err)
+			}
+			st.lastErr = err
+		} else if done, err := w.observe(obj, &st); done {
+			return st.last, err
+		}
+
+		if watching && ctx.Err() == nil {
+			done, err, broken := w.watch(ctx, &st)
+			if done {
+				return st.last, err
+			}
+			if broken != nil {
+				logger.Default.Logf(t, "Watch on %s failed, falling back to polling every %s: %v", w.Resource(), interval, broken)
+				watching = false
+			}
+		}
+
+		if err := s.sleep(ctx, deadline, interval); err != nil {
+			return st.last, w.doneError(err, st)
+		}
+		interval = s.next(interval)
+	}
+}
+
+// doneError builds the error returned when the wait's context is done: a *TimeoutError when it
+// expired, or the cancellation error when the caller's context was cancelled.
+func (w Waiter[T]) doneError(err error, st state[T]) error {
+	if errors.Is(err, context.Canceled) {
+		return fmt.Errorf("cancelled waiting for %s: %w", w.Resource(), err)
+	}
+	return w.timeoutError(err, st)
+}
+
+// state is what a Waiter has learned about the resource so far.
+type state[T any] struct {
+	last     T
+	observed bool
+	lastErr  error
+}
+
+// observe records obj and reports whether the wait is over, along with its result.
+func (w Waiter[T]) observe(obj T, st *state[T]) (bool, error) {
+	st.last, st.observed = obj, true
+	// Ready wins over Failed: a resource that is ready may still carry the failure of an earlier
+	// attempt, such as a failed renewal or a superseded sync, and that should not fail the wait.
+	if !w.UntilDeleted && w.Ready(obj) {
+		return true, nil
+	}
+	if w.Failed != nil {
+		if err := w.Failed(obj); err != nil {
+			return true, w.identify(err)
+		}
+	}
+	return false, nil
+}
+
+// watch consumes events from a single watch until the wait is over, the context expires or the
+// watch ends. A watch that is closed by the server is not an error; the caller re-reads the
+// resource and watches again. broken is set when the watch cannot be used at all.
+func (w Waiter[T]) watch(ctx context.Context, st *state[T]) (done bool, err error, broken error) {
+	watcher, err := w.Watch(ctx)
+	if err != nil {
+		return false, nil, err
+	}
+	defer watcher.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return false, nil, nil
+		case event, ok := <-watcher.ResultChan():
+			if !ok {
+				return false, nil, nil
+			}
+			switch event.Type {
+			case watch.Error:
+				return false, nil, apierrors.FromObject(event.Object)
+			case watch.Added, watch.Modified, watch.Deleted:
+				obj, ok := event.Object.(T)
+				if !ok {
+					return false, nil, fmt.Errorf("unexpected object %T in watch", event.Object)
+				}
+				// Fakes and some clients ignore the field selector, so check the event is ours.
+				// Cluster-scoped objects have no namespace whatever the caller passed.
+				if m, err := meta.Accessor(obj); err == nil &&
+					(m.GetName() != w.Name || (m.GetNamespace() != "" && m.GetNamespace() != w.Namespace)) {
+					continue
+				}
+				if event.Type == watch.Deleted && w.UntilDeleted {
+					return true, nil, nil
+				} else if event.Type == watch.Deleted {
+					st.lastErr = fmt.Errorf("%s was deleted", w.Resource())
+					continue
+				}
+				if done, err := w.observe(obj, st); done {
+					return true, err, nil
+				}
+			}
+		}
+	}
+}
+
+// Resource returns a human readable reference to the resource, e.g. "Certificate default/my-cert".
+func (w Waiter[T]) Resource() string {
+	return resourceRef(w.Kind, w.Namespace, w.Name)
+}
+
+// timeoutError builds the *TimeoutError returned when the wait times out.
+func (w Waiter[T]) timeoutError(err error, st state[T]) *TimeoutError {
+	terr := &TimeoutError{
+		Kind:         w.Kind,
+		Namespace:    w.Namespace,
+		Name:         w.Name,
+		Timeout:      w.Timeout,
+		UntilDeleted: w.UntilDeleted,
+		LastGetError: st.lastErr,
+		Err:          err,
+	}
+	if st.observed {
+		terr.Object = st.last
+		terr.Status = StatusOf(st.last)
+	}
+	if w.Events != nil {
+		// The wait context has expired, so list Events with a fresh, bounded one.
+		ctx, cancel := context.WithTimeout(context.Background(), eventsTimeout)
+		defer cancel()
+		terr.Events, terr.EventsError = w.Events(ctx, w.Kind, w.Namespace, w.Name)
+	}
+	return terr
+}
+
+// identify fills in the resource identity on a *TerminalStateError returned by Failed so that
+// predicates only need to describe the state itself.
+func (w Waiter[T]) identify(err error) error {
+	var terminal *TerminalStateError
+	if errors.As(err, &terminal) {
+		if terminal.Kind == "" {
+			terminal.Kind = w.Kind
+		}
+		if terminal.Name == "" {
+			terminal.Name, terminal.Namespace = w.Name, w.Namespace
+		}
+	}
+	return err
+}
+
+// Exists is a Ready predicate that is satisfied as soon as Get succeeds. It is used by the
+// WaitFor*Exists helpers for resources that report no status of their own.
+func Exists[T any](T) bool {
+	return true
+}
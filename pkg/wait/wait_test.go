@@ -0,0 +1,138 @@
+package wait
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWaiterWait(t *testing.T) {
+	errFailed := errors.New("terminal")
+
+	tests := []struct {
+		name      string
+		states    []string
+		getErrs   int
+		wantErr   error
+		wantState string
+	}{
+		{
+			name:      "ready immediately",
+			states:    []string{"Ready"},
+			wantState: "Ready",
+		},
+		{
+			name:      "ready after transient errors and pending polls",
+			states:    []string{"Pending", "Pending", "Ready"},
+			getErrs:   2,
+			wantState: "Ready",
+		},
+		{
+			name:      "terminal failure stops the wait",
+			states:    []string{"Pending", "Failed", "Ready"},
+			wantErr:   errFailed,
+			wantState: "Failed",
+		},
+		{
+			name:      "timeout",
+			states:    []string{"Pending"},
+			wantErr:   context.DeadlineExceeded,
+			wantState: "Pending",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			got, err := Waiter[string]{
+				Kind:      "Widget",
+				Name:      "test",
+				Namespace: "default",
+				Timeout:   200 * time.Millisecond,
+				Interval:  5 * time.Millisecond,
+				Get: func(ctx context.Context) (string, error) {
+					calls++
+					if calls <= tt.getErrs {
+						return "", errors.New("not found")
+					}
+					idx := calls - tt.getErrs - 1
+					if idx >= len(tt.states) {
+						idx = len(tt.states) - 1
+					}
+					return tt.states[idx], nil
+				},
+				Ready: func(state string) bool { return state == "Ready" },
+				Failed: func(state string) error {
+					if state == "Failed" {
+						return errFailed
+					}
+					return nil
+				},
+			}.Wait(t)
+
+			if tt.wantErr != nil {
+				require.Error(t, err)
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantState, got)
+		})
+	}
+}
+
+func TestWaiterResource(t *testing.T) {
+	assert.Equal(t, "Widget default/test", Waiter[string]{Kind: "Widget", Name: "test", Namespace: "default"}.Resource())
+	assert.Equal(t, "Widget test", Waiter[string]{Kind: "Widget", Name: "test"}.Resource())
+}
+
+func TestWaiterWaitTerminalStateError(t *testing.T) {
+	_, err := Waiter[string]{
+		Kind:      "Widget",
+		Name:      "test",
+		Namespace: "default",
+		Timeout:   time.Second,
+		Interval:  5 * time.Millisecond,
+		Get:       func(ctx context.Context) (string, error) { return "Failed", nil },
+		Ready:     func(state string) bool { return false },
+		Failed: func(state string) error {
+			return &TerminalStateError{Phase: state, Reason: "BackOff", Message: "image pull failed"}
+		},
+	}.Wait(t)
+
+	require.Error(t, err)
+	assert.True(t, IsTerminalState(err))
+	var terminal *TerminalStateError
+	require.ErrorAs(t, err, &terminal)
+	assert.Equal(t, "Widget", terminal.Kind)
+	assert.Equal(t, "default", terminal.Namespace)
+	assert.Equal(t, "test", terminal.Name)
+	assert.Equal(t, `Widget default/test reached terminal state "Failed": BackOff: image pull failed`, err.Error())
+}
+
+func TestWaiterWaitReadyWinsOverFailed(t *testing.T) {
+	got, err := Waiter[string]{
+		Kind:      "Widget",
+		Name:      "test",
+		Namespace: "default",
+		Timeout:   time.Second,
+		Interval:  5 * time.Millisecond,
+		Get:       func(ctx context.Context) (string, error) { return "Ready", nil },
+		Ready:     func(state string) bool { return true },
+		Failed: func(state string) error {
+			return &TerminalStateError{Phase: state, Reason: "RenewalFailed"}
+		},
+	}.Wait(t)
+
+	require.NoError(t, err)
+	assert.Equal(t, "Ready", got)
+}
+
+func TestTerminalStateErrorError(t *testing.T) {
+	assert.Equal(t, "Widget test reached terminal state", (&TerminalStateError{Kind: "Widget", Name: "test"}).Error())
+	assert.False(t, IsTerminalState(errors.New("other")))
+}
@@ -0,0 +1,28 @@
+package wait
+
+import (
+	"context"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/watch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ControllerRuntimeWatch returns a Waiter.Watch function that watches a single object through a
+// controller-runtime client. list is an empty list of the object's type, e.g. &sourcev1.GitRepositoryList{}.
+//
+// It returns nil when c cannot watch, e.g. because it was built with client.New rather than
+// client.NewWithWatch, in which case the Waiter falls back to polling.
+func ControllerRuntimeWatch(c client.Client, list client.ObjectList, namespace, name string) func(ctx context.Context) (watch.Interface, error) {
+	wc, ok := c.(client.WithWatch)
+	if !ok {
+		return nil
+	}
+	return func(ctx context.Context) (watch.Interface, error) {
+		return wc.Watch(ctx, list, &client.ListOptions{
+			Namespace:     namespace,
+			FieldSelector: fields.OneTermEqualSelector(metav1.ObjectNameField, name),
+		})
+	}
+}
@@ -0,0 +1,194 @@
+package wait
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newPod(name string, phase corev1.PodPhase) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Status:     corev1.PodStatus{Phase: phase},
+	}
+}
+
+func TestWaiterWaitWatch(t *testing.T) {
+	fw := watch.NewFake()
+	go func() {
+		fw.Add(newPod("other", corev1.PodSucceeded))
+		fw.Modify(newPod("test", corev1.PodRunning))
+		fw.Modify(newPod("test", corev1.PodSucceeded))
+	}()
+
+	gets := 0
+	got, err := Waiter[*corev1.Pod]{
+		Kind:      "Pod",
+		Name:      "test",
+		Namespace: "default",
+		Timeout:   5 * time.Second,
+		// An interval longer than the timeout proves the change was seen through the watch.
+		Interval: time.Hour,
+		Get: func(ctx context.Context) (*corev1.Pod, error) {
+			gets++
+			return newPod("test", corev1.PodPending), nil
+		},
+		Watch: func(ctx context.Context) (watch.Interface, error) { return fw, nil },
+		Ready: func(pod *corev1.Pod) bool { return pod.Status.Phase == corev1.PodSucceeded },
+	}.Wait(t)
+
+	require.NoError(t, err)
+	assert.Equal(t, "test", got.Name)
+	assert.Equal(t, 1, gets)
+}
+
+func TestWaiterWaitWatchFallsBackToPolling(t *testing.T) {
+	tests := []struct {
+		name  string
+		watch func(ctx context.Context) (watch.Interface, error)
+	}{
+		{
+			name: "watch cannot be opened",
+			watch: func(ctx context.Context) (watch.Interface, error) {
+				return nil, errors.New("watch not supported")
+			},
+		},
+		{
+			name: "watch reports an error",
+			watch: func(ctx context.Context) (watch.Interface, error) {
+				fw := watch.NewFake()
+				go fw.Error(&metav1.Status{Status: metav1.StatusFailure, Message: "too old resource version"})
+				return fw, nil
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gets := 0
+			_, err := Waiter[*corev1.Pod]{
+				Kind:      "Pod",
+				Name:      "test",
+				Namespace: "default",
+				Timeout:   time.Second,
+				Interval:  5 * time.Millisecond,
+				Get: func(ctx context.Context) (*corev1.Pod, error) {
+					gets++
+					if gets < 3 {
+						return newPod("test", corev1.PodPending), nil
+					}
+					return newPod("test", corev1.PodSucceeded), nil
+				},
+				Watch: tt.watch,
+				Ready: func(pod *corev1.Pod) bool { return pod.Status.Phase == corev1.PodSucceeded },
+			}.Wait(t)
+
+			require.NoError(t, err)
+			assert.Equal(t, 3, gets)
+		})
+	}
+}
+
+func TestControllerRuntimeWatch(t *testing.T) {
+	c := fake.NewClientBuilder().WithObjects(newPod("test", corev1.PodPending)).Build()
+
+	_, err := Waiter[*corev1.Pod]{
+		Kind:      "Pod",
+		Name:      "test",
+		Namespace: "default",
+		Timeout:   5 * time.Second,
+		Interval:  time.Hour,
+		Get: func(ctx context.Context) (*corev1.Pod, error) {
+			var pod corev1.Pod
+			err := c.Get(ctx, client.ObjectKey{Name: "test", Namespace: "default"}, &pod)
+			return &pod, err
+		},
+		Watch: ControllerRuntimeWatch(c, &corev1.PodList{}, "default", "test"),
+		Ready: func(pod *corev1.Pod) bool {
+			if pod.Status.Phase == corev1.PodPending {
+				// Move the pod on once the watch has been seeded with its current state.
+				go func() {
+					pod := pod.DeepCopy()
+					pod.Status.Phase = corev1.PodRunning
+					_ = c.Status().Update(context.Background(), pod)
+				}()
+			}
+			return pod.Status.Phase == corev1.PodRunning
+		},
+	}.Wait(t)
+	require.NoError(t, err)
+
+	assert.Nil(t, ControllerRuntimeWatch(struct{ client.Client }{c}, &corev1.PodList{}, "default", "test"))
+}
+
+func TestWaiterWaitUntilDeleted(t *testing.T) {
+	deleting := newPod("test", corev1.PodRunning)
+	deleting.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+	deleting.Finalizers = []string{"example.com/drain"}
+	notFound := apierrors.NewNotFound(corev1.Resource("pods"), "test")
+
+	t.Run("polling", func(t *testing.T) {
+		gets := 0
+		_, err := Waiter[*corev1.Pod]{
+			Kind:         "Pod",
+			Name:         "test",
+			Namespace:    "default",
+			Timeout:      time.Second,
+			Interval:     5 * time.Millisecond,
+			UntilDeleted: true,
+			Get: func(ctx context.Context) (*corev1.Pod, error) {
+				if gets++; gets < 3 {
+					return deleting, nil
+				}
+				return nil, notFound
+			},
+		}.Wait(t)
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, gets)
+	})
+
+	t.Run("watch", func(t *testing.T) {
+		fw := watch.NewFake()
+		go fw.Delete(deleting)
+
+		_, err := Waiter[*corev1.Pod]{
+			Kind:         "Pod",
+			Name:         "test",
+			Namespace:    "default",
+			Timeout:      5 * time.Second,
+			Interval:     time.Hour,
+			UntilDeleted: true,
+			Get:          func(ctx context.Context) (*corev1.Pod, error) { return deleting, nil },
+			Watch:        func(ctx context.Context) (watch.Interface, error) { return fw, nil },
+		}.Wait(t)
+
+		require.NoError(t, err)
+	})
+
+	t.Run("timeout reports finalizers", func(t *testing.T) {
+		_, err := Waiter[*corev1.Pod]{
+			Kind:         "Pod",
+			Name:         "test",
+			Namespace:    "default",
+			Timeout:      50 * time.Millisecond,
+			Interval:     5 * time.Millisecond,
+			UntilDeleted: true,
+			Get:          func(ctx context.Context) (*corev1.Pod, error) { return deleting, nil },
+		}.Wait(t)
+
+		require.True(t, IsTimeout(err), "expected a timeout, got %v", err)
+		assert.Contains(t, err.Error(), "waiting for Pod default/test to be deleted")
+		assert.Contains(t, err.Error(), "deletion blocked by finalizers: example.com/drain")
+	})
+}
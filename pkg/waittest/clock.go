@@ -0,0 +1,67 @@
+// Package waittest provides a virtual clock and scripted fake-client responses for unit testing
+// code built on pkg/wait. A Script decides what a fake client returns for one resource on each Get,
+// either per call or by virtual time, and a Clock passed to the wait with wait.WithClock makes the
+// wait's sleeps and timeout advance instantly. Together they let tests check that a resource goes
+// Pending → Ready after N polls, fails fast on a terminal state, or times out with the expected
+// diagnostics, in milliseconds and without flakiness.
+//
+// Example usage:
+//
+//	clock := waittest.NewClock()
+//	client := certmanager.NewTestClient(t)
+//	waittest.Prepend(t, client, "certificaterequests", waittest.NewScript(clock, "default", "my-cr").
+//	    Return(pending).
+//	    Return(pending).
+//	    Return(ready))
+//	err := certmanager.WaitForCertificateRequestReadyE(t, options, "my-cr", "default", time.Minute, wait.WithClock(clock))
+package waittest
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock is a virtual clock for waits run with wait.WithClock. Time only moves when the wait sleeps
+// or the test calls Step, and sleeping returns at once, so a ten minute wait completes instantly.
+type Clock struct {
+	mu    sync.Mutex
+	start time.Time
+	now   time.Time
+}
+
+// NewClock returns a Clock set to a fixed instant, so that runs are reproducible.
+func NewClock() *Clock {
+	start := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	return &Clock{start: start, now: start}
+}
+
+// Now returns the current virtual time.
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// After advances the clock by d and returns a channel that already holds the new time.
+func (c *Clock) After(d time.Duration) <-chan time.Time {
+	ch := make(chan time.Time, 1)
+	ch <- c.Step(d)
+	return ch
+}
+
+// Step advances the clock by d and returns the new time.
+func (c *Clock) Step(d time.Duration) time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if d > 0 {
+		c.now = c.now.Add(d)
+	}
+	return c.now
+}
+
+// Elapsed returns how far the clock has advanced since it was created.
+func (c *Clock) Elapsed() time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now.Sub(c.start)
+}
@@ -0,0 +1,177 @@
+package waittest
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/gruntwork-io/terratest/modules/testing"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+	k8stesting "k8s.io/client-go/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+// Script scripts the responses a fake client gives to Get requests for a single resource. Steps are
+// triggered either by the number of Gets made (Return, ReturnError) or by virtual time (At,
+// AtError), and each Get answers with the latest step, in the order they were added, whose trigger
+// has been reached. Until the first step is reached, Gets fall through to the fake's seeded objects.
+//
+// A Script is installed on a typed fake clientset with Prepend or Reactor, and on a
+// controller-runtime fake client with Interceptor.
+type Script struct {
+	clock     *Clock
+	namespace string
+	name      string
+
+	mu      sync.Mutex
+	steps   []step
+	returns int
+	gets    int
+}
+
+// step is one scripted response.
+type step struct {
+	// gets is the number of Gets from which a per-call step applies; timed steps use at instead.
+	gets  int
+	at    time.Duration
+	timed bool
+
+	obj runtime.Object
+	err error
+}
+
+// NewScript returns an empty Script for the resource namespace/name. Use an empty namespace for
+// cluster-scoped resources. clock may be nil when only per-call steps are used.
+func NewScript(clock *Clock, namespace, name string) *Script {
+	return &Script{clock: clock, namespace: namespace, name: name}
+}
+
+// Return adds a step that answers the next Get, and every Get after it until a later step applies,
+// with obj.
+func (s *Script) Return(obj runtime.Object) *Script {
+	return s.addCall(obj, nil)
+}
+
+// ReturnError adds a step that fails the next Get, and every Get after it until a later step
+// applies, with err.
+func (s *Script) ReturnError(err error) *Script {
+	return s.addCall(nil, err)
+}
+
+// At adds a step that answers every Get made once the clock has advanced by d with obj.
+func (s *Script) At(d time.Duration, obj runtime.Object) *Script {
+	return s.addTimed(d, obj, nil)
+}
+
+// AtError adds a step that fails every Get made once the clock has advanced by d with err.
+func (s *Script) AtError(d time.Duration, err error) *Script {
+	return s.addTimed(d, nil, err)
+}
+
+func (s *Script) addCall(obj runtime.Object, err error) *Script {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.returns++
+	s.steps = append(s.steps, step{gets: s.returns, obj: obj, err: err})
+	return s
+}
+
+func (s *Script) addTimed(d time.Duration, obj runtime.Object, err error) *Script {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.steps = append(s.steps, step{at: d, timed: true, obj: obj, err: err})
+	return s
+}
+
+// Gets returns the number of Gets the Script has answered or let through so far.
+func (s *Script) Gets() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.gets
+}
+
+// next records a Get and returns the step that answers it, or nil when none applies yet.
+func (s *Script) next() *step {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.gets++
+
+	var elapsed time.Duration
+	if s.clock != nil {
+		elapsed = s.clock.Elapsed()
+	}
+	var current *step
+	for i := range s.steps {
+		st := &s.steps[i]
+		if (st.timed && s.clock != nil && elapsed >= st.at) || (!st.timed && s.gets >= st.gets) {
+			current = st
+		}
+	}
+	return current
+}
+
+// matches reports whether a request for namespace/name is for the scripted resource.
+func (s *Script) matches(namespace, name string) bool {
+	return name == s.name && namespace == s.namespace
+}
+
+// Reactor returns a client-go reaction that answers Gets for the scripted resource. Register it for
+// the "get" verb and the resource's plural name, e.g.
+// fake.PrependReactor("get", "certificates", script.Reactor()).
+func (s *Script) Reactor() k8stesting.ReactionFunc {
+	return func(action k8stesting.Action) (bool, runtime.Object, error) {
+		get, ok := action.(k8stesting.GetAction)
+		if !ok || !s.matches(get.GetNamespace(), get.GetName()) {
+			return false, nil, nil
+		}
+		st := s.next()
+		if st == nil {
+			return false, nil, nil
+		}
+		if st.err != nil {
+			return true, nil, st.err
+		}
+		return true, st.obj.DeepCopyObject(), nil
+	}
+}
+
+// Interceptor returns controller-runtime interceptor functions that answer Gets for the scripted
+// resource, for use with fake.ClientBuilder.WithInterceptorFuncs or the NewTestClientWithInterceptors
+// helpers. Gets for other objects, or of another type than the step's object, go to the fake client.
+func (s *Script) Interceptor() interceptor.Funcs {
+	return interceptor.Funcs{
+		Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+			if !s.matches(key.Namespace, key.Name) {
+				return c.Get(ctx, key, obj, opts...)
+			}
+			st := s.next()
+			switch {
+			case st == nil:
+				return c.Get(ctx, key, obj, opts...)
+			case st.err != nil:
+				return st.err
+			case reflect.TypeOf(st.obj) != reflect.TypeOf(obj):
+				return c.Get(ctx, key, obj, opts...)
+			}
+			reflect.ValueOf(obj).Elem().Set(reflect.ValueOf(st.obj.DeepCopyObject()).Elem())
+			return nil
+		},
+	}
+}
+
+// reactable is implemented by every generated fake clientset through its embedded testing.Fake.
+type reactable interface {
+	PrependReactor(verb, resource string, reaction k8stesting.ReactionFunc)
+}
+
+// Prepend installs script on a fake clientset, such as one returned by a NewTestClient helper, for
+// Gets of resource (the plural name, e.g. "certificaterequests"). It fails the test if client is not
+// a generated fake clientset.
+func Prepend(t testing.TestingT, c any, resource string, script *Script) {
+	fake, ok := c.(reactable)
+	require.True(t, ok, "%T is not a fake clientset", c)
+	fake.PrependReactor("get", resource, script.Reactor())
+}
@@ -0,0 +1,108 @@
+package waittest
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	crfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func pod(phase corev1.PodPhase) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-pod", Namespace: "default"},
+		Status:     corev1.PodStatus{Phase: phase},
+	}
+}
+
+func TestScriptReactor(t *testing.T) {
+	clock := NewClock()
+	errUnavailable := errors.New("unavailable")
+	script := NewScript(clock, "default", "test-pod").
+		Return(pod(corev1.PodPending)).
+		ReturnError(errUnavailable).
+		At(time.Minute, pod(corev1.PodRunning))
+
+	seeded := pod(corev1.PodUnknown)
+	seeded.Name = "other-pod"
+	client := fake.NewClientset(seeded)
+	Prepend(t, client, "pods", script)
+	get := func(name string) (corev1.PodPhase, error) {
+		p, err := client.CoreV1().Pods("default").Get(context.Background(), name, metav1.GetOptions{})
+		if err != nil {
+			return "", err
+		}
+		return p.Status.Phase, nil
+	}
+
+	phase, err := get("test-pod")
+	require.NoError(t, err)
+	assert.Equal(t, corev1.PodPending, phase)
+
+	_, err = get("test-pod")
+	assert.ErrorIs(t, err, errUnavailable)
+	_, err = get("test-pod")
+	assert.ErrorIs(t, err, errUnavailable, "the last per-call step repeats")
+
+	clock.Step(time.Minute)
+	phase, err = get("test-pod")
+	require.NoError(t, err)
+	assert.Equal(t, corev1.PodRunning, phase)
+
+	phase, err = get("other-pod")
+	require.NoError(t, err)
+	assert.Equal(t, corev1.PodUnknown, phase, "other objects come from the fake")
+	assert.Equal(t, 4, script.Gets())
+}
+
+func TestScriptFallsThroughBeforeFirstStep(t *testing.T) {
+	clock := NewClock()
+	script := NewScript(clock, "default", "test-pod").At(time.Minute, pod(corev1.PodRunning))
+	client := fake.NewClientset(pod(corev1.PodPending))
+	Prepend(t, client, "pods", script)
+
+	p, err := client.CoreV1().Pods("default").Get(context.Background(), "test-pod", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, corev1.PodPending, p.Status.Phase)
+}
+
+func TestScriptInterceptor(t *testing.T) {
+	clock := NewClock()
+	script := NewScript(clock, "default", "test-pod").
+		Return(pod(corev1.PodPending)).
+		Return(pod(corev1.PodRunning))
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	seeded := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "test-pod", Namespace: "default"}, Data: map[string]string{"k": "v"}}
+	c := crfake.NewClientBuilder().WithScheme(scheme).WithObjects(seeded).WithInterceptorFuncs(script.Interceptor()).Build()
+
+	key := client.ObjectKey{Namespace: "default", Name: "test-pod"}
+	for _, want := range []corev1.PodPhase{corev1.PodPending, corev1.PodRunning, corev1.PodRunning} {
+		var p corev1.Pod
+		require.NoError(t, c.Get(context.Background(), key, &p))
+		assert.Equal(t, want, p.Status.Phase)
+	}
+
+	var cm corev1.ConfigMap
+	require.NoError(t, c.Get(context.Background(), key, &cm))
+	assert.Equal(t, "v", cm.Data["k"], "objects of another type come from the fake")
+}
+
+func TestClock(t *testing.T) {
+	clock := NewClock()
+	start := clock.Now()
+
+	now := <-clock.After(time.Second)
+	assert.Equal(t, start.Add(time.Second), now)
+	clock.Step(time.Minute)
+	assert.Equal(t, time.Minute+time.Second, clock.Elapsed())
+}